@@ -1,16 +1,42 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/company/go-product-service/internal/analytics"
 	"github.com/company/go-product-service/internal/api"
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/cache"
 	"github.com/company/go-product-service/internal/config"
 	"github.com/company/go-product-service/internal/database"
+	"github.com/company/go-product-service/internal/dbreload"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/events"
+	grpcapi "github.com/company/go-product-service/internal/grpc"
+	"github.com/company/go-product-service/internal/health"
+	"github.com/company/go-product-service/internal/idempotency"
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/jobs"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/outbox"
 	"github.com/company/go-product-service/internal/repository"
 	"github.com/company/go-product-service/internal/service"
+	"github.com/company/go-product-service/internal/snapshot"
+	"github.com/company/go-product-service/internal/telemetry"
 	"github.com/company/go-product-service/pkg/logger"
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 )
 
 // @title Product Service API
@@ -40,36 +66,371 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid configuration", err)
+	}
+	if err := logger.SetLevel(cfg.LogLevel); err != nil {
+		logger.Fatal("Invalid LOG_LEVEL", err)
+	}
+
+	// Initialize tracing; it stays off unless OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), cfg.OTLPEndpoint, cfg.ServiceName)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", err)
+	}
+	var logProvider *sdklog.LoggerProvider
+	if cfg.OTLPLogsEnabled {
+		logProvider, err = telemetry.SetupLogExport(context.Background(), cfg.ServiceName)
+		if err != nil {
+			logger.Fatal("Failed to initialize log export", err)
+		}
+		logger.ExportTo(logProvider, cfg.ServiceName)
+	}
 
-	// Initialize database
-	db, err := database.NewPostgresDB(cfg.DatabaseURL)
+	// Initialize database; the connector lets SIGHUP swap in rotated credentials,
+	// and with tracing on every statement records a SQL span
+	db, dbConnector, err := database.NewPostgresDB(cfg.DatabaseURL, database.Options{
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second,
+		Traced:          cfg.OTLPEndpoint != "",
+	})
 	if err != nil {
 		logger.Fatal("Failed to connect to database", err)
 	}
 	defer db.Close()
 
 	// Run migrations
-	if err := database.RunMigrations(cfg.DatabaseURL); err != nil {
-		logger.Fatal("Failed to run migrations", err)
+	if cfg.EnableMigrations {
+		if err := database.RunMigrations(cfg.DatabaseURL); err != nil {
+			logger.Fatal("Failed to run migrations", err)
+		}
+	}
+
+	// Catch schema drift before serving traffic
+	indexCtx, cancelIndexCheck := context.WithTimeout(context.Background(), 10*time.Second)
+	err = health.CheckIndexes(indexCtx, db, health.ExpectedIndexes, cfg.StrictIndexCheck, func(err error) {
+		logger.Warn("Index verification failed", err)
+	})
+	if err != nil {
+		logger.Fatal("Index verification failed", err)
+	}
+	cancelIndexCheck()
+
+	// Initialize the optional analytics mirror; it must never block startup
+	var mirror *analytics.Mirror
+	if cfg.AnalyticsDBURL != "" {
+		mirror, err = analytics.Open(cfg.AnalyticsDBURL, cfg.AnalyticsBufferSize)
+		if err != nil {
+			logger.Warn("Analytics mirror disabled", err)
+		} else {
+			mirror.Start()
+		}
 	}
 
-	// Initialize repositories
+	// Initialize the optional event publisher
+	var publisher *events.Publisher
+	if cfg.EventWebhookURL != "" {
+		window := time.Duration(cfg.EventBatchWindowMillis) * time.Millisecond
+		publisher = events.NewPublisher(events.NewHTTPSink(cfg.EventWebhookURL), window, cfg.EventBatchMaxSize)
+	}
+
+	// Initialize repositories, behind the Redis cache when it is enabled and reachable
 	productRepo := repository.NewProductRepository(db)
+	productRepo.SetMaxUnpaginatedRows(cfg.MaxUnpaginatedRows)
+	var replicaConnector *dbreload.Connector
+	if cfg.ReplicaDatabaseURL != "" {
+		replica, connector, err := database.NewPostgresDB(cfg.ReplicaDatabaseURL, database.Options{
+			MaxOpenConns:    cfg.MaxOpenConns,
+			MaxIdleConns:    cfg.MaxIdleConns,
+			ConnMaxLifetime: time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second,
+			Traced:          cfg.OTLPEndpoint != "",
+		})
+		if err != nil {
+			logger.Fatal("Failed to connect to the read replica", err)
+		}
+		defer replica.Close()
+		productRepo.SetReplica(replica)
+		replicaConnector = connector
+	}
+	var productCache cache.ProductRepository
+	var redisClient *redis.Client
+	if cfg.CacheEnabled {
+		redisOpts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			logger.Fatal("Invalid REDIS_URL", err)
+		}
+		redisClient = redis.NewClient(redisOpts)
+		pingCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err = redisClient.Ping(pingCtx).Err()
+		cancel()
+		if err != nil {
+			logger.Warn("Redis cache disabled", err)
+			redisClient.Close()
+			redisClient = nil
+		} else {
+			productCache = cache.NewCachedProductRepository(productRepo, redisClient, time.Duration(cfg.CacheTTLSeconds)*time.Second)
+		}
+	}
+
+	// One validator serves both APIs, so REST and gRPC reject the same input
+	validate := validator.New()
 
 	// Initialize services
-	productService := service.NewProductService(productRepo, logger)
+	var suggestionLimit int
+	if cfg.SearchSuggestionsEnabled {
+		suggestionLimit = cfg.SearchSuggestionLimit
+	}
+	var listCacheTTL time.Duration
+	if cfg.ListCacheEnabled {
+		listCacheTTL = time.Duration(cfg.ListCacheTTLSeconds) * time.Second
+	}
+	productService := service.NewProductService(productRepo, logger, service.Options{
+		Cache:           productCache,
+		Validate:        validate,
+		ImportBatchSize: cfg.ImportBatchSize,
+		ImportMaxErrors: cfg.ImportMaxErrors,
+
+		Publisher:                publisher,
+		ClearPriceWatchOnNotify:  cfg.ClearPriceWatchOnNotify,
+		Mirror:                   mirror,
+		DefaultProductActive:     cfg.DefaultProductActive,
+		AutoGenerateSKU:          cfg.AutoGenerateSKU,
+		StatsCacheTTL:            time.Duration(cfg.StatsCacheTTLSeconds) * time.Second,
+		ListCacheTTL:             listCacheTTL,
+		PriceDecimalPlaces:       cfg.PriceDecimalPlaces,
+		DefaultCurrency:          cfg.DefaultCurrency,
+		TouchParentOnChildChange: cfg.TouchParentOnChildChange,
+		PriceGuard:               models.PriceGuard{Min: cfg.MinPrice, AllowMin: cfg.MinPriceInclusive},
+		SearchSuggestionLimit:    suggestionLimit,
+		NormalizeInput:           cfg.NormalizeInput,
+		TitleCaseNames:           cfg.TitleCaseNames,
+		RejectDuplicateBatchIDs:  cfg.RejectDuplicateBatchIDs,
+		BulkCreateMode:           cfg.BulkCreateMode,
+		ReorderVelocityDays:      cfg.ReorderVelocityDays,
+		ReorderCoverDays:         cfg.ReorderCoverDays,
+		CoverageVelocityDays:     cfg.CoverageVelocityDays,
+		UpdateCoalesceWindow:     time.Duration(cfg.UpdateCoalesceWindowMillis) * time.Millisecond,
+		QualityWeights: models.QualityWeights{
+			MissingDescription: cfg.QualityWeightDescription,
+			NoImage:            cfg.QualityWeightImage,
+			ZeroStock:          cfg.QualityWeightStock,
+			NoTags:             cfg.QualityWeightTags,
+		},
+		StockActivation: models.StockActivation{
+			DeactivateOnZero:    cfg.AutoDeactivateOnZeroStock,
+			ReactivateOnRestock: cfg.AutoReactivateOnRestock,
+		},
+	})
+
+	// Initialize API server; request metrics are served at /metrics when enabled
+	var metrics *telemetry.Metrics
+	if cfg.MetricsEnabled {
+		metrics = telemetry.NewMetrics()
+	}
+	idempotencyStore, err := idempotency.NewStore(cfg.IdempotencyStore, db)
+	if err != nil {
+		logger.Fatal("Invalid IDEMPOTENCY_STORE", err)
+	}
+	var rounding *dto.Rounding
+	if cfg.RoundDisplayPrices {
+		rounding = &dto.Rounding{DefaultCurrency: cfg.DefaultCurrency, DefaultPlaces: cfg.PriceDecimalPlaces}
+	}
+	var security *middleware.Security
+	if cfg.IsProduction() {
+		security = &middleware.Security{
+			HSTSMaxAge:            time.Duration(cfg.HSTSMaxAgeSeconds) * time.Second,
+			FrameOptions:          cfg.FrameOptions,
+			ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+			RedirectHTTP:          cfg.RedirectHTTPS,
+			TrustForwardedProto:   cfg.TrustForwardedProto,
+		}
+	}
+	server := api.NewServer(productService, logger, api.Options{
+		Validate: validate,
+		Limits: middleware.Limits{
+			MaxBodyBytes: int64(cfg.MaxBodyBytes),
+			Timeout:      time.Duration(cfg.RequestTimeoutSeconds) * time.Second,
+		},
+		ImportLimits: middleware.Limits{
+			MaxBodyBytes: int64(cfg.ImportMaxBodyBytes),
+			Timeout:      time.Duration(cfg.ImportRequestTimeoutSeconds) * time.Second,
+		},
+		ImportDecimalSeparator:      cfg.ImportDecimalSeparator,
+		MaxConcurrentExports:        cfg.MaxConcurrentExports,
+		ExportQueueSize:             cfg.ExportQueueSize,
+		StrictJSON:                  cfg.StrictJSON,
+		Metrics:                     metrics,
+		Idempotency:                 idempotencyStore,
+		IdempotencyTTL:              time.Duration(cfg.IdempotencyTTLSeconds) * time.Second,
+		DisplayRounding:             rounding,
+		Security:                    security,
+		MaskIdentifiersForAnonymous: cfg.MaskIdentifiersForAnonymous,
+		HeadProduct:                 cfg.EnableHeadProduct,
+		FilterExplain:               !cfg.IsProduction(),
+		Readiness:                   health.NewReadinessChecker(db, time.Duration(cfg.ReadinessCacheMillis)*time.Millisecond),
+		Tracing:                     cfg.OTLPEndpoint != "",
+		ServiceName:                 cfg.ServiceName,
+	})
 
-	// Initialize API server
-	server := api.NewServer(productService, logger)
+	// Start background jobs
+	runner := jobs.NewRunner(cfg.JobWorkers)
+	var relaySink events.Sink
+	var kafkaSink *events.KafkaSink
+	switch {
+	case cfg.KafkaBrokers != "":
+		kafkaSink = events.NewKafkaSink(strings.Split(cfg.KafkaBrokers, ","), cfg.KafkaTopic,
+			time.Duration(cfg.KafkaWriteTimeoutSeconds)*time.Second)
+		relaySink = kafkaSink
+	case cfg.EventWebhookURL != "":
+		relaySink = events.NewHTTPSink(cfg.EventWebhookURL)
+	}
+	if relaySink != nil && cfg.OutboxPollIntervalSeconds > 0 {
+		relay := outbox.NewRelay(db, relaySink, cfg.OutboxBatchSize,
+			cfg.OutboxMaxAttempts, time.Duration(cfg.OutboxRetryBackoffSeconds)*time.Second)
+		if err := runner.Schedule(relay.Job(time.Duration(cfg.OutboxPollIntervalSeconds) * time.Second)); err != nil {
+			logger.Fatal("Failed to schedule outbox relay", err)
+		}
+	}
+	if cfg.AuditRetentionDays > 0 && cfg.AuditCompactionIntervalMinutes > 0 {
+		compactor := audit.NewCompactor(db, time.Duration(cfg.AuditRetentionDays)*24*time.Hour, cfg.AuditRollUp, 1000)
+		if err := runner.Schedule(compactor.Job(time.Duration(cfg.AuditCompactionIntervalMinutes) * time.Minute)); err != nil {
+			logger.Fatal("Failed to schedule audit compaction", err)
+		}
+		if metrics != nil {
+			metrics.CounterFunc("audit_entries_compacted_total", "Audit log entries removed by retention compaction.", compactor.Compacted)
+		}
+	}
+	if cfg.ReservationExpiryIntervalSeconds > 0 {
+		expiry := inventory.ExpiryJob(db, time.Duration(cfg.ReservationExpiryIntervalSeconds)*time.Second)
+		if err := runner.Schedule(expiry); err != nil {
+			logger.Fatal("Failed to schedule reservation expiry", err)
+		}
+	}
+	if cfg.IdempotencyPurgeIntervalMinutes > 0 {
+		purge := idempotency.PurgeJob(idempotencyStore, time.Duration(cfg.IdempotencyPurgeIntervalMinutes)*time.Minute)
+		if err := runner.Schedule(purge); err != nil {
+			logger.Fatal("Failed to schedule idempotency key purge", err)
+		}
+	}
+	if cfg.BackfillIntervalMinutes > 0 {
+		backfill := jobs.ContentHashBackfill(db, jobs.NewSQLCheckpointStore(db), cfg.ImportBatchSize,
+			time.Duration(cfg.BackfillIntervalMinutes)*time.Minute)
+		if err := runner.Schedule(backfill); err != nil {
+			logger.Fatal("Failed to schedule content hash backfill", err)
+		}
+	}
+	if cfg.SnapshotBucket != "" && cfg.SnapshotIntervalMinutes > 0 {
+		store := snapshot.NewS3Store(snapshot.S3Config{
+			Endpoint:        cfg.SnapshotEndpoint,
+			Region:          cfg.AWSRegion,
+			Bucket:          cfg.SnapshotBucket,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+		})
+		snapshotter := snapshot.NewSnapshotter(db, store, cfg.SnapshotPrefix)
+		if err := runner.Schedule(snapshotter.Job(time.Duration(cfg.SnapshotIntervalMinutes) * time.Minute)); err != nil {
+			logger.Fatal("Failed to schedule catalog snapshots", err)
+		}
+	}
+	runner.Start()
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	httpServer := &http.Server{Addr: ":" + cfg.Port, Handler: server.Handler()}
+	logger.Info("Starting server on port " + cfg.Port)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Server failed to start", err)
+		}
+	}()
+
+	// Start the gRPC server for internal consumers; an empty GRPC_PORT disables it
+	var grpcServer *grpcapi.Server
+	if cfg.GRPCPort != "" {
+		grpcServer = grpcapi.NewServer(productService, validate)
+		logger.Info("Starting gRPC server on port " + cfg.GRPCPort)
+		go func() {
+			if err := grpcServer.Start(":" + cfg.GRPCPort); err != nil {
+				logger.Fatal("gRPC server failed to start", err)
+			}
+		}()
 	}
 
-	logger.Info("Starting server on port " + port)
-	if err := server.Start(":" + port); err != nil {
-		logger.Fatal("Server failed to start", err)
+	// Reload database credentials on SIGHUP; the new DATABASE_URL and
+	// REPLICA_DATABASE_URL are read from the .env file, since a running
+	// process cannot see environment changes
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			_ = godotenv.Overload()
+			reloaded := config.Load()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := dbConnector.Reload(ctx, reloaded.DatabaseURL); err != nil {
+				logger.Error("Database credential reload failed, keeping current connections", err)
+			} else {
+				logger.Info("Database credentials reloaded")
+			}
+			if replicaConnector != nil && reloaded.ReplicaDatabaseURL != "" {
+				if err := replicaConnector.Reload(ctx, reloaded.ReplicaDatabaseURL); err != nil {
+					logger.Error("Replica credential reload failed, keeping current connections", err)
+				} else {
+					logger.Info("Replica credentials reloaded")
+				}
+			}
+			cancel()
+		}
+	}()
+
+	// Wait for a shutdown signal, then drain requests and background jobs
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("Shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	// Stop accepting requests and let in-flight ones finish before the jobs
+	// and sinks they may depend on go away
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.Warn("HTTP server did not drain before the shutdown timeout", err)
+	}
+	if grpcServer != nil {
+		grpcServer.Stop(ctx)
+	}
+	// No request can add to a coalesced change now, so record them all
+	// while the outbox relay still runs
+	productService.FlushUpdates()
+	if err := runner.Stop(ctx); err != nil {
+		logger.Warn("Background jobs did not stop before the shutdown timeout", err)
+	}
+	// Undelivered events stay pending in the outbox for the next start
+	if kafkaSink != nil {
+		if err := kafkaSink.Close(); err != nil {
+			logger.Warn("Kafka writer did not close cleanly", err)
+		}
+	}
+	if publisher != nil {
+		if err := publisher.Flush(ctx); err != nil {
+			logger.Error("Pending events could not be delivered", err)
+		}
+	}
+	if mirror != nil {
+		if err := mirror.Close(ctx); err != nil {
+			logger.Warn("Analytics mirror did not flush before the shutdown timeout", err)
+		}
+	}
+	if redisClient != nil {
+		redisClient.Close()
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("Pending spans could not be exported", err)
+	}
+	if logProvider != nil {
+		if err := logProvider.Shutdown(ctx); err != nil {
+			log.Println("Pending log records could not be exported:", err)
+		}
 	}
 }
@@ -0,0 +1,142 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	_ "github.com/lib/pq"
+)
+
+// writeTimeout bounds a single snapshot write to the analytics database
+const writeTimeout = 5 * time.Second
+
+// schema creates the denormalized snapshot table in the analytics database
+const schema = `CREATE TABLE IF NOT EXISTS product_snapshots (
+	id BIGSERIAL PRIMARY KEY,
+	product_id UUID NOT NULL,
+	event VARCHAR(50) NOT NULL,
+	sku VARCHAR(50) NOT NULL,
+	category VARCHAR(100) NOT NULL,
+	price NUMERIC(10, 2) NOT NULL,
+	stock INTEGER NOT NULL,
+	is_active BOOLEAN NOT NULL,
+	payload JSONB NOT NULL,
+	recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+)`
+
+type snapshot struct {
+	event   string
+	product models.Product
+}
+
+// Mirror asynchronously copies product snapshots to the analytics database;
+// it never blocks or fails the caller, dropping snapshots when it falls behind
+type Mirror struct {
+	db      *sql.DB
+	queue   chan snapshot
+	wg      sync.WaitGroup
+	once    sync.Once
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+// Open connects to the analytics database; the connection is established lazily,
+// so an unavailable database does not prevent startup
+func Open(url string, buffer int) (*Mirror, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	return NewMirror(db, buffer), nil
+}
+
+// NewMirror creates a mirror writing to db with a queue of the given size
+func NewMirror(db *sql.DB, buffer int) *Mirror {
+	return &Mirror{
+		db:    db,
+		queue: make(chan snapshot, buffer),
+	}
+}
+
+// Start launches the background writer
+func (m *Mirror) Start() {
+	m.wg.Add(1)
+	go m.run()
+}
+
+// Record queues a snapshot of p for the given event without blocking
+func (m *Mirror) Record(event string, p models.Product) {
+	select {
+	case m.queue <- snapshot{event: event, product: p}:
+	default:
+		m.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of snapshots discarded because the queue was full
+func (m *Mirror) Dropped() int64 {
+	return m.dropped.Load()
+}
+
+// Failed returns the number of snapshots that could not be written
+func (m *Mirror) Failed() int64 {
+	return m.failed.Load()
+}
+
+// Close stops accepting snapshots and waits for queued ones to be written or for ctx to expire
+func (m *Mirror) Close(ctx context.Context) error {
+	m.once.Do(func() { close(m.queue) })
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return m.db.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run writes queued snapshots until the queue is closed
+func (m *Mirror) run() {
+	defer m.wg.Done()
+
+	schemaReady := false
+	for s := range m.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		if !schemaReady {
+			if _, err := m.db.ExecContext(ctx, schema); err == nil {
+				schemaReady = true
+			}
+		}
+		if err := m.write(ctx, s); err != nil {
+			m.failed.Add(1)
+		}
+		cancel()
+	}
+}
+
+// write inserts a single snapshot
+func (m *Mirror) write(ctx context.Context, s snapshot) error {
+	payload, err := json.Marshal(s.product)
+	if err != nil {
+		return err
+	}
+
+	p := s.product
+	_, err = m.db.ExecContext(ctx,
+		`INSERT INTO product_snapshots (product_id, event, sku, category, price, stock, is_active, payload)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		p.ID, s.event, p.SKU, p.Category, p.Price, p.Stock, p.IsActive, payload,
+	)
+	return err
+}
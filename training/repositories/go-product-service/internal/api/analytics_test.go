@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/analytics"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestWritesSucceedWhileAnalyticsDatabaseIsDown(t *testing.T) {
+	// Nothing listens on port 1, so every analytics write fails
+	down, err := sql.Open("postgres", "postgres://analytics@127.0.0.1:1/analytics?sslmode=disable&connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirror := analytics.NewMirror(down, 10)
+	mirror.Start()
+
+	s, mock := newTestServer(t, Options{}, service.Options{Mirror: mirror})
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := mirror.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if mirror.Failed() != 2 {
+		t.Fatalf("expected both snapshots to fail against the down database, got %d", mirror.Failed())
+	}
+}
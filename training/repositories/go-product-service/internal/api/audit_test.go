@@ -0,0 +1,62 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestExportAuditRespectsDateRange(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "actor", "action", "entity_type", "entity_id", "before", "after", "created_at"}
+	mock.ExpectQuery("FROM audit_log\\s+WHERE created_at >= \\$1 AND created_at < \\$2").WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(uuid.New(), "client-1", models.AuditActionCreate, models.AuditEntityProduct, uuid.NewString(), nil, []byte(`{"name":"Desk"}`), from).
+			AddRow(uuid.New(), "client-2", models.AuditActionDelete, models.AuditEntityProduct, uuid.NewString(), []byte(`{"name":"Lamp"}`), nil, to.Add(-time.Second)))
+
+	target := "/api/v1/admin/audit/export?format=jsonl&from=" + from.Format(time.RFC3339) + "&to=" + to.Format(time.RFC3339)
+	w := serveAs(s, auth.ScopeAdmin, http.MethodGet, target, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var entries []models.AuditEntry
+	for sc := bufio.NewScanner(w.Body); sc.Scan(); {
+		var e models.AuditEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 2 || entries[0].Actor != "client-1" || string(entries[1].Before) != `{"name":"Lamp"}` {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	for _, e := range entries {
+		if e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			t.Errorf("entry at %s is outside [%s, %s)", e.CreatedAt, from, to)
+		}
+	}
+}
+
+func TestExportAuditRequiresAdminAndAValidRange(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	target := "/api/v1/admin/audit/export?from=2024-05-01T00:00:00Z&to=2024-06-01T00:00:00Z"
+	w := serveAs(s, auth.ScopeRead, http.MethodGet, target, "")
+	if w.Code != http.StatusForbidden || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("expected a JSON 403, got %d %s: %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	inverted := "/api/v1/admin/audit/export?from=2024-06-01T00:00:00Z&to=2024-05-01T00:00:00Z"
+	if w := serveAs(s, auth.ScopeAdmin, http.MethodGet, inverted, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an inverted range, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// decodeFields decodes a JSON object into its raw fields
+func decodeFields(t *testing.T, body []byte) map[string]json.RawMessage {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func TestCostMarginAndCreatorVisibleOnlyToFinance(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	cost, creator := 90.0, "client-7"
+	p.Cost, p.CreatedBy = &cost, &creator
+	// Finance and the reader are also shown coverage; the anonymous caller is not
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+			WillReturnRows(querytest.ProductRows(p))
+		if i < 2 {
+			expectNoSales(mock)
+		}
+	}
+	target := "/api/v1/products/" + p.ID.String()
+
+	finance := decodeFields(t, serveAs(s, auth.ScopeRead+","+auth.ScopeFinance, http.MethodGet, target, "").Body.Bytes())
+	if string(finance["cost"]) != "90" || string(finance["margin"]) != "0.25" {
+		t.Fatalf("expected finance to see cost and margin, got cost=%s margin=%s", finance["cost"], finance["margin"])
+	}
+	if string(finance["created_by"]) != `"client-7"` {
+		t.Fatalf("expected finance to see the creator, got %s", finance["created_by"])
+	}
+	for name, w := range map[string][]byte{
+		"reader":    serveAs(s, auth.ScopeRead, http.MethodGet, target, "").Body.Bytes(),
+		"anonymous": serve(s, http.MethodGet, target, "").Body.Bytes(),
+	} {
+		fields := decodeFields(t, w)
+		if _, ok := fields["cost"]; ok {
+			t.Errorf("%s: cost must be absent", name)
+		}
+		if _, ok := fields["margin"]; ok {
+			t.Errorf("%s: margin must be absent", name)
+		}
+		if _, ok := fields["created_by"]; ok {
+			t.Errorf("%s: created_by must be absent", name)
+		}
+	}
+}
+
+func TestCreateRecordsCaller(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	args := make([]driver.Value, 19)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[18] = "client-1"
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}`
+	w := serveAs(s, auth.ScopeWrite+","+auth.ScopeFinance, http.MethodPost, "/api/v1/products", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	if got := decodeFields(t, w.Body.Bytes())["created_by"]; string(got) != `"client-1"` {
+		t.Fatalf("expected the caller as creator, got %s", got)
+	}
+}
+
+func TestMarginFilterRequiresFinance(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	target := "/api/v1/products?min_margin=0.1&max_margin=0.3"
+	if w := serveAs(s, auth.ScopeRead, http.MethodGet, target, ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without the finance scope, got %d: %s", w.Code, w.Body)
+	}
+
+	p := testProduct()
+	cost := 100.0
+	p.Cost = &cost
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND cost IS NOT NULL AND \\(price - cost\\) / NULLIF\\(price, 0\\) >= \\$1 AND cost IS NOT NULL AND \\(price - cost\\) / NULLIF\\(price, 0\\) <= \\$2").
+		WithArgs(0.1, 0.3, 11).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(0.1, 0.3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := serveAs(s, auth.ScopeFinance, http.MethodGet, target, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list struct {
+		Products []map[string]json.RawMessage `json:"products"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 1 || string(list.Products[0]["margin"]) == "" {
+		t.Fatalf("expected one product with its margin, got %s", w.Body)
+	}
+}
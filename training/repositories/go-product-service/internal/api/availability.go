@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// checkAvailability godoc
+// @Summary Check stock availability
+// @Description Reports for each line item whether its product has enough stock net of active reservations, without reserving it
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param items body models.CheckAvailabilityRequest true "Line items"
+// @Success 200 {array} models.AvailabilityResult
+// @Router /products/check-availability [post]
+func (s *Server) checkAvailability(c *gin.Context) {
+	var req models.CheckAvailabilityRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	results, err := s.products.CheckAvailability(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// cartSnapshot godoc
+// @Summary Snapshot a cart's prices and availability
+// @Description Reports for each line item its product's price, currency, active sale price and stock net of active reservations, from one query; availability matches check-availability
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param items body models.CartSnapshotRequest true "Line items"
+// @Success 200 {array} models.CartSnapshotItem
+// @Router /products/cart-snapshot [post]
+func (s *Server) cartSnapshot(c *gin.Context) {
+	var req models.CartSnapshotRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	snapshot, err := s.products.CartSnapshot(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestCheckAvailabilityNetsReservations(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	plenty, held := testProduct(), testProduct()
+	plenty.Stock = 10
+	held.Stock, held.Reserved = 5, 4
+	missing := uuid.New()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = ANY").WillReturnRows(querytest.ProductRows(plenty, held))
+
+	body := `{"items":[
+		{"product_id":"` + plenty.ID.String() + `","qty":3},
+		{"product_id":"` + held.ID.String() + `","qty":2},
+		{"product_id":"` + missing.String() + `","qty":1}]}`
+	w := serve(s, http.MethodPost, "/api/v1/products/check-availability", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var results []models.AvailabilityResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	want := []models.AvailabilityResult{
+		{ProductID: plenty.ID, Qty: 3, Available: true, AvailableQty: 10},
+		{ProductID: held.ID, Qty: 2, Available: false, AvailableQty: 1},
+		{ProductID: missing, Qty: 1, Available: false, AvailableQty: 0},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), results)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("item %d: expected %+v, got %+v", i, want[i], results[i])
+		}
+	}
+}
+
+func TestCheckAvailabilityRejectsNonPositiveQuantities(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	body := `{"items":[{"product_id":"` + uuid.New().String() + `","qty":0}]}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/check-availability", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCartSnapshotMatchesAvailabilityAndSales(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{DefaultCurrency: "EUR"})
+	onSale, held := testProduct(), testProduct()
+	sale, started := 99.5, time.Now().Add(-time.Hour)
+	onSale.Stock, onSale.SalePrice, onSale.SaleStartsAt = 10, &sale, &started
+	usd := "USD"
+	held.Stock, held.Reserved, held.Currency = 5, 4, &usd
+	missing := uuid.New()
+	body := `{"items":[
+		{"product_id":"` + onSale.ID.String() + `","qty":3},
+		{"product_id":"` + held.ID.String() + `","qty":2},
+		{"product_id":"` + missing.String() + `","qty":1}]}`
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = ANY").WillReturnRows(querytest.ProductRows(onSale, held))
+	w := serve(s, http.MethodPost, "/api/v1/products/check-availability", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var availability []models.AvailabilityResult
+	if err := json.Unmarshal(w.Body.Bytes(), &availability); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = ANY").WillReturnRows(querytest.ProductRows(onSale, held))
+	w = serve(s, http.MethodPost, "/api/v1/products/cart-snapshot", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var snapshot []models.CartSnapshotItem
+	if err := json.Unmarshal(w.Body.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot) != len(availability) {
+		t.Fatalf("expected %d lines, got %s", len(availability), w.Body)
+	}
+	for i, line := range snapshot {
+		if line.AvailableQty != availability[i].AvailableQty || line.InStock != availability[i].Available {
+			t.Errorf("line %d: snapshot %+v disagrees with availability %+v", i, line, availability[i])
+		}
+	}
+	if first := snapshot[0]; first.Price != 120 || first.Currency != "EUR" || first.SalePrice == nil || *first.SalePrice != sale {
+		t.Errorf("expected the sale line at 120 EUR on sale for 99.5, got %+v", first)
+	}
+	if second := snapshot[1]; second.Currency != "USD" || second.SalePrice != nil {
+		t.Errorf("expected the held line in USD without a sale, got %+v", second)
+	}
+	if third := snapshot[2]; third.Found {
+		t.Errorf("expected the missing product to be reported as not found, got %+v", third)
+	}
+}
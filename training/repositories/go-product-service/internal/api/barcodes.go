@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// lookupBarcodes godoc
+// @Summary Look up products by barcode
+// @Description Returns the products carrying any of the scanned barcodes, ignoring leading zeros, and the barcodes that matched none
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param barcodes body models.BarcodeLookupRequest true "Scanned barcodes"
+// @Success 200 {object} dto.BarcodeLookup
+// @Router /products/by-barcodes [post]
+func (s *Server) lookupBarcodes(c *gin.Context) {
+	var req models.BarcodeLookupRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	result, err := s.products.LookupBarcodes(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewBarcodeLookup(c.Request.Context(), result))
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestLookupBarcodesReportsMatchedAndUnmatched(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	stored := "0012345"
+	p.Barcode = &stored
+	mock.ExpectQuery("SELECT .+ FROM products WHERE .+ltrim\\(barcode, '0'\\) = ANY").
+		WithArgs(`{"12345","99999"}`).
+		WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodPost, "/api/v1/products/by-barcodes", `{"barcodes":["12345","000012345","99999"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.BarcodeLookup
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Products) != 1 || got.Products[0].ID != p.ID {
+		t.Fatalf("expected the zero-padded product to match, got %+v", got.Products)
+	}
+	if len(got.Unmatched) != 1 || got.Unmatched[0] != "99999" {
+		t.Fatalf("expected only 99999 unmatched, got %v", got.Unmatched)
+	}
+}
+
+func TestLookupBarcodesRequiresBarcodes(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/by-barcodes", `{"barcodes":[]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// bulkGetProducts godoc
+// @Summary Get several products by ID
+// @Description Returns each live product once, however often its ID is repeated, and the IDs that matched none; with REJECT_DUPLICATE_BATCH_IDS a repeated ID is a 400
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.BulkIDsRequest true "Product IDs"
+// @Success 200 {object} dto.BulkGet
+// @Router /products/bulk-get [post]
+func (s *Server) bulkGetProducts(c *gin.Context) {
+	var req models.BulkIDsRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	result, err := s.products.GetProducts(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewBulkGet(c.Request.Context(), result))
+}
+
+// bulkDeleteProducts godoc
+// @Summary Delete several products by ID
+// @Description Soft-deletes each product once, however often its ID is repeated, and reports the IDs that matched no live product; with REJECT_DUPLICATE_BATCH_IDS a repeated ID is a 400
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.BulkIDsRequest true "Product IDs"
+// @Success 200 {object} models.BulkDeleteResult
+// @Router /products/bulk-delete [post]
+func (s *Server) bulkDeleteProducts(c *gin.Context) {
+	var req models.BulkIDsRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	result, err := s.products.DeleteProducts(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestBulkGetCoalescesRepeatedIDs(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p, missing := testProduct(), uuid.New()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = ANY\\(\\$1\\)").
+		WithArgs(`{"` + p.ID.String() + `","` + missing.String() + `"}`).
+		WillReturnRows(querytest.ProductRows(p))
+
+	body := `{"ids":["` + p.ID.String() + `","` + missing.String() + `","` + p.ID.String() + `"]}`
+	w := serve(s, http.MethodPost, "/api/v1/products/bulk-get", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.BulkGet
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Products) != 1 || got.Products[0].ID != p.ID || len(got.NotFound) != 1 || got.NotFound[0] != missing {
+		t.Fatalf("unexpected bulk get: %s", w.Body)
+	}
+}
+
+func TestBulkDeleteDeletesRepeatedIDsOnce(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p, missing := testProduct(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at = NOW\\(\\)").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at = NOW\\(\\)").WithArgs(missing).
+		WillReturnRows(sqlmock.NewRows(querytest.ProductColumns()))
+	mock.ExpectRollback()
+
+	body := `{"ids":["` + p.ID.String() + `","` + p.ID.String() + `","` + missing.String() + `"]}`
+	w := serve(s, http.MethodPost, "/api/v1/products/bulk-delete", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got models.BulkDeleteResult
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Deleted) != 1 || got.Deleted[0] != p.ID || len(got.NotFound) != 1 || got.NotFound[0] != missing {
+		t.Fatalf("unexpected bulk delete: %s", w.Body)
+	}
+}
+
+func TestBulkGetRejectsRepeatedIDsWhenConfigured(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{RejectDuplicateBatchIDs: true})
+	id := uuid.New()
+	if w := serve(s, http.MethodPost, "/api/v1/products/bulk-get", `{"ids":["`+id.String()+`","`+id.String()+`"]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a repeated ID, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/company/go-product-service/internal/decode"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// bindJSON decodes and validates the request body into dst, writing a 400
+// response and returning false when it is invalid
+func (s *Server) bindJSON(c *gin.Context, dst interface{}) bool {
+	return s.decodeJSON(c, dst) && s.validateStruct(c, dst)
+}
+
+// decodeJSON decodes the request body into dst without validating it
+func (s *Server) decodeJSON(c *gin.Context, dst interface{}) bool {
+	if err := decode.JSON(c.Request.Body, dst, s.strictJSON(c)); err != nil {
+		var unknown *decode.UnknownFieldsError
+		var tooLarge *http.MaxBytesError
+		if !errors.As(err, &unknown) && !errors.As(err, &tooLarge) {
+			err = fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+		s.respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// validateStruct validates dst, writing a 400 response when it is invalid
+func (s *Server) validateStruct(c *gin.Context, dst interface{}) bool {
+	if err := s.validate.Struct(dst); err != nil {
+		s.respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// bindQuery binds and validates the query string into dst
+func (s *Server) bindQuery(c *gin.Context, dst interface{}) bool {
+	if err := c.ShouldBindQuery(dst); err != nil {
+		s.respondError(c, fmt.Errorf("%w: %v", errBadRequest, err))
+		return false
+	}
+	if err := s.validate.Struct(dst); err != nil {
+		s.respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// pathID parses a UUID path parameter
+func (s *Server) pathID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		s.respondError(c, fmt.Errorf("%w: invalid %s", errBadRequest, name))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// strictJSON reports whether unknown body fields are rejected, either for
+// every request or because the client asked for it with decode.StrictHeader
+func (s *Server) strictJSON(c *gin.Context) bool {
+	if s.opts.StrictJSON {
+		return true
+	}
+	strict, _ := strconv.ParseBool(c.GetHeader(decode.StrictHeader))
+	return strict
+}
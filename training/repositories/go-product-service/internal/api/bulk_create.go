@@ -0,0 +1,40 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// bulkCreateProducts godoc
+// @Summary Create several products
+// @Description Validates each product like a single create and inserts them in one transaction, reporting every item's new ID or error; all_or_nothing rolls back on any item error, best_effort keeps the valid items
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param mode query string false "all_or_nothing or best_effort; defaults to BULK_CREATE_MODE"
+// @Param products body []models.CreateProductRequest true "Products"
+// @Success 200 {object} models.BulkCreateResponse
+// @Router /products/bulk [post]
+func (s *Server) bulkCreateProducts(c *gin.Context) {
+	var q models.BulkCreateQuery
+	if !s.bindQuery(c, &q) {
+		return
+	}
+	var reqs []models.CreateProductRequest
+	if !s.decodeJSON(c, &reqs) {
+		return
+	}
+	if len(reqs) == 0 || len(reqs) > models.MaxBulkCreateItems {
+		s.respondError(c, fmt.Errorf("%w: a bulk create takes 1 to %d products", errBadRequest, models.MaxBulkCreateItems))
+		return
+	}
+	resp, err := s.products.BulkCreateProducts(c.Request.Context(), reqs, q.Mode)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
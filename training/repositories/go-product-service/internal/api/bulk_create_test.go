@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/lib/pq"
+)
+
+// bulkBody is a batch with one valid item, one invalid item, a SKU repeated
+// within the batch and a SKU that already exists
+const bulkBody = `[
+	{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"},
+	{"name":"","price":10,"category":"furniture","sku":"BAD-1"},
+	{"name":"Desk again","price":120,"category":"furniture","sku":"DESK-1"},
+	{"name":"Lamp","price":20,"category":"lighting","sku":"LAMP-1"}
+]`
+
+func expectBulkInserts(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO products").WithArgs(sqlmock.AnyArg(), "Desk", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		sqlmock.AnyArg(), "DESK-1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO products").WillReturnError(&pq.Error{Code: "23505", Constraint: "products_sku_key"})
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT bulk_item").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func decodeBulk(t *testing.T, body []byte) models.BulkCreateResponse {
+	t.Helper()
+	var resp models.BulkCreateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestBulkCreateBestEffortKeepsValidItems(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	expectBulkInserts(mock)
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/bulk?mode=best_effort", bulkBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	resp := decodeBulk(t, w.Body.Bytes())
+	if !resp.Committed || resp.Created != 1 || resp.Failed != 3 {
+		t.Fatalf("unexpected totals: %s", w.Body)
+	}
+	r := resp.Results
+	if !r[0].Success || r[0].ProductID == nil || r[1].Success || !strings.Contains(r[2].Error, "duplicates item 0") ||
+		!strings.Contains(r[3].Error, `"LAMP-1" already exists`) {
+		t.Fatalf("unexpected item results: %s", w.Body)
+	}
+}
+
+func TestBulkCreateAllOrNothingRollsBack(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{BulkCreateMode: models.BulkModeAllOrNothing})
+	expectBulkInserts(mock)
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/bulk", bulkBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	resp := decodeBulk(t, w.Body.Bytes())
+	if resp.Committed || resp.Created != 0 || resp.Mode != models.BulkModeAllOrNothing || resp.Results[0].ProductID != nil {
+		t.Fatalf("expected the batch rolled back, got %s", w.Body)
+	}
+}
+
+func TestBulkCreateRejectsUnknownModeAndEmptyBatch(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/bulk?mode=partial", bulkBody); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown mode, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodPost, "/api/v1/products/bulk", `[]`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an empty batch, got %d: %s", w.Code, w.Body)
+	}
+}
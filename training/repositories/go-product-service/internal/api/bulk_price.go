@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// updateBulkPrices godoc
+// @Summary Adjust prices in bulk
+// @Description Applies a percent, amount or set adjustment to every product in the category or ID list; prices the minimum-price guard refuses are left unchanged and counted as rejected
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param change body models.BulkPriceUpdateRequest true "Price change"
+// @Success 200 {object} models.BulkPricePreview
+// @Router /products/bulk-price-update [post]
+func (s *Server) updateBulkPrices(c *gin.Context) {
+	var req models.BulkPriceUpdateRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	summary, err := s.products.UpdateBulkPrices(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// previewBulkPrices godoc
+// @Summary Preview a bulk price adjustment
+// @Description Reports what the same request to /products/bulk-price-update would change, with a sample of affected products, without writing
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param change body models.BulkPriceUpdateRequest true "Price change"
+// @Success 200 {object} models.BulkPricePreview
+// @Router /products/bulk-price-update/preview [post]
+func (s *Server) previewBulkPrices(c *gin.Context) {
+	var req models.BulkPriceUpdateRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	preview, err := s.products.PreviewBulkPriceUpdate(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// expectPriceWrite expects the bulk apply to store price for one product
+func expectPriceWrite(mock sqlmock.Sqlmock, p models.Product, price float64) {
+	args := make([]driver.Value, 18)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[0], args[3] = p.ID, price
+	mock.ExpectQuery("UPDATE products SET name").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestBulkPricePreviewMatchesApply(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	cut, guarded, big := testProduct(), testProduct(), testProduct()
+	cut.Price, guarded.Price, big.Price = 10, 5, 20
+	body := `{"category":"furniture","mode":"amount","value":-6}`
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE .+category = \\$1 ORDER BY id ASC$").
+		WithArgs("furniture").WillReturnRows(querytest.ProductRows(cut, guarded, big))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE .+category = \\$1 ORDER BY id ASC FOR UPDATE").
+		WithArgs("furniture").WillReturnRows(querytest.ProductRows(cut, guarded, big))
+	expectPriceWrite(mock, cut, 4)
+	expectPriceWrite(mock, big, 14)
+	mock.ExpectCommit()
+
+	decode := func(target string) models.BulkPricePreview {
+		t.Helper()
+		w := serve(s, http.MethodPost, target, body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", target, w.Code, w.Body)
+		}
+		var got models.BulkPricePreview
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	preview := decode("/api/v1/products/bulk-price-update/preview")
+	applied := decode("/api/v1/products/bulk-price-update")
+
+	if preview.Matched != 3 || preview.Changed != 2 || preview.Rejected != 1 {
+		t.Fatalf("unexpected preview summary: %+v", preview)
+	}
+	if !reflect.DeepEqual(preview, applied) {
+		t.Fatalf("preview %+v differs from apply %+v", preview, applied)
+	}
+}
+
+func TestBulkPriceUpdateRequiresTargets(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/bulk-price-update/preview", `{"mode":"percent","value":10}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestBulkPriceUpdatePublishesOneBatchedDelivery(t *testing.T) {
+	sink := &recordingSink{}
+	s, mock := newTestServer(t, Options{}, service.Options{Publisher: events.NewPublisher(sink, 0, 1)})
+	products := []models.Product{testProduct(), testProduct(), testProduct()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE .+category = \\$1 ORDER BY id ASC FOR UPDATE").
+		WithArgs("furniture").WillReturnRows(querytest.ProductRows(products...))
+	for _, p := range products {
+		expectPriceWrite(mock, p, 100)
+	}
+	mock.ExpectCommit()
+	for _, p := range products {
+		mock.ExpectQuery("SELECT .+ FROM price_watches WHERE product_id = \\$1").WithArgs(p.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "email", "created_at"}).
+				AddRow(uuid.New(), p.ID, "ada@example.com", time.Now()))
+	}
+
+	body := `{"category":"furniture","mode":"amount","value":-20}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/bulk-price-update", body); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if len(sink.deliveries) != 1 || len(sink.deliveries[0]) != len(products) {
+		t.Fatalf("expected one delivery of %d price drops, got %+v", len(products), sink.deliveries)
+	}
+	for i, e := range sink.deliveries[0] {
+		if e.Type != models.EventPriceDropped || e.ProductID != products[i].ID {
+			t.Errorf("event %d: expected a price drop for %s, got %+v", i, products[i].ID, e)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// renameCategory godoc
+// @Summary Rename a category
+// @Description Renames the category on every product in one audited transaction; the new name must not be in use
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param rename body models.RenameCategoryRequest true "Old and new names"
+// @Success 200 {object} models.RenameCategoryResult
+// @Router /categories/rename [post]
+func (s *Server) renameCategory(c *gin.Context) {
+	var req models.RenameCategoryRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	result, err := s.products.RenameCategory(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// categoryUsage godoc
+// @Summary Count the products using a category
+// @Description Active and total live products in the category; categories are product attributes, so an unused name reports zero
+// @Tags categories
+// @Produce json
+// @Param name path string true "Category name"
+// @Success 200 {object} models.CategoryUsage
+// @Router /categories/{name}/usage [get]
+func (s *Server) categoryUsage(c *gin.Context) {
+	usage, err := s.products.CategoryUsage(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
+// deleteCategory godoc
+// @Summary Delete a category
+// @Description Retires the category in one audited transaction, moving its products to reassign_to; a category still in use without reassign_to is refused with 409 and its usage
+// @Tags categories
+// @Produce json
+// @Param name path string true "Category name"
+// @Param reassign_to query string false "Category to move remaining products to"
+// @Success 200 {object} models.DeleteCategoryResult
+// @Router /categories/{name} [delete]
+func (s *Server) deleteCategory(c *gin.Context) {
+	var q models.DeleteCategoryQuery
+	if !s.bindQuery(c, &q) {
+		return
+	}
+	result, err := s.products.DeleteCategory(c.Request.Context(), c.Param("name"), &q)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestRenameCategoryMovesEveryProductAndAudits(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("office").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("UPDATE products SET category = \\$2").WithArgs("furniture", "office").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()).AddRow(uuid.New()).AddRow(uuid.New()))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), auth.AnonymousCaller, models.AuditActionRenameCategory, models.AuditEntityCategory, "furniture",
+			`{"category":"furniture"}`, `{"old":"furniture","new":"office","affected":4}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/categories/rename", `{"old":"furniture","new":"office"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var result models.RenameCategoryResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result != (models.RenameCategoryResult{Old: "furniture", New: "office", Affected: 4}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestRenameCategoryRejectsTakenName(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("lighting").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if w := serve(s, http.MethodPost, "/api/v1/categories/rename", `{"old":"furniture","new":"lighting"}`); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodPost, "/api/v1/categories/rename", `{"old":"furniture","new":"furniture"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unchanged name, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCategoryUsageCountsProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("COUNT\\(\\*\\) FILTER \\(WHERE is_active\\) AS active, COUNT\\(\\*\\) AS total").WithArgs("furniture").
+		WillReturnRows(sqlmock.NewRows([]string{"active", "total"}).AddRow(3, 5))
+	mock.ExpectQuery("COUNT\\(\\*\\) FILTER \\(WHERE is_active\\) AS active, COUNT\\(\\*\\) AS total").WithArgs("retired").
+		WillReturnRows(sqlmock.NewRows([]string{"active", "total"}).AddRow(0, 0))
+
+	for _, want := range []models.CategoryUsage{{Category: "furniture", Active: 3, Total: 5}, {Category: "retired"}} {
+		w := serve(s, http.MethodGet, "/api/v1/categories/"+want.Category+"/usage", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", want.Category, w.Code, w.Body)
+		}
+		var got models.CategoryUsage
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	}
+}
+
+func TestDeleteCategoryInUseIsConflict(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("COUNT\\(\\*\\) FILTER \\(WHERE is_active\\) AS active, COUNT\\(\\*\\) AS total").WithArgs("furniture").
+		WillReturnRows(sqlmock.NewRows([]string{"active", "total"}).AddRow(3, 5))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodDelete, "/api/v1/categories/furniture", "")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+	var body struct {
+		Usage models.CategoryUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if want := (models.CategoryUsage{Category: "furniture", Active: 3, Total: 5}); body.Usage != want {
+		t.Fatalf("expected usage %+v, got %+v", want, body.Usage)
+	}
+
+	if w := serve(s, http.MethodDelete, "/api/v1/categories/furniture?reassign_to=furniture", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for reassigning to itself, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteUnusedCategory(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("COUNT\\(\\*\\) FILTER \\(WHERE is_active\\) AS active, COUNT\\(\\*\\) AS total").WithArgs("retired").
+		WillReturnRows(sqlmock.NewRows([]string{"active", "total"}).AddRow(0, 0))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), auth.AnonymousCaller, models.AuditActionDeleteCategory, models.AuditEntityCategory, "retired",
+			`{"category":"retired","active":0,"total":0}`, `{"category":"retired","affected":0}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/categories/retired", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteCategoryReassignsProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("COUNT\\(\\*\\) FILTER \\(WHERE is_active\\) AS active, COUNT\\(\\*\\) AS total").WithArgs("furniture").
+		WillReturnRows(sqlmock.NewRows([]string{"active", "total"}).AddRow(1, 2))
+	mock.ExpectQuery("UPDATE products SET category = \\$2, .+ AND deleted_at IS NULL").WithArgs("furniture", "office").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), auth.AnonymousCaller, models.AuditActionDeleteCategory, models.AuditEntityCategory, "furniture",
+			`{"category":"furniture","active":1,"total":2}`, `{"category":"furniture","reassign_to":"office","affected":2}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodDelete, "/api/v1/categories/furniture?reassign_to=office", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var result models.DeleteCategoryResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result != (models.DeleteCategoryResult{Category: "furniture", ReassignTo: "office", Affected: 2}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
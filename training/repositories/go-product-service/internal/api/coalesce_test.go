@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestRapidUpdatesAreCoalescedIntoOneEvent(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{UpdateCoalesceWindow: time.Minute})
+	p := testProduct()
+	for i := 0; i < 3; i++ {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+		mock.ExpectQuery("UPDATE products SET name").WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+		mock.ExpectCommit()
+	}
+	for _, body := range []string{`{"price":99}`, `{"price":98}`, `{"price":97}`} {
+		if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), body); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expected each update persisted without an event: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	s.products.FlushUpdates()
+}
+
+func TestDeleteRecordsPendingCoalescedUpdateFirst(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{UpdateCoalesceWindow: time.Minute})
+	p := testProduct()
+	target := "/api/v1/products/" + p.ID.String()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET name").WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectCommit()
+	if w := serve(s, http.MethodPut, target, `{"price":99}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(models.EventProductUpdated, p.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(models.EventProductDeleted, p.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	if w := serve(s, http.MethodDelete, target, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+
+	// Nothing is left for the window to record after the delete
+	s.products.FlushUpdates()
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// saveColumnMapping godoc
+// @Summary Save a column mapping
+// @Description Stores a named field to CSV header layout for imports and exports, replacing one with the same name
+// @Tags column-mappings
+// @Accept json
+// @Produce json
+// @Param mapping body models.CreateColumnMappingRequest true "Mapping"
+// @Success 200 {object} models.ColumnMapping
+// @Router /column-mappings [post]
+func (s *Server) saveColumnMapping(c *gin.Context) {
+	var req models.CreateColumnMappingRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	m, err := s.products.SaveColumnMapping(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
+
+// listColumnMappings godoc
+// @Summary List column mappings
+// @Tags column-mappings
+// @Produce json
+// @Success 200 {array} models.ColumnMapping
+// @Router /column-mappings [get]
+func (s *Server) listColumnMappings(c *gin.Context) {
+	mappings, err := s.products.ListColumnMappings(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, mappings)
+}
+
+// getColumnMapping godoc
+// @Summary Get a column mapping
+// @Tags column-mappings
+// @Produce json
+// @Param name path string true "Mapping name"
+// @Success 200 {object} models.ColumnMapping
+// @Router /column-mappings/{name} [get]
+func (s *Server) getColumnMapping(c *gin.Context) {
+	m, err := s.products.GetColumnMapping(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
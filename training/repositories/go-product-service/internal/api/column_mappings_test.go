@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// supplierColumns is a stored mapping in a non-default column order
+const supplierColumns = `[{"field":"sku","header":"Article No"},{"field":"price","header":"Unit Price"},` +
+	`{"field":"name","header":"Title"},{"field":"category","header":"Group"}]`
+
+func mappingRows(name, columns string) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows([]string{"id", "name", "columns", "created_at", "updated_at"}).
+		AddRow(uuid.New().String(), name, []byte(columns), now, now)
+}
+
+func TestImportWithStoredMappingInNonDefaultOrder(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{DefaultProductActive: true})
+	mock.ExpectQuery("SELECT .+ FROM column_mappings WHERE name = \\$1").WithArgs("supplier").
+		WillReturnRows(mappingRows("supplier", supplierColumns))
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WithArgs(sqlmock.AnyArg(), "Oak desk", "", 120.5, nil, "furniture", "DESK-1", nil, 0, true, []byte("{}"), sqlmock.AnyArg(), auth.AnonymousCaller).
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectCommit()
+
+	body := "Group,Unit Price,Article No,Title\nfurniture,120.5,DESK-1,Oak desk\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import?mapping=supplier", "text/csv", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Failed != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestImportWithUnknownMappingIsNotFound(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("SELECT .+ FROM column_mappings").WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "columns", "created_at", "updated_at"}))
+
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import?mapping=missing", "text/csv", "name\n")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestExportWithStoredMapping(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM column_mappings").WithArgs("partner").
+		WillReturnRows(mappingRows("partner", `[{"field":"sku","header":"Ref"},{"field":"price","header":"Net"}]`))
+	mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/export?mapping=partner", "")
+	if w.Code != http.StatusOK || w.Body.String() != "Ref,Net\nDESK-1,120\n" {
+		t.Fatalf("unexpected export %d: %q", w.Code, w.Body)
+	}
+}
+
+func TestSaveColumnMappingValidatesFields(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("INSERT INTO column_mappings").WithArgs("supplier", sqlmock.AnyArg()).
+		WillReturnRows(mappingRows("supplier", supplierColumns))
+
+	if w := serve(s, http.MethodPost, "/api/v1/column-mappings", `{"name":"supplier","columns":`+supplierColumns+`}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	body := `{"name":"bad","columns":[{"field":"colour","header":"Colour"}]}`
+	if w := serve(s, http.MethodPost, "/api/v1/column-mappings", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown field, got %d: %s", w.Code, w.Body)
+	}
+}
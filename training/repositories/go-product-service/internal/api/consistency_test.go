@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/repository"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/company/go-product-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// newReplicatedTestServer returns a server whose reads may go to a mocked
+// replica besides the mocked primary
+func newReplicatedTestServer(t *testing.T) (*Server, sqlmock.Sqlmock, sqlmock.Sqlmock) {
+	t.Helper()
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		for _, mock := range []sqlmock.Sqlmock{primaryMock, replicaMock} {
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		}
+		primary.Close()
+		replica.Close()
+	})
+	repo := repository.NewProductRepository(primary)
+	repo.SetReplica(replica)
+	log := logger.New(zap.NewNop(), zap.NewAtomicLevel())
+	return NewServer(service.NewProductService(repo, log, service.Options{}), log, Options{}), primaryMock, replicaMock
+}
+
+func TestStrongConsistencyReadsFromPrimary(t *testing.T) {
+	s, primary, replica := newReplicatedTestServer(t)
+	p := testProduct()
+	target := "/api/v1/products/" + p.ID.String()
+
+	replica.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	if w := serve(s, http.MethodGet, target, ""); w.Code != http.StatusOK {
+		t.Fatalf("replica read: expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	primary.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(middleware.ConsistencyHeader, "strong")
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("strong read: expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateReadsFromPrimary(t *testing.T) {
+	s, primary, _ := newReplicatedTestServer(t)
+	p := testProduct()
+	primary.ExpectBegin()
+	primary.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	primary.ExpectQuery("UPDATE products SET name").WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	primary.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	primary.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	primary.ExpectCommit()
+
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), `{"price":99.5}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
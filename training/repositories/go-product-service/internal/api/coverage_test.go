@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// expectNoSales expects the sales velocity lookup behind coverage_days and
+// answers that nothing sold in the window
+func expectNoSales(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM stock_movements WHERE product_id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "daily_velocity"}))
+}
+
+func TestCoverageDaysFromSalesVelocity(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{CoverageVelocityDays: 7})
+	selling, idle := testProduct(), testProduct()
+	selling.Stock, selling.Reserved = 10, 1
+	idle.SKU = "DESK-2"
+	mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(selling, idle))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	// 9 available at 1.5 a day lasts 6 days; the idle product sold nothing in the 7-day window
+	mock.ExpectQuery("FROM stock_movements WHERE product_id = ANY\\(\\$1\\)").
+		WithArgs("{\""+selling.ID.String()+"\",\""+idle.ID.String()+"\"}", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "daily_velocity"}).AddRow(selling.ID, 1.5))
+
+	w := serveAs(s, auth.ScopeRead, http.MethodGet, "/api/v1/products", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 2 {
+		t.Fatalf("expected both products, got %s", w.Body)
+	}
+	if got := list.Products[0].CoverageDays; got == nil || *got != 6 {
+		t.Fatalf("expected 6 days of coverage, got %v", got)
+	}
+	if got := list.Products[1].CoverageDays; got != nil {
+		t.Fatalf("expected infinite coverage to be omitted, got %v", *got)
+	}
+}
+
+func TestCoverageDaysHiddenFromAnonymousCallers(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+
+	fields := decodeFields(t, serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "").Body.Bytes())
+	if _, ok := fields["coverage_days"]; ok {
+		t.Fatal("coverage_days must be absent for anonymous callers")
+	}
+}
+
+func TestListProductsByMaxCoverageDays(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{CoverageVelocityDays: 7})
+	mock.ExpectQuery("stock - reserved <= \\$2 \\* \\(SELECT -SUM\\(m.delta\\)::float8 / \\$1::int FROM stock_movements m").
+		WithArgs(7, 5.0, 11).WillReturnRows(querytest.ProductRows())
+	mock.ExpectQuery("SELECT COUNT").WithArgs(7, 5.0).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if w := serve(s, http.MethodGet, "/api/v1/products?max_coverage_days=5", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
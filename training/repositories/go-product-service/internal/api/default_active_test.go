@@ -0,0 +1,53 @@
+package api
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// expectCreateActive expects a single-product create storing is_active as active
+func expectCreateActive(mock sqlmock.Sqlmock, active bool) {
+	args := make([]driver.Value, 19)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[14] = active
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestCreateProductUsesConfiguredDefaultActive(t *testing.T) {
+	const body = `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}`
+	for _, active := range []bool{true, false} {
+		s, mock := newTestServer(t, Options{}, service.Options{DefaultProductActive: active})
+		expectCreateActive(mock, active)
+		if w := serve(s, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusCreated {
+			t.Fatalf("default %v: expected 201, got %d: %s", active, w.Code, w.Body)
+		}
+	}
+}
+
+func TestOnlyAdminsOverrideDefaultActive(t *testing.T) {
+	const body = `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","is_active":true}`
+	s, mock := newTestServer(t, Options{}, service.Options{DefaultProductActive: false})
+	expectCreateActive(mock, false)
+	expectCreateActive(mock, true)
+
+	if w := serveAs(s, auth.ScopeWrite, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for a writer, got %d: %s", w.Code, w.Body)
+	}
+	if w := serveAs(s, auth.ScopeWrite+","+auth.ScopeAdmin, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for an admin, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// createDraft godoc
+// @Summary Start a draft product
+// @Description Stores an inactive draft; only the name is required until it is published
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param draft body models.CreateDraftRequest true "Draft"
+// @Success 201 {object} dto.Product
+// @Router /products/draft [post]
+func (s *Server) createDraft(c *gin.Context) {
+	var req models.CreateDraftRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	p, err := s.products.CreateDraft(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewProduct(c.Request.Context(), p))
+}
+
+// publishDraft godoc
+// @Summary Publish a draft product
+// @Description Validates the draft like a create, including price and SKU, and makes it active
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.Product
+// @Router /products/{id}/publish [post]
+func (s *Server) publishDraft(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	p, err := s.products.PublishDraft(c.Request.Context(), id)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProduct(c.Request.Context(), p))
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// draftSKU matches the placeholder SKU of a draft started without one
+type draftSKU struct{}
+
+func (draftSKU) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, "draft-")
+}
+
+func testDraft() models.Product {
+	p := testProduct()
+	p.Price, p.IsActive, p.Status = 0, false, models.StatusDraft
+	p.SKU = models.DraftSKU(p.ID)
+	return p
+}
+
+func TestCreateDraftWithoutPrice(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	args := make([]driver.Value, 19)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[1], args[3], args[10], args[14], args[15] = "Desk", 0.0, draftSKU{}, false, models.StatusDraft
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/draft", `{"name":"Desk"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusDraft || got.IsActive {
+		t.Fatalf("expected an inactive draft, got %+v", got)
+	}
+}
+
+func TestCreateDraftRequiresName(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/draft", `{"price":10}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a name, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestPublishDraftRequiresPriceAndSKU(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	draft := testDraft()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(draft.ID).
+		WillReturnRows(querytest.ProductRows(draft))
+	if w := serve(s, http.MethodPost, "/api/v1/products/"+draft.ID.String()+"/publish", ""); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "SKU") {
+		t.Fatalf("expected 400 for the placeholder SKU, got %d: %s", w.Code, w.Body)
+	}
+
+	draft.SKU = "DESK-1"
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(draft.ID).
+		WillReturnRows(querytest.ProductRows(draft))
+	if w := serve(s, http.MethodPost, "/api/v1/products/"+draft.ID.String()+"/publish", ""); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "list price 0") {
+		t.Fatalf("expected 400 for the missing price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestPublishDraftOnceComplete(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	draft := testDraft()
+	draft.SKU, draft.Price = "DESK-1", 120
+	published := draft
+	published.IsActive, published.Status = true, models.StatusActive
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(draft.ID).
+		WillReturnRows(querytest.ProductRows(draft))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").WithArgs(draft.ID).
+		WillReturnRows(querytest.ProductRows(draft))
+	mock.ExpectQuery("UPDATE products SET status = 'active', is_active = TRUE").WithArgs(draft.ID).
+		WillReturnRows(querytest.ProductRows(published))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/"+draft.ID.String()+"/publish", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusActive || !got.IsActive {
+		t.Fatalf("expected the published product to be active, got %+v", got)
+	}
+}
+
+func TestPublishRejectsLiveProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	if w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/publish", ""); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a live product, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/bulk"
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/decode"
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/outbox"
+	"github.com/company/go-product-service/internal/productio"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/company/go-product-service/internal/sku"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// errBadRequest marks request decoding failures that carry no typed error
+var errBadRequest = errors.New("invalid request")
+
+// errUnsupportedMediaType is returned for a request body in a format the route does not accept
+var errUnsupportedMediaType = errors.New("unsupported media type")
+
+// statusFor maps a service error to its HTTP status; anything unrecognized
+// is an internal error
+func statusFor(err error) int {
+	var validationErrs validator.ValidationErrors
+	var unknownFields *decode.UnknownFieldsError
+	var priceErr *models.PriceError
+	var precisionErr *currency.PrecisionError
+	var stockErr *inventory.InsufficientStockError
+	var unknownSKU *inventory.UnknownSKUError
+	var transitionErr *models.TransitionError
+	var scopeErr *auth.ScopeError
+	var tooLarge *http.MaxBytesError
+	var tooMany *query.TooManyRowsError
+	var duplicateErr *models.DuplicateIDError
+	var categoryErr *models.CategoryInUseError
+	switch {
+	case errors.Is(err, errBadRequest), errors.As(err, &validationErrs), errors.As(err, &unknownFields),
+		errors.As(err, &priceErr), errors.Is(err, models.ErrInvalidMetadata), errors.Is(err, models.ErrInvalidFilter),
+		errors.Is(err, query.ErrInvalidSort), errors.Is(err, query.ErrInvalidCursor), errors.Is(err, query.ErrInvalidScope), errors.As(err, &tooMany), errors.As(err, &duplicateErr),
+		errors.Is(err, productio.ErrInvalidMapping), errors.Is(err, service.ErrUnsupportedFormat), errors.Is(err, audit.ErrUnknownField),
+		errors.Is(err, bulk.ErrInvalidMode), errors.Is(err, sku.ErrSwapSameProduct), errors.Is(err, models.ErrInvalidTiers),
+		errors.Is(err, models.ErrIncompleteUpsert), errors.Is(err, models.ErrReassignToSelf):
+		return http.StatusBadRequest
+	case errors.As(err, &scopeErr):
+		return http.StatusForbidden
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, errUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, models.ErrColumnMappingNotFound),
+		errors.Is(err, models.ErrPriceWatchNotFound), errors.Is(err, sku.ErrSwapNotFound), errors.Is(err, outbox.ErrNotDead),
+		errors.Is(err, models.ErrTagNotFound), errors.Is(err, models.ErrSavedQueryNotFound),
+		errors.Is(err, models.ErrVariantNotFound), errors.Is(err, models.ErrTranslationNotFound),
+		errors.As(err, &unknownSKU):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrDuplicateSKU), errors.As(err, &stockErr), errors.As(err, &transitionErr),
+		errors.Is(err, models.ErrCategoryTaken), errors.Is(err, models.ErrNotDraft), errors.Is(err, models.ErrSavedQueryExists),
+		errors.Is(err, models.ErrDuplicateVariantSKU), errors.As(err, &categoryErr):
+		return http.StatusConflict
+	case errors.As(err, &precisionErr):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// respondError writes err as a JSON error body. Internal errors are logged
+// and replaced by a generic message so driver details never reach clients;
+// a stock shortfall also names the line item that fell short and a category
+// still in use reports its usage.
+func (s *Server) respondError(c *gin.Context, err error) {
+	status := statusFor(err)
+	message := err.Error()
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		message = "product not found"
+	case status == http.StatusInternalServerError:
+		s.logger.WithContext(c.Request.Context()).Error("Request failed", err)
+		message = "internal error"
+	}
+	body := gin.H{"error": message}
+	var stockErr *inventory.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		body["item"] = stockErr
+	}
+	var categoryErr *models.CategoryInUseError
+	if errors.As(err, &categoryErr) {
+		body["usage"] = categoryErr.Usage
+	}
+	c.AbortWithStatusJSON(status, body)
+}
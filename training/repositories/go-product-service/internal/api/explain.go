@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// explainFilter godoc
+// @Summary Explain a product filter
+// @Description Development aid returning the parameterized list and count SQL a filter compiles to, without running them; not served in production
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param filter body models.ProductFilter true "Filter"
+// @Success 200 {object} query.FilterExplanation
+// @Router /admin/filter-explain [post]
+func (s *Server) explainFilter(c *gin.Context) {
+	var f models.ProductFilter
+	if !s.decodeJSON(c, &f) {
+		return
+	}
+	f.ApplyDefaults()
+	if !s.validateStruct(c, &f) {
+		return
+	}
+	explained, err := s.products.ExplainFilter(&f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, explained)
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestExplainFilter(t *testing.T) {
+	s, _ := newTestServer(t, Options{FilterExplain: true}, service.Options{})
+	w := serve(s, http.MethodPost, "/api/v1/admin/filter-explain", `{"category":"furniture","min_price":50,"sort_by":"price","sort_order":"asc","limit":20}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got query.FilterExplanation
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	where := " FROM products WHERE deleted_at IS NULL AND category = $1 AND price >= $2"
+	if want := "SELECT " + query.ProductColumns + where + " ORDER BY price ASC, id ASC LIMIT $3"; got.SQL != want {
+		t.Fatalf("unexpected SQL:\n got %s\nwant %s", got.SQL, want)
+	}
+	if want := []interface{}{"furniture", 50.0, 21.0}; !reflect.DeepEqual(got.Args, want) {
+		t.Fatalf("expected args %v, got %v", want, got.Args)
+	}
+	if want := "SELECT COUNT(*)" + where; got.CountSQL != want {
+		t.Fatalf("unexpected count SQL:\n got %s\nwant %s", got.CountSQL, want)
+	}
+	if want := []interface{}{"furniture", 50.0}; !reflect.DeepEqual(got.CountArgs, want) {
+		t.Fatalf("expected count args %v, got %v", want, got.CountArgs)
+	}
+}
+
+func TestExplainFilterNotServedInProduction(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/admin/filter-explain", `{}`); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without FilterExplain, got %d", w.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// updateFrequency godoc
+// @Summary List products by update frequency
+// @Description Live products whose number of audited updates since since falls within min..max, most changed first; min=0&max=0 finds products that never changed in the window
+// @Tags products
+// @Produce json
+// @Param filter query models.UpdateFrequencyFilter true "Window, count range and paging"
+// @Success 200 {array} dto.ProductUpdateFrequency
+// @Router /products/update-frequency [get]
+func (s *Server) updateFrequency(c *gin.Context) {
+	var f models.UpdateFrequencyFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	counted, err := s.products.UpdateFrequency(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProductUpdateFrequencies(c.Request.Context(), counted))
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestUpdateFrequencyRange(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	busy := testProduct()
+	rows := sqlmock.NewRows(append(querytest.ProductColumns(), "update_count")).
+		AddRow(append(querytest.ProductValues(&busy), 40)...)
+	mock.ExpectQuery("WITH changes AS \\(SELECT entity_id, COUNT\\(\\*\\) AS n FROM audit_log .+ AND created_at >= \\$1 GROUP BY entity_id\\)"+
+		".+ WHERE deleted_at IS NULL AND update_count >= \\$2 AND update_count <= \\$3 ORDER BY update_count DESC, id ASC LIMIT \\$4 OFFSET \\$5").
+		WithArgs(since, 20, 100, 50, 0).WillReturnRows(rows)
+
+	w := serve(s, http.MethodGet, "/api/v1/products/update-frequency?since=2026-09-01T00:00:00Z&min=20&max=100", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || string(got[0]["update_count"]) != "40" {
+		t.Fatalf("expected one product changed 40 times, got %s", w.Body)
+	}
+}
+
+func TestUpdateFrequencyRejectsInvertedRange(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	target := "/api/v1/products/update-frequency?since=2026-09-01T00:00:00Z&min=5&max=2"
+	if w := serve(s, http.MethodGet, target, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
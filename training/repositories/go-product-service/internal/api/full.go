@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// getFullProduct godoc
+// @Summary Get a product with its relations
+// @Description The product with its images, variants, tags, translations and price tiers in one response; missing relations are empty lists
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.FullProduct
+// @Router /products/{id}/full [get]
+func (s *Server) getFullProduct(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	full, err := s.products.GetFullProduct(c.Request.Context(), id)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewFullProduct(c.Request.Context(), full))
+}
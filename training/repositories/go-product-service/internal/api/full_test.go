@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestGetFullProductLoadsEachRelationOnce(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	now := time.Now()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("FROM product_images").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "url", "position", "created_at"}).
+			AddRow(uuid.New(), p.ID, "https://img.example/desk.jpg", 0, now))
+	mock.ExpectQuery("FROM product_variants").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "name", "price", "stock", "attributes", "created_at", "updated_at"}).
+			AddRow(uuid.New(), p.ID, "DESK-1-OAK", "Oak", nil, 2, []byte(`{"finish":"oak"}`), now, now))
+	mock.ExpectQuery("FROM product_tags").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "tag"}).AddRow(p.ID, "office").AddRow(p.ID, "wood"))
+	mock.ExpectQuery("FROM product_translations").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "locale", "name", "description"}).AddRow(p.ID, "de", "Schreibtisch", ""))
+	mock.ExpectQuery("FROM product_price_tiers").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "min_qty", "price"}).AddRow(p.ID, 10, 110.0))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String()+"/full", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.FullProduct
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Product == nil || got.ID != p.ID || got.Name != p.Name {
+		t.Fatalf("expected the product fields inline, got %s", w.Body)
+	}
+	if len(got.Images) != 1 || len(got.Variants) != 1 || got.Variants[0].Attributes["finish"] != "oak" ||
+		len(got.Tags) != 2 || len(got.Translations) != 1 || len(got.PriceTiers) != 1 {
+		t.Fatalf("expected every relation, got %s", w.Body)
+	}
+}
+
+func TestGetFullProductWithoutRelations(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	for _, table := range []string{"product_images", "product_variants", "product_tags", "product_translations", "product_price_tiers"} {
+		mock.ExpectQuery("FROM " + table).WillReturnRows(sqlmock.NewRows([]string{"product_id"}))
+	}
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String()+"/full", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"images", "variants", "tags", "translations", "price_tiers"} {
+		if string(raw[key]) != "[]" {
+			t.Errorf("expected %s to be an empty list, got %s", key, raw[key])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// groupedProducts godoc
+// @Summary List products grouped by a field
+// @Description Buckets live products by category or is_active; each group carries its full count and up to items_per_group of its newest products
+// @Tags products
+// @Produce json
+// @Param filter query models.GroupedProductsFilter true "Grouping"
+// @Success 200 {array} dto.ProductGroup
+// @Router /products/grouped [get]
+func (s *Server) groupedProducts(c *gin.Context) {
+	var f models.GroupedProductsFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	groups, err := s.products.GroupedProducts(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProductGroups(c.Request.Context(), groups))
+}
+
+// categorySample godoc
+// @Summary Sample products from several categories
+// @Description Up to limit of the newest products from each category in one query, groups in request order; is_active and in_stock_only filter before the cap
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.CategorySampleRequest true "Categories and per-category limit"
+// @Success 200 {array} dto.ProductGroup
+// @Router /products/by-category-sample [post]
+func (s *Server) categorySample(c *gin.Context) {
+	var req models.CategorySampleRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	groups, err := s.products.CategorySample(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProductGroups(c.Request.Context(), groups))
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestGroupedProductsBucketsAndCapsItems(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	chair1, chair2, desk := testProduct(), testProduct(), testProduct()
+	chair1.Category, chair2.Category = "chairs", "chairs"
+	rows := sqlmock.NewRows(append(querytest.ProductColumns(), "group_key", "group_count"))
+	for _, row := range []struct {
+		p     models.Product
+		count int64
+	}{{chair1, 3}, {chair2, 3}, {desk, 1}} {
+		rows.AddRow(append(querytest.ProductValues(&row.p), driver.Value(row.p.Category), driver.Value(row.count))...)
+	}
+	mock.ExpectQuery("ROW_NUMBER\\(\\) OVER \\(PARTITION BY category ORDER BY created_at DESC, id DESC\\) AS group_rank.+WHERE group_rank <= \\$1").
+		WithArgs(2).WillReturnRows(rows)
+
+	w := serve(s, http.MethodGet, "/api/v1/products/grouped?group_by=category&items_per_group=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var groups []dto.ProductGroup
+	if err := json.Unmarshal(w.Body.Bytes(), &groups); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %s", w.Body)
+	}
+	// The chairs group counts all 3 chairs but samples only the capped 2
+	if groups[0].Group != "chairs" || groups[0].Count != 3 || len(groups[0].Items) != 2 || groups[0].Items[1].ID != chair2.ID {
+		t.Fatalf("unexpected chairs group: %+v", groups[0])
+	}
+	if groups[1].Group != "furniture" || groups[1].Count != 1 || len(groups[1].Items) != 1 {
+		t.Fatalf("unexpected furniture group: %+v", groups[1])
+	}
+}
+
+func TestGroupedProductsValidatesTheGrouping(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for _, target := range []string{"", "?group_by=price", "?group_by=category&items_per_group=21"} {
+		if w := serve(s, http.MethodGet, "/api/v1/products/grouped"+target, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d: %s", target, w.Code, w.Body)
+		}
+	}
+}
+
+func TestCategorySampleCapsEachCategory(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	chair1, chair2, desk := testProduct(), testProduct(), testProduct()
+	chair1.Category, chair2.Category, desk.Category = "chairs", "chairs", "desks"
+	mock.ExpectQuery("ROW_NUMBER\\(\\) OVER \\(PARTITION BY category ORDER BY created_at DESC, id DESC\\) AS category_rank"+
+		" FROM products WHERE deleted_at IS NULL AND category = ANY\\(\\$1\\) AND .+ AND stock - reserved > 0\\) AS products WHERE category_rank <= \\$2").
+		WithArgs(`{"desks","chairs","lamps"}`, 2).WillReturnRows(querytest.ProductRows(chair1, chair2, desk))
+
+	body := `{"categories":["desks","chairs","lamps"],"limit":2,"is_active":true,"in_stock_only":true}`
+	w := serve(s, http.MethodPost, "/api/v1/products/by-category-sample", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var groups []dto.ProductGroup
+	if err := json.Unmarshal(w.Body.Bytes(), &groups); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		group string
+		items int
+	}{{"desks", 1}, {"chairs", 2}, {"lamps", 0}}
+	if len(groups) != len(want) {
+		t.Fatalf("expected %d groups, got %s", len(want), w.Body)
+	}
+	for i, g := range groups {
+		if g.Group != want[i].group || len(g.Items) != want[i].items || len(g.Items) > 2 {
+			t.Errorf("group %d: expected %s with %d items, got %s with %d", i, want[i].group, want[i].items, g.Group, len(g.Items))
+		}
+	}
+}
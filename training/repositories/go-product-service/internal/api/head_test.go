@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestHeadProductMatchesGetHeaders(t *testing.T) {
+	s, mock := newTestServer(t, Options{HeadProduct: true}, service.Options{})
+	p := testProduct()
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	}
+
+	get := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "")
+	head := serve(s, http.MethodHead, "/api/v1/products/"+p.ID.String(), "")
+	if get.Code != http.StatusOK || head.Code != http.StatusOK {
+		t.Fatalf("expected 200 for both, got GET %d and HEAD %d", get.Code, head.Code)
+	}
+	if head.Body.Len() != 0 {
+		t.Fatalf("expected no HEAD body, got %s", head.Body)
+	}
+	for _, name := range []string{"ETag", "Last-Modified", "Cache-Control", "Content-Type"} {
+		if got, want := head.Header().Get(name), get.Header().Get(name); got == "" || got != want {
+			t.Fatalf("%s: HEAD sent %q, GET sent %q", name, got, want)
+		}
+	}
+	if got, want := head.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+		t.Fatalf("expected Content-Length %s of the GET body, got %q", want, got)
+	}
+}
+
+func TestHeadMissingProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{HeadProduct: true}, service.Options{})
+	id := uuid.New()
+	expectMissing(mock, id)
+
+	w := serve(s, http.MethodHead, "/api/v1/products/"+id.String(), "")
+	if w.Code != http.StatusNotFound || w.Body.Len() != 0 {
+		t.Fatalf("expected a bodiless 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestHeadProductDisabled(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodHead, "/api/v1/products/"+uuid.NewString(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected no HEAD route, got %d", w.Code)
+	}
+}
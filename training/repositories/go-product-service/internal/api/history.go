@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldHistory godoc
+// @Summary Get the change history of one product field
+// @Description Every audited change to the field, oldest first, with the actor and the values before and after; a field that never changed gives an empty list
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param field path string true "Field name, e.g. price or category"
+// @Success 200 {array} audit.FieldChange
+// @Router /products/{id}/field-history/{field} [get]
+func (s *Server) fieldHistory(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	changes, err := s.products.FieldHistory(c.Request.Context(), id, c.Param("field"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
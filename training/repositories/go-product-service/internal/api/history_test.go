@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestFieldHistoryListsEachChangeInOrder(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	first := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"actor", "action", "before", "after", "created_at"}
+	mock.ExpectQuery("FROM audit_log\\s+WHERE entity_type = 'product' AND entity_id = \\$1").WithArgs(id.String(), "price").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("client-1", models.AuditActionCreate, nil, []byte(`100`), first).
+			AddRow("client-2", models.AuditActionUpdate, []byte(`100`), []byte(`120`), first.Add(time.Hour)).
+			AddRow("client-1", models.AuditActionUpdate, []byte(`120`), []byte(`95.5`), first.Add(2*time.Hour)))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+id.String()+"/field-history/price", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var changes []audit.FieldChange
+	if err := json.Unmarshal(w.Body.Bytes(), &changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	if string(changes[0].Before) != "null" || string(changes[1].After) != "120" || string(changes[2].After) != "95.5" {
+		t.Fatalf("unexpected values: %+v", changes)
+	}
+	if changes[1].Actor != "client-2" || !changes[2].ChangedAt.After(changes[1].ChangedAt) {
+		t.Fatalf("unexpected order or actor: %+v", changes)
+	}
+}
+
+func TestFieldHistoryOfAnUnchangedFieldIsEmpty(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectQuery("FROM audit_log").WithArgs(id.String(), "barcode").
+		WillReturnRows(sqlmock.NewRows([]string{"actor", "action", "before", "after", "created_at"}))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+id.String()+"/field-history/barcode", "")
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Fatalf("expected an empty list, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestFieldHistoryChecksTheField(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	base := "/api/v1/products/" + uuid.NewString() + "/field-history/"
+
+	if w := serve(s, http.MethodGet, base+"content_hash", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a field outside the allowlist, got %d: %s", w.Code, w.Body)
+	}
+	if w := serveAs(s, auth.ScopeRead, http.MethodGet, base+"cost", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for cost history without the finance scope, got %d: %s", w.Code, w.Body)
+	}
+}
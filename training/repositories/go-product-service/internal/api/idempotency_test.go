@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/idempotency"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestIdempotencyKeyReplaysCreate(t *testing.T) {
+	s, mock := newTestServer(t, Options{Idempotency: idempotency.NewMemoryStore(), IdempotencyTTL: time.Hour}, service.Options{})
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.IdempotencyKeyHeader, "create-desk")
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, req)
+		return w
+	}
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}`
+	first := post(body)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", first.Code, first.Body)
+	}
+	retry := post(body)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected the retry to replay the first response, got %d: %s", retry.Code, retry.Body)
+	}
+	if w := post(`{"name":"Lamp","price":20,"category":"lighting","sku":"LAMP-1"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a reused key, got %d: %s", w.Code, w.Body)
+	}
+}
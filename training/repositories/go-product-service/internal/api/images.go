@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// attachImages godoc
+// @Summary Bulk attach images by SKU
+// @Description Attaches up to 500 images to the products with the given SKUs in one transaction; unknown SKUs are reported per row
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param images body models.BulkImageRequest true "Images"
+// @Success 200 {array} models.BulkImageResult
+// @Router /products/images/bulk [post]
+func (s *Server) attachImages(c *gin.Context) {
+	var req models.BulkImageRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	results, err := s.products.AttachImages(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
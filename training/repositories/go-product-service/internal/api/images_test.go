@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestAttachImagesReportsUnknownSKUs(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	desk, lamp := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products WHERE sku = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(desk, "DESK-1").AddRow(lamp, "LAMP-1"))
+	mock.ExpectQuery("INSERT INTO product_images").WithArgs(desk, "https://cdn.example.com/desk.jpg", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectQuery("INSERT INTO product_images").WithArgs(lamp, "https://cdn.example.com/lamp.jpg", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	body := `{"items":[
+		{"sku":"DESK-1","url":"https://cdn.example.com/desk.jpg","position":0},
+		{"sku":"GONE-1","url":"https://cdn.example.com/gone.jpg","position":0},
+		{"sku":"LAMP-1","url":"https://cdn.example.com/lamp.jpg","position":1}]}`
+	w := serve(s, http.MethodPost, "/api/v1/products/images/bulk", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var results []models.BulkImageResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || !results[0].Success || results[1].Success || !results[2].Success {
+		t.Fatalf("expected only the unknown SKU to fail, got %+v", results)
+	}
+	if results[1].Error == "" || results[1].ImageID != nil {
+		t.Fatalf("expected an error and no image for the unknown SKU, got %+v", results[1])
+	}
+}
+
+func TestAttachImagesRejectsBadURLsAndOversizedBatches(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/images/bulk", `{"items":[{"sku":"DESK-1","url":"not a url"}]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid URL, got %d: %s", w.Code, w.Body)
+	}
+
+	items := make([]string, models.MaxBulkImageItems+1)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"sku":"SKU-%d","url":"https://cdn.example.com/%d.jpg"}`, i, i)
+	}
+	body := `{"items":[` + strings.Join(items, ",") + `]}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/images/bulk", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for more than %d items, got %d", models.MaxBulkImageItems, w.Code)
+	}
+}
+
+func TestAttachImagesTouchesParentProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	desk := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products WHERE sku = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(desk, "DESK-1"))
+	mock.ExpectQuery("INSERT INTO product_images").WithArgs(desk, "https://cdn.example.com/desk.jpg", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectExec("UPDATE products SET updated_at = GREATEST\\(clock_timestamp\\(\\), updated_at \\+ INTERVAL '1 microsecond'\\)").
+		WithArgs(`{"` + desk.String() + `"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"items":[{"sku":"DESK-1","url":"https://cdn.example.com/desk.jpg","position":0}]}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/images/bulk", body); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestExportIncrementalStreamsTheWindowWithTombstones(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	live, deleted := testProduct(), testProduct()
+	live.UpdatedAt = since
+	deletedAt := until.Add(-time.Second)
+	deleted.UpdatedAt, deleted.DeletedAt = deletedAt, &deletedAt
+	// Deleted rows are not excluded: the window is the only condition
+	mock.ExpectQuery("FROM products WHERE updated_at >= \\$1 AND updated_at < \\$2 ORDER BY updated_at ASC, id ASC$").
+		WithArgs(since, until).WillReturnRows(querytest.ProductRows(live, deleted))
+
+	target := "/api/v1/products/export/incremental?since=" + since.Format(time.RFC3339) + "&until=" + until.Format(time.RFC3339)
+	w := serve(s, http.MethodGet, target, "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != mediaTypeJSONL {
+		t.Fatalf("expected 200 NDJSON, got %d %s: %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	var got []dto.Product
+	for sc := bufio.NewScanner(w.Body); sc.Scan(); {
+		var p dto.Product
+		if err := json.Unmarshal(sc.Bytes(), &p); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p)
+	}
+	if len(got) != 2 || got[0].ID != live.ID || got[0].DeletedAt != nil {
+		t.Fatalf("expected the live product first, got %+v", got)
+	}
+	if got[1].ID != deleted.ID || got[1].DeletedAt == nil || !got[1].DeletedAt.Equal(deletedAt) {
+		t.Fatalf("expected a tombstone with deleted_at, got %+v", got[1])
+	}
+}
+
+func TestExportIncrementalRequiresAnOrderedWindow(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for _, target := range []string{
+		"?since=2024-05-01T00:00:00Z",
+		"?since=2024-05-02T00:00:00Z&until=2024-05-01T00:00:00Z",
+		"?since=2024-05-01T00:00:00Z&until=2024-05-01T00:00:00Z",
+	} {
+		if w := serve(s, http.MethodGet, "/api/v1/products/export/incremental"+target, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", target, w.Code, w.Body)
+		}
+	}
+}
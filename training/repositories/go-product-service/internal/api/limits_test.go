@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestImportLimitsOverrideOnlyTheirRoutes(t *testing.T) {
+	s, mock := newTestServer(t, Options{
+		Limits:       middleware.Limits{MaxBodyBytes: 128, Timeout: time.Second},
+		ImportLimits: middleware.Limits{MaxBodyBytes: 1 << 20},
+	}, service.Options{})
+
+	large := `{"name":"Desk","description":"` + strings.Repeat("x", 200) + `","price":120,"category":"furniture","sku":"DESK-1"}`
+	if w := serve(s, http.MethodPost, "/api/v1/products", large); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 under the default limit, got %d: %s", w.Code, w.Body)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectCommit()
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "application/x-ndjson", large+"\n")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the import limit to admit the body, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestListCacheServesRepeatsUntilAWrite(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{ListCacheTTL: time.Minute})
+	p := testProduct()
+	expectList := func() {
+		mock.ExpectQuery("SELECT .+ FROM products WHERE .+category = \\$1").WillReturnRows(querytest.ProductRows(p))
+		mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	}
+	list := func(target string) {
+		t.Helper()
+		if w := serve(s, http.MethodGet, target, ""); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", target, w.Code, w.Body)
+		}
+	}
+
+	expectList()
+	list("/api/v1/products?category=furniture&min_price=1")
+	// Same filter in another parameter order; no queries are expected
+	list("/api/v1/products?min_price=1&category=furniture")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at").WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+
+	expectList()
+	list("/api/v1/products?category=furniture&min_price=1")
+}
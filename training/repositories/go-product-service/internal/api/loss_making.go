@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// lossMakingProducts godoc
+// @Summary List loss-making products
+// @Description Active products whose cost is at or above their price, largest loss first; requires the finance scope
+// @Tags products
+// @Produce json
+// @Param page query models.PageFilter false "Paging"
+// @Success 200 {array} dto.Product
+// @Router /products/loss-making [get]
+func (s *Server) lossMakingProducts(c *gin.Context) {
+	var page models.PageFilter
+	if !s.bindQuery(c, &page) {
+		return
+	}
+	products, err := s.products.LossMakingProducts(c.Request.Context(), &page)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProducts(c.Request.Context(), products))
+}
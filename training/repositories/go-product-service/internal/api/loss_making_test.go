@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestLossMakingProductsListsOnlyLosses(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	loss := testProduct()
+	cost := 150.0
+	loss.Cost = &cost
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND is_active AND cost IS NOT NULL AND cost >= price ORDER BY \\(cost - price\\) DESC").
+		WithArgs(10, 0).WillReturnRows(querytest.ProductRows(loss))
+
+	w := serveAs(s, auth.ScopeFinance, http.MethodGet, "/api/v1/products/loss-making", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || string(got[0]["id"]) != `"`+loss.ID.String()+`"` || string(got[0]["cost"]) != "150" {
+		t.Fatalf("expected only the loss-making product with its cost, got %s", w.Body)
+	}
+}
+
+func TestLossMakingProductsRequiresFinance(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serveAs(s, auth.ScopeRead, http.MethodGet, "/api/v1/products/loss-making", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestIdentifiersMaskedForAnonymousCallers(t *testing.T) {
+	s, mock := newTestServer(t, Options{MaskIdentifiersForAnonymous: true}, service.Options{})
+	p := testProduct()
+	barcode := "4006381333931"
+	p.Barcode = &barcode
+	target := "/api/v1/products/" + p.ID.String()
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	}
+
+	anonymous := decodeFields(t, serve(s, http.MethodGet, target, "").Body.Bytes())
+	if _, ok := anonymous["sku"]; ok {
+		t.Error("anonymous: sku must be absent")
+	}
+	if _, ok := anonymous["barcode"]; ok {
+		t.Error("anonymous: barcode must be absent")
+	}
+	if string(anonymous["name"]) != `"Desk"` {
+		t.Errorf("anonymous: expected the rest of the product, got name=%s", anonymous["name"])
+	}
+
+	// A client granted no scopes is still authenticated
+	authenticated := decodeFields(t, serveAs(s, "", http.MethodGet, target, "").Body.Bytes())
+	if string(authenticated["sku"]) != `"DESK-1"` || string(authenticated["barcode"]) != `"`+barcode+`"` {
+		t.Fatalf("authenticated: expected sku and barcode, got sku=%s barcode=%s", authenticated["sku"], authenticated["barcode"])
+	}
+}
+
+func TestIdentifiersShownToAnonymousCallersByDefault(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+
+	fields := decodeFields(t, serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "").Body.Bytes())
+	if string(fields["sku"]) != `"DESK-1"` {
+		t.Fatalf("expected the sku without masking, got %s", fields["sku"])
+	}
+}
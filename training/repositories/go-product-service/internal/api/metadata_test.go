@@ -0,0 +1,118 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestCreateProductStoresMetadata(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	args := make([]driver.Value, 19)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[16] = []byte(`{"material":"oak","screen_size":15}`)
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","metadata":{"screen_size":15,"material":"oak"}}`
+	if w := serve(s, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestGetProductReturnsMetadata(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Metadata = models.Metadata{"isbn": "978-0136083238", "pages": float64(464)}
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Metadata["isbn"] != "978-0136083238" || got.Metadata["pages"] != float64(464) {
+		t.Fatalf("expected the stored metadata, got %v", got.Metadata)
+	}
+}
+
+func TestListProductsByMetadataKey(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Metadata = models.Metadata{"screen_size": float64(15)}
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND metadata @> \\$1::jsonb").
+		WithArgs(`{"screen_size":15}`, 11).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(`{"screen_size":15}`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := serve(s, http.MethodGet, "/api/v1/products?metadata_key=screen_size&metadata_value=15", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 1 || list.Products[0].ID != p.ID {
+		t.Fatalf("expected the matching product, got %s", w.Body)
+	}
+}
+
+func TestCreateProductRejectsDeepMetadata(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	nested := strings.Repeat(`{"a":`, models.MaxMetadataDepth+1) + "1" + strings.Repeat("}", models.MaxMetadataDepth+1)
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","metadata":` + nested + `}`
+	w := serve(s, http.MethodPost, "/api/v1/products", body)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "nesting depth") {
+		t.Fatalf("expected 400 for metadata nested too deep, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestListProductsByTypedMetadataComparisons(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Metadata = models.Metadata{"screen_size": float64(17), "material": "oak"}
+	mock.ExpectQuery("jsonb_typeof\\(metadata -> \\$1::text\\) = 'number' THEN \\(metadata ->> \\$2::text\\)::numeric END\\) > \\$3::numeric").
+		WithArgs("screen_size", "screen_size", "15", 11).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("jsonb_typeof\\(metadata -> \\$1::text\\) = 'string' THEN metadata ->> \\$2::text END\\) = \\$3").
+		WithArgs("material", "material", "15", 11).WillReturnRows(querytest.ProductRows())
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if w := serve(s, http.MethodGet, "/api/v1/products?metadata_filter=screen_size:gt:15", ""); w.Code != http.StatusOK {
+		t.Fatalf("numeric: expected 200, got %d: %s", w.Code, w.Body)
+	}
+	// A quoted value compares as text even when it looks like a number
+	if w := serve(s, http.MethodGet, `/api/v1/products?metadata_filter=material:eq:"15"`, ""); w.Code != http.StatusOK {
+		t.Fatalf("string: expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestListProductsRejectsMalformedMetadataFilters(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for _, expr := range []string{"screen_size", "screen_size:like:15", ":gt:15"} {
+		if w := serve(s, http.MethodGet, "/api/v1/products?metadata_filter="+expr, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", expr, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// neverSoldProducts godoc
+// @Summary List products never sold
+// @Description Active products created before created_before without a single sale stock movement, oldest first, as clearance candidates
+// @Tags products
+// @Produce json
+// @Param filter query models.NeverSoldFilter true "Cutoff and paging"
+// @Success 200 {array} dto.Product
+// @Router /products/never-sold [get]
+func (s *Server) neverSoldProducts(c *gin.Context) {
+	var f models.NeverSoldFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	products, err := s.products.NeverSoldProducts(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProducts(c.Request.Context(), products))
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestNeverSoldProductsBeforeCutoff(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	unsold := testProduct()
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND is_active AND created_at < \\$1 AND NOT EXISTS \\(SELECT 1 FROM stock_movements m WHERE m.product_id = products.id AND m.reason = 'sale'\\) ORDER BY created_at ASC").
+		WithArgs(cutoff, 50, 0).WillReturnRows(querytest.ProductRows(unsold))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/never-sold?created_before=2024-06-01", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || string(got[0]["id"]) != `"`+unsold.ID.String()+`"` {
+		t.Fatalf("expected the unsold product, got %s", w.Body)
+	}
+}
+
+func TestNeverSoldProductsRequiresCutoff(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodGet, "/api/v1/products/never-sold", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
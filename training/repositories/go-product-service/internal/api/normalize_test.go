@@ -0,0 +1,68 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// expectInsertNamed expects a product insert with the given name, SKU and category
+func expectInsertNamed(mock sqlmock.Sqlmock, name, sku, category string) {
+	args := make([]driver.Value, 19)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[1], args[9], args[10] = name, category, sku
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestCreateProductTrimsInputWhenNormalizing(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{NormalizeInput: true})
+	// Padded to past the 255 character limit, which only the trimmed name meets
+	name := strings.Repeat("d", 255)
+	expectInsertNamed(mock, name, "DESK-1", "furniture")
+
+	body := `{"name":"  ` + name + `  ","price":120,"category":" furniture ","sku":"DESK-1 "}`
+	w := serve(s, http.MethodPost, "/api/v1/products", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != name || got.Category != "furniture" || got.SKU != "DESK-1" {
+		t.Fatalf("expected trimmed fields, got %+v", got)
+	}
+}
+
+func TestCreateProductTitleCasesNames(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{NormalizeInput: true, TitleCaseNames: true})
+	expectInsertNamed(mock, "Oak Writing Desk", "DESK-1", "furniture")
+
+	body := `{"name":" oak   WRITING desk ","price":120,"category":"furniture","sku":"DESK-1"}`
+	if w := serve(s, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateProductKeepsInputByDefault(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	body := `{"name":"  ` + strings.Repeat("d", 255) + `  ","price":120,"category":"furniture","sku":"DESK-1"}`
+	if w := serve(s, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for the untrimmed name, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// productID matches an argument equal to the ID the insert was given
+type productID struct{ id *driver.Value }
+
+func (m productID) Match(v driver.Value) bool {
+	if *m.id == nil {
+		*m.id = v
+		return true
+	}
+	return *m.id == v
+}
+
+func TestCreateProductRecordsOpeningMovement(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	var id driver.Value
+	insertArgs := make([]driver.Value, 19)
+	for i := range insertArgs {
+		insertArgs[i] = sqlmock.AnyArg()
+	}
+	insertArgs[0] = productID{&id}
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(insertArgs...).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO stock_movements").WithArgs(sqlmock.AnyArg(), productID{&id}, 12, "stocktake").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","stock":12,"opening_stock_reason":"stocktake"}`
+	w := serve(s, http.MethodPost, "/api/v1/products", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID.String() != id.(string) || got.Stock != 12 {
+		t.Fatalf("expected the movement to match the created product, got %+v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// deadOutboxEvents godoc
+// @Summary List dead-lettered outbox events
+// @Description Events that failed OUTBOX_MAX_ATTEMPTS deliveries, newest first, with their last error; requires the admin scope
+// @Tags admin
+// @Produce json
+// @Param page query models.PageFilter false "Paging"
+// @Success 200 {array} outbox.Entry
+// @Router /admin/outbox/dead [get]
+func (s *Server) deadOutboxEvents(c *gin.Context) {
+	var page models.PageFilter
+	if !s.bindQuery(c, &page) {
+		return
+	}
+	entries, err := s.products.DeadOutboxEvents(c.Request.Context(), &page)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// retryOutboxEvent godoc
+// @Summary Requeue a dead-lettered outbox event
+// @Description Returns the event to pending with a fresh attempt budget; requires the admin scope
+// @Tags admin
+// @Param id path string true "Outbox event ID"
+// @Success 204
+// @Router /admin/outbox/dead/{id}/retry [post]
+func (s *Server) retryOutboxEvent(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.products.RetryOutboxEvent(c.Request.Context(), id); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
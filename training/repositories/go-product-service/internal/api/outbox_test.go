@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/outbox"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestDeadOutboxEventsRequireAdmin(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	if w := serveAs(s, auth.ScopeRead, http.MethodGet, "/api/v1/admin/outbox/dead", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without the admin scope, got %d: %s", w.Code, w.Body)
+	}
+
+	id, productID, lastError := uuid.New(), uuid.New(), "webhook unavailable"
+	mock.ExpectQuery("SELECT .+ FROM outbox_events WHERE status = 'dead'").WithArgs(10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "product_id", "payload", "status", "attempts", "last_error", "created_at"}).
+			AddRow(id, "product.updated", productID, []byte(`{}`), outbox.StatusDead, 5, lastError, time.Now()))
+	w := serveAs(s, auth.ScopeAdmin, http.MethodGet, "/api/v1/admin/outbox/dead", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []outbox.Entry
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != id || got[0].Attempts != 5 || got[0].LastError == nil || *got[0].LastError != lastError {
+		t.Fatalf("unexpected dead events: %s", w.Body)
+	}
+}
+
+func TestRetryOutboxEvent(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	dead, live := uuid.New(), uuid.New()
+	mock.ExpectExec("UPDATE outbox_events SET status = 'pending'").WithArgs(dead).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE outbox_events SET status = 'pending'").WithArgs(live).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if w := serveAs(s, auth.ScopeAdmin, http.MethodPost, "/api/v1/admin/outbox/dead/"+dead.String()+"/retry", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+	if w := serveAs(s, auth.ScopeAdmin, http.MethodPost, "/api/v1/admin/outbox/dead/"+live.String()+"/retry", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an event that is not dead, got %d: %s", w.Code, w.Body)
+	}
+}
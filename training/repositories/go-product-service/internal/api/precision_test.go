@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestCreateProductChecksPricePrecision(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":19.99,"category":"furniture","sku":"DESK-1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for 19.99, got %d: %s", w.Code, w.Body)
+	}
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":19.999,"category":"furniture","sku":"DESK-2"}`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "19.999 has more than 2 decimal places") {
+		t.Fatalf("expected 422 naming the price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateProductUsesConfiguredPricePlaces(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{PriceDecimalPlaces: 1})
+	if w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":19.99,"category":"furniture","sku":"DESK-1"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 at one decimal place, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateProductChecksPriceAgainstItsCurrency(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	yen := "JPY"
+	p.Currency = &yen
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), `{"price":1200.5}`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "more than 0 decimal places") {
+		t.Fatalf("expected 422 for a fractional yen price, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestCreateProductAppliesPriceGuard(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{PriceGuard: models.PriceGuard{Min: 1}})
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":0.5,"category":"furniture","sku":"DESK-1"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `list price 0.5 must be \u003e 1`) {
+		t.Fatalf("expected 400 naming the list price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestPriceGuardAtMinimumAllowsFreeProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{PriceGuard: models.PriceGuard{Min: 0, AllowMin: true}})
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Sticker","price":0,"category":"swag","sku":"STICKER-1"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for a free product, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDefaultPriceGuardRejectsFreeProducts(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Sticker","price":0,"category":"swag","sku":"STICKER-1"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `list price 0 must be \u003e 0`) {
+		t.Fatalf("expected 400 for a zero price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateProductAppliesPriceGuard(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{PriceGuard: models.PriceGuard{Min: 5}})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), `{"price":4}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `list price 4 must be \u003e 5`) {
+		t.Fatalf("expected 400 naming the list price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateProductAppliesPriceGuardToSalePrice(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{PriceGuard: models.PriceGuard{Min: 5, AllowMin: true}})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), `{"sale_price":4.5}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `sale price 4.5 must be \u003e= 5`) {
+		t.Fatalf("expected 400 naming the sale price, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestImportAppliesPriceGuardPerRow(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{PriceGuard: models.PriceGuard{Min: 1}})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}` + "\n" +
+		`{"name":"Sticker","price":0.5,"category":"swag","sku":"STICKER-1"}` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "application/x-ndjson", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Failed != 1 || report.Errors[0].Line != 2 || !strings.Contains(report.Errors[0].Error, "list price 0.5") {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// setPriceTiers godoc
+// @Summary Replace a product's price tiers
+// @Description Stores quantity breaks, each the unit price from a minimum quantity; quantities must rise, prices must never rise and every tier must be below the list price
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body models.SetPriceTiersRequest true "Tiers by increasing quantity"
+// @Success 200 {array} models.PriceTier
+// @Router /products/{id}/price-tiers [put]
+func (s *Server) setPriceTiers(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.SetPriceTiersRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	tiers, err := s.products.SetPriceTiers(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tiers)
+}
+
+// effectivePrice godoc
+// @Summary Get the effective unit price for a quantity
+// @Description The lower of the active sale (or list) price and the deepest price tier the quantity reaches
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param qty query int false "Quantity, default 1"
+// @Success 200 {object} models.EffectivePrice
+// @Router /products/{id}/price [get]
+func (s *Server) effectivePrice(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var q models.EffectivePriceQuery
+	if !s.bindQuery(c, &q) {
+		return
+	}
+	price, err := s.products.EffectivePrice(c.Request.Context(), id, q.Qty)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewEffectivePrice(c.Request.Context(), price))
+}
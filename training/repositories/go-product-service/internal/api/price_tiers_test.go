@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestEffectivePriceAtTierBoundary(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	tiers := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"product_id", "min_qty", "price"}).AddRow(p.ID, 10, 108).AddRow(p.ID, 50, 96)
+	}
+
+	for _, tc := range []struct {
+		qty       string
+		unitPrice float64
+		tierQty   int
+	}{
+		{"9", 120, 0},
+		{"10", 108, 10},
+		{"49", 108, 10},
+		{"50", 96, 50},
+	} {
+		mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+		mock.ExpectQuery("SELECT product_id, min_qty, price FROM product_price_tiers").WillReturnRows(tiers())
+
+		w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String()+"/price?qty="+tc.qty, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("qty %s: expected 200, got %d: %s", tc.qty, w.Code, w.Body)
+		}
+		var got models.EffectivePrice
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		tierQty := 0
+		if got.Tier != nil {
+			tierQty = got.Tier.MinQty
+		}
+		if got.UnitPrice != tc.unitPrice || tierQty != tc.tierQty {
+			t.Errorf("qty %s: expected %.2f from tier %d, got %.2f from tier %d", tc.qty, tc.unitPrice, tc.tierQty, got.UnitPrice, tierQty)
+		}
+	}
+}
+
+func TestSetPriceTiersReplacesTiers(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("DELETE FROM product_price_tiers").WithArgs(p.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO product_price_tiers").WithArgs(p.ID, 10, 108.0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO product_price_tiers").WithArgs(p.ID, 50, 96.0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"tiers":[{"min_qty":10,"price":108},{"min_qty":50,"price":96}]}`
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"/price-tiers", body); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestSetPriceTiersRejectsInvalidTiers(t *testing.T) {
+	guard := models.PriceGuard{Min: 100}
+	for name, body := range map[string]string{
+		"quantities not rising": `{"tiers":[{"min_qty":10,"price":108},{"min_qty":10,"price":96}]}`,
+		"price rising":          `{"tiers":[{"min_qty":10,"price":108},{"min_qty":50,"price":110}]}`,
+		"above list price":      `{"tiers":[{"min_qty":10,"price":130}]}`,
+		"below price guard":     `{"tiers":[{"min_qty":10,"price":96}]}`,
+	} {
+		s, mock := newTestServer(t, Options{}, service.Options{PriceGuard: guard})
+		p := testProduct()
+		mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+		if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"/price-tiers", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", name, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// createPriceWatch godoc
+// @Summary Watch a product's price
+// @Description Notifies the email through the event pipeline when the product's price drops
+// @Tags price-watches
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param watch body models.CreatePriceWatchRequest true "Watcher"
+// @Success 201 {object} models.PriceWatch
+// @Router /products/{id}/price-watches [post]
+func (s *Server) createPriceWatch(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.CreatePriceWatchRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	w, err := s.products.CreatePriceWatch(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, w)
+}
+
+// deletePriceWatch godoc
+// @Summary Stop watching a product's price
+// @Tags price-watches
+// @Param id path string true "Product ID"
+// @Param watch_id path string true "Price watch ID"
+// @Success 204
+// @Router /products/{id}/price-watches/{watch_id} [delete]
+func (s *Server) deletePriceWatch(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	watchID, ok := s.pathID(c, "watch_id")
+	if !ok {
+		return
+	}
+	if err := s.products.DeletePriceWatch(c.Request.Context(), id, watchID); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
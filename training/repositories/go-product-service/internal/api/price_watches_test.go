@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// recordingSink keeps every delivery it receives
+type recordingSink struct {
+	mu         sync.Mutex
+	deliveries [][]events.Event
+}
+
+func (s *recordingSink) Deliver(_ context.Context, batch []events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, batch)
+	return nil
+}
+
+func (s *recordingSink) events() []events.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var all []events.Event
+	for _, d := range s.deliveries {
+		all = append(all, d...)
+	}
+	return all
+}
+
+// expectPriceUpdate mocks updating p, read back as stored, to a new price
+func expectPriceUpdate(mock sqlmock.Sqlmock, p models.Product) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET name").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestPriceDropNotifiesWatchersButIncreaseDoesNot(t *testing.T) {
+	sink := &recordingSink{}
+	s, mock := newTestServer(t, Options{}, service.Options{
+		Publisher:               events.NewPublisher(sink, 0, 1),
+		ClearPriceWatchOnNotify: true,
+	})
+	p := testProduct()
+	target := "/api/v1/products/" + p.ID.String()
+
+	expectPriceUpdate(mock, p)
+	if w := serve(s, http.MethodPut, target, `{"price":150}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if got := sink.events(); len(got) != 0 {
+		t.Fatalf("expected no event for a price increase, got %+v", got)
+	}
+
+	expectPriceUpdate(mock, p)
+	mock.ExpectQuery("SELECT .+ FROM price_watches WHERE product_id = \\$1").WithArgs(p.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "email", "created_at"}).
+			AddRow(uuid.New(), p.ID, "ada@example.com", time.Now()).
+			AddRow(uuid.New(), p.ID, "bob@example.com", time.Now()))
+	mock.ExpectExec("DELETE FROM price_watches WHERE product_id = \\$1").WithArgs(p.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	if w := serve(s, http.MethodPut, target, `{"price":99}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	got := sink.events()
+	if len(got) != 1 || got[0].Type != models.EventPriceDropped || got[0].ProductID != p.ID {
+		t.Fatalf("expected one price drop event, got %+v", got)
+	}
+	var drop models.PriceDropEvent
+	if err := json.Unmarshal(got[0].Payload, &drop); err != nil {
+		t.Fatal(err)
+	}
+	if drop.OldPrice != 120 || drop.NewPrice != 99 || len(drop.Emails) != 2 {
+		t.Fatalf("unexpected price drop: %+v", drop)
+	}
+}
+
+func TestPriceWatchEndpoints(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	productID, watchID := uuid.New(), uuid.New()
+	mock.ExpectQuery("INSERT INTO price_watches").WithArgs(productID, "ada@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "email", "created_at"}).
+			AddRow(watchID, productID, "ada@example.com", time.Now()))
+	mock.ExpectExec("DELETE FROM price_watches WHERE id = \\$1").WithArgs(watchID, productID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM price_watches WHERE id = \\$1").WithArgs(watchID, productID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	target := "/api/v1/products/" + productID.String() + "/price-watches"
+	if w := serve(s, http.MethodPost, target, `{"email":"ada@example.com"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodPost, target, `{"email":"not-an-email"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodDelete, target+"/"+watchID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodDelete, target+"/"+watchID.String(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a deleted watch, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// createProduct godoc
+// @Summary Create a product
+// @Description New products start active or inactive per DEFAULT_PRODUCT_ACTIVE; is_active overrides that only for admin callers
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body models.CreateProductRequest true "Product"
+// @Success 201 {object} dto.Product
+// @Router /products [post]
+func (s *Server) createProduct(c *gin.Context) {
+	var req models.CreateProductRequest
+	if !s.decodeJSON(c, &req) {
+		return
+	}
+	s.products.Normalize(&req)
+	// A generated SKU must be in place before the request is validated
+	if err := s.products.AssignSKU(c.Request.Context(), &req); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	if !s.validateStruct(c, &req) {
+		return
+	}
+	p, err := s.products.CreateProduct(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewProduct(c.Request.Context(), p))
+}
+
+// getProduct godoc
+// @Summary Get a product
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Description HEAD answers with the same headers and no body when enabled
+// @Success 200 {object} dto.Product
+// @Router /products/{id} [get]
+// @Router /products/{id} [head]
+func (s *Server) getProduct(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	p, err := s.products.GetProduct(c.Request.Context(), id)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	ctx, err := s.withCoverage(c, *p)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	setFreshnessHeaders(c, p)
+	c.JSON(http.StatusOK, dto.NewProduct(ctx, p))
+}
+
+// setFreshnessHeaders lets clients revalidate a product cheaply, e.g. with
+// HEAD. The ETag follows updated_at, which every write advances; the body
+// depends on the caller's scopes, so shared caches must not store it.
+func setFreshnessHeaders(c *gin.Context, p *models.Product) {
+	c.Header("ETag", `W/"`+strconv.FormatInt(p.UpdatedAt.UnixNano(), 36)+`"`)
+	c.Header("Last-Modified", p.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "private, no-cache")
+}
+
+// updateProduct godoc
+// @Summary Update a product
+// @Description With upsert=true a missing product is created under the path ID, answering 201, provided the body carries every field a create requires
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param upsert query bool false "Create the product when the ID does not exist"
+// @Param product body models.UpdateProductRequest true "Fields to change"
+// @Success 200 {object} dto.Product
+// @Success 201 {object} dto.Product
+// @Router /products/{id} [put]
+func (s *Server) updateProduct(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var q models.UpdateQuery
+	if !s.bindQuery(c, &q) {
+		return
+	}
+	var req models.UpdateProductRequest
+	if !s.decodeJSON(c, &req) {
+		return
+	}
+	s.products.Normalize(&req)
+	if !s.validateStruct(c, &req) {
+		return
+	}
+	if q.Upsert {
+		p, created, err := s.products.UpsertProduct(c.Request.Context(), id, &req)
+		if err != nil {
+			s.respondError(c, err)
+			return
+		}
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		c.JSON(status, dto.NewProduct(c.Request.Context(), p))
+		return
+	}
+	p, err := s.products.UpdateProduct(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProduct(c.Request.Context(), p))
+}
+
+// deleteProduct godoc
+// @Summary Delete a product
+// @Tags products
+// @Param id path string true "Product ID"
+// @Success 204
+// @Router /products/{id} [delete]
+func (s *Server) deleteProduct(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.products.DeleteProduct(c.Request.Context(), id); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// listProducts godoc
+// @Summary List products
+// @Description Pages by offset, or by the opaque next_cursor of the previous page. A search that matches nothing may carry "did you mean" suggestions when they are enabled.
+// @Tags products
+// @Produce json
+// @Param filter query models.ProductFilter false "Filter"
+// @Success 200 {object} dto.ProductList
+// @Router /products [get]
+func (s *Server) listProducts(c *gin.Context) {
+	var f models.ProductFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	s.respondList(c, &f)
+}
+
+// searchProducts godoc
+// @Summary Search products with a filter body
+// @Description Lists products exactly as GET /products does, reading the filter from a JSON body so filters with many categories or tags need not fit in a URL
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param filter body models.ProductFilter true "Filter"
+// @Success 200 {object} dto.ProductList
+// @Router /products/search [post]
+func (s *Server) searchProducts(c *gin.Context) {
+	var f models.ProductFilter
+	if !s.decodeJSON(c, &f) {
+		return
+	}
+	f.ApplyDefaults()
+	if !s.validateStruct(c, &f) {
+		return
+	}
+	s.respondList(c, &f)
+}
+
+// respondList writes the page of products matching f
+func (s *Server) respondList(c *gin.Context, f *models.ProductFilter) {
+	products, total, next, err := s.products.ListProducts(c.Request.Context(), f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	ctx, err := s.withCoverage(c, products...)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	list := dto.NewProductList(ctx, products, total, f, next)
+	if list.Suggestions, err = s.products.SearchSuggestions(c.Request.Context(), f, total); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// withCoverage returns the request context carrying the sales velocities
+// behind the coverage_days of products
+func (s *Server) withCoverage(c *gin.Context, products ...models.Product) (context.Context, error) {
+	velocities, err := s.products.SalesVelocities(c.Request.Context(), products)
+	if err != nil || velocities == nil {
+		return c.Request.Context(), err
+	}
+	return dto.WithSalesVelocity(c.Request.Context(), velocities), nil
+}
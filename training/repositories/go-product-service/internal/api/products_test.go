@@ -0,0 +1,308 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/decode"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func testProduct() models.Product {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return models.Product{
+		ID: uuid.New(), Name: "Desk", Price: 120, Category: "furniture", SKU: "DESK-1",
+		Stock: 3, IsActive: true, Status: models.StatusActive, CreatedAt: now, UpdatedAt: now,
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO stock_movements").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3, models.MovementInitial).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","stock":3}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID == uuid.Nil || got.Name != "Desk" || got.Status != models.StatusActive {
+		t.Fatalf("unexpected product: %+v", got)
+	}
+}
+
+func TestCreateProductRejectsInvalidBodies(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for name, body := range map[string]string{
+		"malformed":     `{"name":`,
+		"unknown field": `{"name":"Desk","price":120,"category":"furniture","colour":"red"}`,
+		"missing name":  `{"price":120,"category":"furniture"}`,
+	} {
+		if w := serve(s, http.MethodPost, "/api/v1/products", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", name, w.Code, w.Body)
+		}
+	}
+}
+
+func TestStrictJSONRejectsUnknownFields(t *testing.T) {
+	body := `{"name":"Desk","pric":99,"colour":"red"}`
+	target := "/api/v1/products/" + uuid.New().String()
+
+	strict, _ := newTestServer(t, Options{StrictJSON: true}, service.Options{})
+	w := serve(strict, http.MethodPut, target, body)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "unknown fields: colour, pric") {
+		t.Fatalf("expected 400 listing the unknown fields, got %d: %s", w.Code, w.Body)
+	}
+
+	lenient, _ := newTestServer(t, Options{}, service.Options{})
+	req := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(decode.StrictHeader, "true")
+	rec := httptest.NewRecorder()
+	lenient.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "pric") {
+		t.Fatalf("expected the header to opt into strict decoding, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestCreateProductDuplicateSKUConflicts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WillReturnError(models.ErrDuplicateSKU)
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestGetProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	missing := uuid.New()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(missing).
+		WillReturnRows(sqlmock.NewRows(querytest.ProductColumns()))
+
+	if w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodGet, "/api/v1/products/"+missing.String(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+	if w := serve(s, http.MethodGet, "/api/v1/products/not-a-uuid", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET name").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String(), `{"price":99.5}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Price != 99.5 || got.Name != p.Name {
+		t.Fatalf("expected only the price to change: %+v", got)
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestListProductsPagesByCursor(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	a, b, c := testProduct(), testProduct(), testProduct()
+	b.CreatedAt = a.CreatedAt.Add(-time.Hour)
+	c.CreatedAt = b.CreatedAt.Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(a, b, c))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	w := serve(s, http.MethodGet, "/api/v1/products?limit=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var first dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Products) != 2 || first.Total != 3 || first.NextCursor == "" {
+		t.Fatalf("expected two products and a cursor: %s", w.Body)
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(c))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	w = serve(s, http.MethodGet, "/api/v1/products?limit=2&cursor="+first.NextCursor, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var last dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &last); err != nil {
+		t.Fatal(err)
+	}
+	if len(last.Products) != 1 || last.NextCursor != "" {
+		t.Fatalf("expected the final page to have no cursor: %s", w.Body)
+	}
+}
+
+func TestListProductsRejectsGarbageCursor(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodGet, "/api/v1/products?cursor=garbage", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestInternalErrorsHideDetails(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectQuery("SELECT .+ FROM products").WithArgs(id).WillReturnError(errDriver)
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+id.String(), "")
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), errDriver.Error()) {
+		t.Fatalf("expected a generic 500, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateProductGeneratesMissingSKU(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{AutoGenerateSKU: true})
+	taken := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE sku = \\$1").WillReturnRows(querytest.ProductRows(taken))
+	mock.ExpectQuery("SELECT .+ FROM products WHERE sku = \\$1").WillReturnRows(sqlmock.NewRows(querytest.ProductColumns()))
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":120,"category":"furniture"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got.SKU, "FUR-") {
+		t.Fatalf("expected a generated FUR- SKU, got %q", got.SKU)
+	}
+}
+
+func TestCreateProductRequiresSKUWithoutGeneration(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products", `{"name":"Desk","price":120,"category":"furniture"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestListProductsByContentHash(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	a, b := testProduct(), testProduct()
+	hash := a.ComputeContentHash()
+	a.ContentHash, b.ContentHash = hash, hash
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND content_hash = \\$1").WithArgs(hash, 11).
+		WillReturnRows(querytest.ProductRows(a, b))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(hash).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	w := serve(s, http.MethodGet, "/api/v1/products?content_hash="+hash, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 2 || list.Products[0].ContentHash != hash || list.Products[1].ContentHash != hash {
+		t.Fatalf("expected both duplicates with their shared hash, got %s", w.Body)
+	}
+}
+
+func TestSearchProductsReadsLargeFilterFromBody(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	categories, tags := make([]string, 60), make([]string, 40)
+	for i := range categories {
+		categories[i] = fmt.Sprintf("category-%02d", i)
+	}
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag-%02d", i)
+	}
+	array := func(values []string) string { return `{"` + strings.Join(values, `","`) + `"}` }
+	body, err := json.Marshal(map[string]interface{}{"categories": categories, "tags": tags})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND category = ANY\\(\\$1\\) AND EXISTS .+ t.tag = ANY\\(\\$2\\)\\) ORDER BY created_at DESC, id DESC LIMIT \\$3").
+		WithArgs(array(categories), array(tags), 11).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(array(categories), array(tags)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := serve(s, http.MethodPost, "/api/v1/products/search", string(body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 1 || list.Total != 1 || list.Products[0].ID != p.ID {
+		t.Fatalf("expected the one matching product, got %s", w.Body)
+	}
+}
+
+func TestSearchProductsValidatesBodyFilter(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodPost, "/api/v1/products/search", `{"limit":1000}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// lowQualityProducts godoc
+// @Summary List products with low data quality
+// @Description Products whose data_quality_score is at most max_score, worst first; the score starts at 100 and loses the configured points for a missing description, no images, zero stock and no tags
+// @Tags products
+// @Produce json
+// @Param filter query models.LowQualityFilter false "Filter"
+// @Success 200 {array} dto.ScoredProduct
+// @Router /products/low-quality [get]
+func (s *Server) lowQualityProducts(c *gin.Context) {
+	var f models.LowQualityFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	scored, err := s.products.LowQualityProducts(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewScoredProducts(c.Request.Context(), scored))
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestLowQualityProductsUseConfiguredWeightsWorstFirst(t *testing.T) {
+	weights := models.QualityWeights{MissingDescription: 40, NoImage: 30, ZeroStock: 20, NoTags: 10}
+	s, mock := newTestServer(t, Options{}, service.Options{QualityWeights: weights})
+
+	// bare has every gap and scores 0; noImage lacks images and tags and
+	// scores 60
+	bare, noImage := testProduct(), testProduct()
+	bare.Stock = 0
+	rows := sqlmock.NewRows(append(querytest.ProductColumns(), "data_quality_score")).
+		AddRow(append(querytest.ProductValues(&bare), 0)...).
+		AddRow(append(querytest.ProductValues(&noImage), 60)...)
+	mock.ExpectQuery("SELECT .+, data_quality_score FROM \\(SELECT p\\.\\*, 100 - CASE WHEN COALESCE\\(TRIM\\(description\\), ''\\) = '' THEN \\$1::int .+\\) AS products"+
+		" WHERE data_quality_score <= \\$5 ORDER BY data_quality_score ASC, id ASC LIMIT \\$6 OFFSET \\$7").
+		WithArgs(40, 30, 20, 10, 60, 10, 0).
+		WillReturnRows(rows)
+
+	w := serve(s, http.MethodGet, "/api/v1/products/low-quality?max_score=60", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []struct {
+		ID               string `json:"id"`
+		DataQualityScore int    `json:"data_quality_score"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != bare.ID.String() || got[0].DataQualityScore != 0 ||
+		got[1].ID != noImage.ID.String() || got[1].DataQualityScore != 60 {
+		t.Fatalf("expected the two products at or below 60, worst first, got %s", w.Body)
+	}
+}
+
+func TestLowQualityProductsRejectOutOfRangeScore(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodGet, "/api/v1/products/low-quality?max_score=150", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
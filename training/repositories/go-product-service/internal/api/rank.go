@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// productRank godoc
+// @Summary Get a product's rank
+// @Description The product's 1-based position and the number of products in its scope under the given ordering, e.g. #37 of 412 by price in its category
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param rank query models.RankQuery false "Ordering and scope"
+// @Success 200 {object} models.ProductRank
+// @Router /products/{id}/rank [get]
+func (s *Server) productRank(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var q models.RankQuery
+	if !s.bindQuery(c, &q) {
+		return
+	}
+	rank, err := s.products.ProductRank(c.Request.Context(), id, &q)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rank)
+}
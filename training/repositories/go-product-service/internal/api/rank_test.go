@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestProductRankWithinCategory(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectQuery("ROW_NUMBER\\(\\) OVER \\(ORDER BY price ASC, id ASC\\) AS rank, COUNT\\(\\*\\) OVER \\(\\) AS total FROM products WHERE deleted_at IS NULL AND category = \\(SELECT category FROM products WHERE id = \\$1\\)").
+		WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"rank", "total"}).AddRow(37, 412))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+id.String()+"/rank?sort_by=price&within=category", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got models.ProductRank
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Rank != 37 || got.Total != 412 || got.Within != "category" {
+		t.Fatalf("expected #37 of 412 in its category, got %s", w.Body)
+	}
+}
+
+func TestProductRankOfMissingProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectQuery("ROW_NUMBER").WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"rank", "total"}))
+
+	if w := serve(s, http.MethodGet, "/api/v1/products/"+id.String()+"/rank", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestProductRankRejectsUnknownScope(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	target := "/api/v1/products/" + uuid.NewString() + "/rank?within=supplier"
+	if w := serve(s, http.MethodGet, target, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
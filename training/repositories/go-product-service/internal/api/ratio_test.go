@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestListProductsPricedAboveCategoryAverage(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	// Chairs at 50, 55 and 500 average 201.67; only the 500 one is over 1.2 times that
+	outlier := testProduct()
+	outlier.Category, outlier.Price = "chairs", 500
+	mock.ExpectQuery("AVG\\(price\\) OVER \\(PARTITION BY category\\) AS category_avg_price,\\s+COUNT\\(\\*\\) OVER \\(PARTITION BY category\\) AS category_size"+
+		".+category = \\$1 AND category_size > 1 AND price > \\$2 \\* category_avg_price").
+		WithArgs("chairs", 1.2, 11).WillReturnRows(querytest.ProductRows(outlier))
+	mock.ExpectQuery("SELECT COUNT").WithArgs("chairs", 1.2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	expectNoSales(mock)
+
+	target := "/api/v1/products?category=chairs&price_vs_category_avg=" + url.QueryEscape(">1.2")
+	w := serveAs(s, auth.ScopeRead, http.MethodGet, target, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 1 || list.Products[0].ID != outlier.ID {
+		t.Fatalf("expected the outlier, got %s", w.Body)
+	}
+}
+
+func TestListProductsByCategoryAverageChecksTheFilter(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for _, expr := range []string{"1.2", ">abc", ">-1", "=1.2"} {
+		target := "/api/v1/products?price_vs_category_avg=" + url.QueryEscape(expr)
+		if w := serveAs(s, auth.ScopeRead, http.MethodGet, target, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", expr, w.Code, w.Body)
+		}
+	}
+	target := "/api/v1/products?price_vs_category_avg=" + url.QueryEscape(">1.2")
+	if w := serveAs(s, auth.ScopeWrite, http.MethodGet, target, ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without the read scope, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// reconcileStock godoc
+// @Summary Reconcile stock against a physical count
+// @Description Sets each counted SKU's stock to its counted quantity in one transaction, recording the variance as a reconciliation stock movement; an unknown SKU is refused with 404 and nothing is changed
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.ReconcileStockRequest true "Counted quantities"
+// @Success 200 {object} models.ReconcileResult
+// @Router /products/stock-reconcile [post]
+func (s *Server) reconcileStock(c *gin.Context) {
+	var req models.ReconcileStockRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	result, err := s.products.ReconcileStock(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestReconcileStockSetsCountAndRecordsVariance(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products WHERE sku = ANY").WithArgs(`{"DESK-1"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(id, "DESK-1"))
+	mock.ExpectQuery("SELECT stock FROM products WHERE id = \\$1 AND sku = \\$2").WithArgs(id, "DESK-1").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+	mock.ExpectExec("UPDATE products SET stock = \\$2").WithArgs(id, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").WithArgs(sqlmock.AnyArg(), id, -3, models.MovementReconciliation).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/stock-reconcile", `{"counts":[{"sku":"DESK-1","counted_qty":7}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var result models.ReconcileResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalVariance != -3 || result.AbsoluteVariance != 3 || len(result.Lines) != 1 ||
+		result.Lines[0].Previous != 10 || result.Lines[0].Counted != 7 {
+		t.Fatalf("unexpected reconciliation: %s", w.Body)
+	}
+}
+
+func TestReconcileStockRefusesUnknownSKU(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products WHERE sku = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(uuid.New(), "DESK-1"))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/stock-reconcile",
+		`{"counts":[{"sku":"DESK-1","counted_qty":7},{"sku":"GONE-1","counted_qty":2}]}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestReconcileStockRejectsRepeatedSKU(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	w := serve(s, http.MethodPost, "/api/v1/products/stock-reconcile",
+		`{"counts":[{"sku":"DESK-1","counted_qty":7},{"sku":"DESK-1","counted_qty":2}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
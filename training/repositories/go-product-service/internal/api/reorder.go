@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// reorderSuggestions godoc
+// @Summary Suggest products to reorder
+// @Description Active products whose available stock is at or below their reorder level, with the quantity that covers REORDER_COVER_DAYS of the sales seen over REORDER_VELOCITY_DAYS, fastest sellers first
+// @Tags products
+// @Produce json
+// @Param filter query models.ReorderFilter false "Paging"
+// @Success 200 {array} models.ReorderSuggestion
+// @Router /products/reorder-suggestions [get]
+func (s *Server) reorderSuggestions(c *gin.Context) {
+	var f models.ReorderFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	suggestions, err := s.products.ReorderSuggestions(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestReorderSuggestionsCoverConfiguredDays(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{ReorderVelocityDays: 7, ReorderCoverDays: 10})
+
+	// 3 available against a level of 5, selling 2.5 a day: covering 10 days
+	// needs 25, so 22 more
+	p, level := testProduct(), 5
+	p.ReorderLevel = &level
+	rows := sqlmock.NewRows(append(querytest.ProductColumns(), "daily_velocity")).
+		AddRow(append(querytest.ProductValues(&p), 2.5)...)
+	mock.ExpectQuery("WITH velocity AS .+ LEFT JOIN velocity v ON v.product_id = products.id .+ ORDER BY daily_velocity DESC").
+		WithArgs(7, 50, 0).
+		WillReturnRows(rows)
+
+	w := serve(s, http.MethodGet, "/api/v1/products/reorder-suggestions", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []models.ReorderSuggestion
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ProductID != p.ID || got[0].Available != 3 || got[0].SuggestedQty != 22 {
+		t.Fatalf("expected a suggestion of 22, got %s", w.Body)
+	}
+}
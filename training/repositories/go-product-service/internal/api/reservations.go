@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// reserveBatch godoc
+// @Summary Reserve stock for several products
+// @Description Reserves every line item in one transaction or none of them; when an item lacks stock the 409 response names it under item. Items repeating a product are coalesced into one reservation for their combined quantity.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.ReserveBatchRequest true "Line items to reserve"
+// @Success 201 {array} models.Reservation
+// @Router /products/reserve-batch [post]
+func (s *Server) reserveBatch(c *gin.Context) {
+	var req models.ReserveBatchRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	reservations, err := s.products.ReserveBatch(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, reservations)
+}
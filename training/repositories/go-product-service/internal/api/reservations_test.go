@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// expectReserved expects reserving qty of product id and recording the
+// write, the reservation itself included
+func expectReserved(mock sqlmock.Sqlmock, id uuid.UUID, qty int) {
+	p := testProduct()
+	p.ID, p.Reserved = id, qty
+	mock.ExpectExec("UPDATE products\\s+SET reserved = reserved \\+ \\$2").WithArgs(id, qty).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(id).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(models.EventProductUpdated, id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestReserveBatchReservesEveryItem(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	low := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	high := uuid.MustParse("ffffffff-0000-0000-0000-000000000001")
+	mock.ExpectBegin()
+	expectReserved(mock, low, 1)
+	expectReserved(mock, high, 2)
+	mock.ExpectCommit()
+
+	body := `{"items":[{"product_id":"` + high.String() + `","qty":2},{"product_id":"` + low.String() + `","qty":1}],"reference":"order-7"}`
+	w := serve(s, http.MethodPost, "/api/v1/products/reserve-batch", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var reservations []models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &reservations); err != nil {
+		t.Fatal(err)
+	}
+	if len(reservations) != 2 || reservations[0].ProductID != high || reservations[1].Reference != "order-7" {
+		t.Fatalf("unexpected reservations: %s", w.Body)
+	}
+}
+
+func TestReserveBatchNamesTheItemLackingStock(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WithArgs(id, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT GREATEST\\(stock - reserved, 0\\)").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(3))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/reserve-batch", `{"items":[{"product_id":"`+id.String()+`","qty":5}]}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+	var body struct {
+		Item struct {
+			Index     int       `json:"index"`
+			ProductID uuid.UUID `json:"product_id"`
+			Available int       `json:"available"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Item.Index != 0 || body.Item.ProductID != id || body.Item.Available != 3 {
+		t.Fatalf("expected the failing item in the body, got %s", w.Body)
+	}
+}
+
+func TestReserveBatchCoalescesRepeatedProducts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id, other := uuid.New(), uuid.New()
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectBegin()
+	expectReserved(mock, id, 5)
+	expectReserved(mock, other, 1)
+	mock.ExpectCommit()
+
+	body := `{"items":[{"product_id":"` + id.String() + `","qty":2},{"product_id":"` + other.String() + `","qty":1},{"product_id":"` + id.String() + `","qty":3}]}`
+	w := serve(s, http.MethodPost, "/api/v1/products/reserve-batch", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var reservations []models.Reservation
+	if err := json.Unmarshal(w.Body.Bytes(), &reservations); err != nil {
+		t.Fatal(err)
+	}
+	if len(reservations) != 2 || reservations[0].ProductID != id || reservations[0].Qty != 5 || reservations[1].ProductID != other {
+		t.Fatalf("expected one reservation of 5 for the repeated product, got %s", w.Body)
+	}
+}
+
+func TestReserveBatchRejectsRepeatedProductsWhenConfigured(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{RejectDuplicateBatchIDs: true})
+	id := uuid.New()
+	body := `{"items":[{"product_id":"` + id.String() + `","qty":2},{"product_id":"` + id.String() + `","qty":3}]}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/reserve-batch", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a repeated product, got %d: %s", w.Code, w.Body)
+	}
+}
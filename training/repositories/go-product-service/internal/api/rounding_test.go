@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestDisplayRoundingAgreesAcrossListAndDetail(t *testing.T) {
+	s, mock := newTestServer(t, Options{DisplayRounding: &dto.Rounding{DefaultCurrency: "USD"}}, service.Options{})
+	usd, jpy, none := testProduct(), testProduct(), testProduct()
+	usdCode, jpyCode := "USD", "JPY"
+	usd.Currency, usd.Price = &usdCode, 19.996
+	jpy.Currency, jpy.Price = &jpyCode, 1234.6
+	none.Price = 5.006
+	salePrice, saleStart := 14.994, time.Now().Add(-time.Hour)
+	usd.SalePrice, usd.SaleStartsAt = &salePrice, &saleStart
+	want := map[string]float64{usd.ID.String(): 20, jpy.ID.String(): 1235, none.ID.String(): 5.01}
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL").WillReturnRows(querytest.ProductRows(usd, jpy, none))
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	w := serve(s, http.MethodGet, "/api/v1/products", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range list.Products {
+		if p.Price != want[p.ID.String()] {
+			t.Errorf("list: product %s priced %v, want %v", p.ID, p.Price, want[p.ID.String()])
+		}
+		if p.ID == usd.ID && (p.SalePrice == nil || *p.SalePrice != 14.99) {
+			t.Errorf("list: expected the sale price rounded to 14.99, got %v", p.SalePrice)
+		}
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(jpy.ID).WillReturnRows(querytest.ProductRows(jpy))
+	w = serve(s, http.MethodGet, "/api/v1/products/"+jpy.ID.String(), "")
+	var detail dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Price != 1235 {
+		t.Fatalf("detail: expected the zero-decimal price 1235, got %v", detail.Price)
+	}
+}
+
+func TestDisplayRoundingAppliesToActiveSalePrice(t *testing.T) {
+	s, mock := newTestServer(t, Options{DisplayRounding: &dto.Rounding{DefaultCurrency: "USD"}}, service.Options{})
+	p := testProduct()
+	jpyCode, salePrice, saleStart := "JPY", 999.6, time.Now().Add(-time.Hour)
+	p.Currency, p.Price = &jpyCode, 1234.6
+	p.SalePrice, p.SaleStartsAt = &salePrice, &saleStart
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("SELECT product_id, min_qty, price FROM product_price_tiers").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "min_qty", "price"}))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String()+"/price?qty=3", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got models.EffectivePrice
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.OnSale || got.BasePrice != 1235 || got.UnitPrice != 1000 || got.Total != 3000 {
+		t.Fatalf("expected the sale price 1000 x 3 = 3000 against 1235, got %+v", got)
+	}
+}
+
+func TestPricesAreUnroundedByDefault(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Price = 19.996
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "")
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Price != 19.996 {
+		t.Fatalf("expected the stored price, got %v", got.Price)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// upcomingSales godoc
+// @Summary List upcoming sales
+// @Description Products whose sale has not started but starts within the window, soonest first
+// @Tags products
+// @Produce json
+// @Param filter query models.UpcomingSalesFilter false "Window (e.g. 7d, 12h, up to 90d) and paging"
+// @Success 200 {array} dto.Product
+// @Router /products/upcoming-sales [get]
+func (s *Server) upcomingSales(c *gin.Context) {
+	var f models.UpcomingSalesFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	products, err := s.products.UpcomingSales(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProducts(c.Request.Context(), products))
+}
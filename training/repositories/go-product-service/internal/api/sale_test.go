@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestUpcomingSalesUseInjectedClock(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s, mock := newTestServer(t, Options{}, service.Options{Now: func() time.Time { return now }})
+	p := testProduct()
+	sale, starts := 99.0, now.Add(48*time.Hour)
+	p.SalePrice, p.SaleStartsAt = &sale, &starts
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND sale_price IS NOT NULL AND sale_starts_at > \\$1 AND sale_starts_at <= \\$2 ORDER BY sale_starts_at ASC").
+		WithArgs(now, now.Add(7*24*time.Hour), 50, 0).
+		WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/upcoming-sales?within=7d", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != p.ID.String() {
+		t.Fatalf("expected the one upcoming sale, got %s", w.Body)
+	}
+}
+
+func TestUpcomingSalesRejectInvalidWindow(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	for _, within := range []string{"soon", "-1d", "91d"} {
+		if w := serve(s, http.MethodGet, "/api/v1/products/upcoming-sales?within="+within, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("within=%s: expected 400, got %d: %s", within, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// createSavedQuery godoc
+// @Summary Save a product query
+// @Description Stores a named product filter that GET /products runs through ?saved_query=; its paging is not stored
+// @Tags saved-queries
+// @Accept json
+// @Produce json
+// @Param query body models.SaveQueryRequest true "Saved query"
+// @Success 201 {object} models.SavedQuery
+// @Router /saved-queries [post]
+func (s *Server) createSavedQuery(c *gin.Context) {
+	var req models.SaveQueryRequest
+	if !s.decodeJSON(c, &req) {
+		return
+	}
+	(*models.ProductFilter)(&req.Filter).ApplyDefaults()
+	if !s.validateStruct(c, &req) {
+		return
+	}
+	q, err := s.products.CreateSavedQuery(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, q)
+}
+
+// listSavedQueries godoc
+// @Summary List saved queries
+// @Tags saved-queries
+// @Produce json
+// @Param limit query int false "Page size" default(10)
+// @Param offset query int false "Rows to skip" default(0)
+// @Success 200 {array} models.SavedQuery
+// @Router /saved-queries [get]
+func (s *Server) listSavedQueries(c *gin.Context) {
+	var page models.PageFilter
+	if !s.bindQuery(c, &page) {
+		return
+	}
+	queries, err := s.products.ListSavedQueries(c.Request.Context(), &page)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, queries)
+}
+
+// getSavedQuery godoc
+// @Summary Get a saved query
+// @Tags saved-queries
+// @Produce json
+// @Param name path string true "Query name"
+// @Success 200 {object} models.SavedQuery
+// @Router /saved-queries/{name} [get]
+func (s *Server) getSavedQuery(c *gin.Context) {
+	q, err := s.products.GetSavedQuery(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, q)
+}
+
+// updateSavedQuery godoc
+// @Summary Replace a saved query
+// @Description Replaces the description and filter of a saved query; the name comes from the path
+// @Tags saved-queries
+// @Accept json
+// @Produce json
+// @Param name path string true "Query name"
+// @Param query body models.SaveQueryRequest true "Saved query"
+// @Success 200 {object} models.SavedQuery
+// @Router /saved-queries/{name} [put]
+func (s *Server) updateSavedQuery(c *gin.Context) {
+	var req models.SaveQueryRequest
+	if !s.decodeJSON(c, &req) {
+		return
+	}
+	req.Name = c.Param("name")
+	(*models.ProductFilter)(&req.Filter).ApplyDefaults()
+	if !s.validateStruct(c, &req) {
+		return
+	}
+	q, err := s.products.UpdateSavedQuery(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, q)
+}
+
+// deleteSavedQuery godoc
+// @Summary Delete a saved query
+// @Tags saved-queries
+// @Param name path string true "Query name"
+// @Success 204
+// @Router /saved-queries/{name} [delete]
+func (s *Server) deleteSavedQuery(c *gin.Context) {
+	if err := s.products.DeleteSavedQuery(c.Request.Context(), c.Param("name")); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/lib/pq"
+)
+
+var savedQueryColumns = []string{"name", "description", "filter", "created_at", "updated_at"}
+
+func TestCreateSavedQueryDropsPaging(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO saved_queries").
+		WithArgs("big-desks", "Desks over 100", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(savedQueryColumns).
+			AddRow("big-desks", "Desks over 100", []byte(`{"category":"furniture","min_price":100,"limit":10,"sort_by":"created_at","sort_order":"desc"}`), now, now))
+
+	body := `{"name":"big-desks","description":"Desks over 100","filter":{"category":"furniture","min_price":100,"limit":50,"offset":20}}`
+	w := serve(s, http.MethodPost, "/api/v1/saved-queries", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got models.SavedQuery
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Filter.Category != "furniture" || got.Filter.Limit != 10 || got.Filter.Offset != 0 {
+		t.Fatalf("expected the filter stored without its paging, got %s", w.Body)
+	}
+}
+
+func TestCreateSavedQueryRejectsBadFilter(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	body := `{"name":"bad","filter":{"sort_by":"cost"}}`
+	if w := serve(s, http.MethodPost, "/api/v1/saved-queries", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestListProductsThroughSavedQuery(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	now := time.Now()
+	mock.ExpectQuery("SELECT name, description, filter, created_at, updated_at FROM saved_queries WHERE name = \\$1").
+		WithArgs("big-desks").
+		WillReturnRows(sqlmock.NewRows(savedQueryColumns).
+			AddRow("big-desks", "", []byte(`{"category":"furniture","min_price":100,"sort_by":"created_at","sort_order":"desc"}`), now, now))
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL .+category.+price >=").
+		WithArgs("furniture", 100.0, 6).WillReturnRows(querytest.ProductRows(testProduct()))
+	mock.ExpectQuery("SELECT COUNT").WithArgs("furniture", 100.0).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	w := serve(s, http.MethodGet, "/api/v1/products?saved_query=big-desks&category=ignored&limit=5", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list struct {
+		Products []json.RawMessage `json:"products"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 1 {
+		t.Fatalf("expected the saved query's product, got %s", w.Body)
+	}
+}
+
+func TestUnknownSavedQuery(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("FROM saved_queries WHERE name = \\$1").WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(savedQueryColumns))
+	if w := serve(s, http.MethodGet, "/api/v1/products?saved_query=missing", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+
+	mock.ExpectExec("DELETE FROM saved_queries").WithArgs("missing").WillReturnResult(sqlmock.NewResult(0, 0))
+	if w := serve(s, http.MethodDelete, "/api/v1/saved-queries/missing", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateSavedQueryNameTaken(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("INSERT INTO saved_queries").WillReturnError(&pq.Error{Code: "23505"})
+	if w := serve(s, http.MethodPost, "/api/v1/saved-queries", `{"name":"taken","filter":{}}`); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// expectEmptySearch expects a word search for term that matches nothing
+func expectEmptySearch(mock sqlmock.Sqlmock, term string) {
+	mock.ExpectQuery("search_vector @@ plainto_tsquery\\('simple', \\$1\\)").WithArgs(term, 11).
+		WillReturnRows(querytest.ProductRows())
+	mock.ExpectQuery("SELECT COUNT").WithArgs(term).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+}
+
+func TestMisspelledSearchSuggestsSimilarNames(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{SearchSuggestionLimit: 3})
+	expectEmptySearch(mock, "chiar")
+	mock.ExpectQuery("SELECT name FROM products WHERE .*similarity\\(name, \\$1\\) > \\$2 GROUP BY name").
+		WithArgs("chiar", 0.3, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Chair").AddRow("Armchair"))
+
+	w := serve(s, http.MethodGet, "/api/v1/products?search=chiar&match_mode=word", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var list dto.ProductList
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Products) != 0 || strings.Join(list.Suggestions, ",") != "Chair,Armchair" {
+		t.Fatalf("expected suggestions for the misspelling, got %s", w.Body)
+	}
+}
+
+func TestEmptySearchWithoutSuggestionsIsAPlainEmptyList(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	expectEmptySearch(mock, "chiar")
+
+	w := serve(s, http.MethodGet, "/api/v1/products?search=chiar&match_mode=word", "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "suggestions") {
+		t.Fatalf("expected a plain empty list, got %d: %s", w.Code, w.Body)
+	}
+}
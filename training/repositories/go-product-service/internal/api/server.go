@@ -0,0 +1,232 @@
+// Package api serves the product REST API
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/health"
+	"github.com/company/go-product-service/internal/idempotency"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/company/go-product-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Options configures the REST server
+type Options struct {
+	// Validate checks request payloads; share it with the gRPC server so
+	// both APIs reject the same input
+	Validate *validator.Validate
+
+	// Limits bound the body size and handling time of every request;
+	// ImportLimits replace them on the import and export routes
+	Limits       middleware.Limits
+	ImportLimits middleware.Limits
+
+	// ImportDecimalSeparator is the decimal separator of CSV import prices:
+	// ".", "," or "auto"; empty means "."
+	ImportDecimalSeparator string
+
+	// MaxConcurrentExports bounds the exports running at once; up to
+	// ExportQueueSize more wait for a slot and the rest get 429. Zero
+	// leaves exports unlimited.
+	MaxConcurrentExports int
+	ExportQueueSize      int
+
+	// StrictJSON rejects unknown request body fields on every request;
+	// without it a client opts in per request with the X-Strict-JSON header
+	StrictJSON bool
+
+	// Metrics, when set, records every request and is served at /metrics
+	Metrics *telemetry.Metrics
+
+	// Idempotency, when set, replays the stored response of a POST retried
+	// with the same Idempotency-Key for IdempotencyTTL
+	Idempotency    idempotency.Store
+	IdempotencyTTL time.Duration
+
+	// DisplayRounding, when set, rounds response prices to the minor units of
+	// each product's currency; stored prices are unaffected
+	DisplayRounding *dto.Rounding
+
+	// Security, when set, adds the hardening headers to every response and
+	// may redirect plain HTTP to HTTPS; production deployments set it
+	Security *middleware.Security
+
+	// MaskIdentifiersForAnonymous omits SKUs and barcodes from the product
+	// responses of unauthenticated callers
+	MaskIdentifiersForAnonymous bool
+
+	// HeadProduct serves HEAD /products/:id with the headers of the GET,
+	// Content-Length included, and no body
+	HeadProduct bool
+
+	// FilterExplain serves POST /admin/filter-explain, which reveals the
+	// generated SQL; it must stay off in production
+	FilterExplain bool
+
+	// Readiness, when set, answers /readyz from its cached database check;
+	// /healthz is always served and never touches the database
+	Readiness *health.ReadinessChecker
+
+	// Tracing starts a server span per request, named after ServiceName
+	Tracing     bool
+	ServiceName string
+}
+
+// Server routes REST requests to the product service
+type Server struct {
+	router   *gin.Engine
+	products *service.ProductService
+	logger   *logger.Logger
+	validate *validator.Validate
+	opts     Options
+}
+
+// NewServer creates the server and registers every route
+func NewServer(products *service.ProductService, logger *logger.Logger, opts Options) *Server {
+	validate := opts.Validate
+	if validate == nil {
+		validate = validator.New()
+	}
+	s := &Server{
+		router:   gin.New(),
+		products: products,
+		logger:   logger,
+		validate: validate,
+		opts:     opts,
+	}
+	if opts.Tracing {
+		s.router.Use(telemetry.TracingMiddleware(opts.ServiceName))
+	}
+	if opts.Metrics != nil {
+		s.router.Use(opts.Metrics.Middleware())
+	}
+	s.router.Use(gin.Recovery(), middleware.Authenticate(), middleware.ReadConsistency(), middleware.RequestLimits(opts.Limits, map[string]middleware.Limits{
+		middleware.RouteKey(http.MethodPost, "/api/v1/products/import"):            opts.ImportLimits,
+		middleware.RouteKey(http.MethodGet, "/api/v1/products/export"):             opts.ImportLimits,
+		middleware.RouteKey(http.MethodGet, "/api/v1/products/export/incremental"): opts.ImportLimits,
+	}))
+	if opts.Security != nil {
+		s.router.Use(middleware.SecurityHeaders(*opts.Security))
+	}
+	if opts.DisplayRounding != nil {
+		s.router.Use(middleware.DisplayRounding(*opts.DisplayRounding))
+	}
+	if opts.MaskIdentifiersForAnonymous {
+		s.router.Use(middleware.MaskIdentifiers())
+	}
+	if opts.Idempotency != nil {
+		s.router.Use(middleware.Idempotency(opts.Idempotency, opts.IdempotencyTTL))
+	}
+	s.routes()
+	return s
+}
+
+// routes registers the API routes
+func (s *Server) routes() {
+	if s.opts.Metrics != nil {
+		s.router.GET("/metrics", gin.WrapH(s.opts.Metrics.Handler()))
+	}
+	s.router.GET("/healthz", health.LivenessHandler())
+	if s.opts.Readiness != nil {
+		s.router.GET("/readyz", health.ReadinessHandler(s.opts.Readiness))
+	}
+
+	v1 := s.router.Group("/api/v1")
+
+	products := v1.Group("/products")
+	products.POST("", s.createProduct)
+	products.POST("/draft", s.createDraft)
+	products.POST("/bulk", s.bulkCreateProducts)
+	products.GET("", s.listProducts)
+	products.POST("/search", s.searchProducts)
+	products.POST("/import", s.importProducts)
+	// Both exports share one limit, as both scan large result sets
+	exports := products.Group("/export")
+	if s.opts.MaxConcurrentExports > 0 {
+		exports.Use(middleware.ConcurrencyLimit(s.opts.MaxConcurrentExports, s.opts.ExportQueueSize))
+	}
+	exports.GET("", s.exportProducts)
+	exports.GET("/incremental", s.exportIncremental)
+	products.POST("/images/bulk", s.attachImages)
+	products.POST("/check-availability", s.checkAvailability)
+	products.POST("/cart-snapshot", s.cartSnapshot)
+	products.POST("/reserve-batch", s.reserveBatch)
+	products.POST("/stock-reconcile", s.reconcileStock)
+	products.POST("/bulk-get", s.bulkGetProducts)
+	products.POST("/bulk-delete", s.bulkDeleteProducts)
+	products.POST("/swap-skus", s.swapSKUs)
+	products.POST("/by-barcodes", s.lookupBarcodes)
+	products.POST("/bulk-price-update", s.updateBulkPrices)
+	products.POST("/bulk-price-update/preview", s.previewBulkPrices)
+	products.GET("/stats", s.productStats)
+	products.GET("/stats/creation-timeline", s.creationTimeline)
+	products.GET("/grouped", s.groupedProducts)
+	products.POST("/by-category-sample", s.categorySample)
+	products.GET("/loss-making", s.lossMakingProducts)
+	products.GET("/never-sold", s.neverSoldProducts)
+	products.GET("/low-quality", s.lowQualityProducts)
+	products.GET("/update-frequency", s.updateFrequency)
+	products.GET("/reorder-suggestions", s.reorderSuggestions)
+	products.GET("/upcoming-sales", s.upcomingSales)
+	products.GET("/:id", s.getProduct)
+	if s.opts.HeadProduct {
+		products.HEAD("/:id", middleware.HeadResponse(), s.getProduct)
+	}
+	products.PUT("/:id", s.updateProduct)
+	products.DELETE("/:id", s.deleteProduct)
+	products.POST("/:id/publish", s.publishDraft)
+	products.POST("/:id/transition", s.transitionStatus)
+	products.GET("/:id/similar", s.similarProducts)
+	products.GET("/:id/full", s.getFullProduct)
+	products.GET("/:id/price", s.effectivePrice)
+	products.GET("/:id/rank", s.productRank)
+	products.PUT("/:id/price-tiers", s.setPriceTiers)
+	products.POST("/:id/variants", s.createVariant)
+	products.DELETE("/:id/variants/:variant_id", s.deleteVariant)
+	products.PUT("/:id/translations/:locale", s.setTranslation)
+	products.DELETE("/:id/translations/:locale", s.deleteTranslation)
+	products.GET("/:id/field-history/:field", s.fieldHistory)
+	products.POST("/:id/stock-adjustments", s.adjustStock)
+	products.POST("/:id/price-watches", s.createPriceWatch)
+	products.DELETE("/:id/price-watches/:watch_id", s.deletePriceWatch)
+
+	categories := v1.Group("/categories")
+	categories.POST("/rename", s.renameCategory)
+	categories.GET("/:name/usage", s.categoryUsage)
+	categories.DELETE("/:name", s.deleteCategory)
+
+	tags := v1.Group("/tags")
+	tags.GET("", s.tagCounts)
+	tags.DELETE("/:tag", s.deleteTag)
+
+	admin := v1.Group("/admin")
+	admin.GET("/audit/export", s.exportAudit)
+	admin.GET("/outbox/dead", s.deadOutboxEvents)
+	admin.POST("/outbox/dead/:id/retry", s.retryOutboxEvent)
+	if s.opts.FilterExplain {
+		admin.POST("/filter-explain", s.explainFilter)
+	}
+
+	mappings := v1.Group("/column-mappings")
+	mappings.POST("", s.saveColumnMapping)
+	mappings.GET("", s.listColumnMappings)
+	mappings.GET("/:name", s.getColumnMapping)
+
+	saved := v1.Group("/saved-queries")
+	saved.POST("", s.createSavedQuery)
+	saved.GET("", s.listSavedQueries)
+	saved.GET("/:name", s.getSavedQuery)
+	saved.PUT("/:name", s.updateSavedQuery)
+	saved.DELETE("/:name", s.deleteSavedQuery)
+}
+
+// Handler returns the HTTP handler serving every route
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/middleware"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/repository"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/company/go-product-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// errDriver stands in for a database failure whose text must not leak
+var errDriver = errors.New("pq: connection reset by peer")
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestServer returns a server on a mocked database with the given options
+func newTestServer(t *testing.T, opts Options, serviceOpts service.Options) (*Server, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+	log := logger.New(zap.NewNop(), zap.NewAtomicLevel())
+	products := service.NewProductService(repository.NewProductRepository(db), log, serviceOpts)
+	return NewServer(products, log, opts), mock
+}
+
+// serve sends a request to s and returns the recorded response
+func serve(s *Server, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+	return w
+}
+
+// serveAs sends a request as an authenticated client granted scopes,
+// comma-separated as the gateway sends them
+func serveAs(s *Server, scopes, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(middleware.ClientIDHeader, "client-1")
+	req.Header.Set(middleware.ScopesHeader, scopes)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+	return w
+}
+
+func TestMetricsRecordRouteTemplates(t *testing.T) {
+	s, mock := newTestServer(t, Options{Metrics: telemetry.NewMetrics()}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+
+	serve(s, http.MethodGet, "/api/v1/products/"+p.ID.String(), "")
+	serve(s, http.MethodGet, "/no/such/route", "")
+
+	body := serve(s, http.MethodGet, "/metrics", "").Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/api/v1/products/:id",status="200"} 1`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		"http_requests_in_flight",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
+
+func TestSecurityHeadersOnlyWhenConfigured(t *testing.T) {
+	production, _ := newTestServer(t, Options{Security: &middleware.Security{ContentSecurityPolicy: "default-src 'none'"}}, service.Options{})
+	w := serve(production, http.MethodGet, "/healthz", "")
+	if w.Header().Get("X-Content-Type-Options") != "nosniff" || w.Header().Get("X-Frame-Options") != "DENY" ||
+		w.Header().Get("Content-Security-Policy") != "default-src 'none'" {
+		t.Fatalf("expected the security headers in production, got %v", w.Header())
+	}
+
+	development, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(development, http.MethodGet, "/healthz", ""); w.Header().Get("X-Frame-Options") != "" {
+		t.Fatalf("expected no security headers in development, got %v", w.Header())
+	}
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// similarProducts godoc
+// @Summary List similar products
+// @Description Active products in the same category within a price band around the product
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param filter query models.SimilarProductsFilter false "Filter"
+// @Success 200 {array} dto.Product
+// @Router /products/{id}/similar [get]
+func (s *Server) similarProducts(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var f models.SimilarProductsFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	products, err := s.products.SimilarProducts(c.Request.Context(), id, &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProducts(c.Request.Context(), products))
+}
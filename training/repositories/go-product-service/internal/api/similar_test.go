@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestSimilarProductsExcludeSourceAndMatchCategory(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	source := testProduct()
+	match := testProduct()
+	match.Price = 110
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(source.ID).
+		WillReturnRows(querytest.ProductRows(source))
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND is_active AND category = \\$1 AND id <> \\$2 AND price BETWEEN \\$3 AND \\$4 ORDER BY ABS\\(price - \\$5\\), id ASC LIMIT \\$6").
+		WithArgs("furniture", source.ID, 96.0, 144.0, 120.0, 8).
+		WillReturnRows(querytest.ProductRows(match))
+
+	target := "/api/v1/products/" + source.ID.String() + "/similar"
+	for i := 0; i < 2; i++ {
+		w := serve(s, http.MethodGet, target, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+		}
+		var got []dto.Product
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != match.ID || got[0].Category != source.Category {
+			t.Fatalf("unexpected similar products: %+v", got)
+		}
+	}
+}
+
+func TestSimilarProductsValidatesFilter(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	id := testProduct().ID.String()
+	if w := serve(s, http.MethodGet, "/api/v1/products/"+id+"/similar?price_band=2", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// swapSKUs godoc
+// @Summary Swap two products' SKUs
+// @Description Exchanges the SKUs of two live products in one transaction, auditing both changes
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body models.SwapSKUsRequest true "The two product IDs"
+// @Success 200 {array} models.SKUSwapResult
+// @Router /products/swap-skus [post]
+func (s *Server) swapSKUs(c *gin.Context) {
+	var req models.SwapSKUsRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	results, err := s.products.SwapSKUs(c.Request.Context(), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestSwapSKUsParksOneSKUBeforeExchanging(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	a, b := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(a, "DESK-1").AddRow(b, "LAMP-1"))
+	// a leaves DESK-1 for a temporary SKU first, so no step ever holds a taken SKU
+	mock.ExpectExec("UPDATE products SET sku").WithArgs(a, "swap:"+a.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE products SET sku").WithArgs(b, "DESK-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE products SET sku").WithArgs(a, "LAMP-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/swap-skus", `{"product_ids":["`+a.String()+`","`+b.String()+`"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []models.SKUSwapResult
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].NewSKU != "LAMP-1" || got[1].NewSKU != "DESK-1" {
+		t.Fatalf("unexpected swap: %s", w.Body)
+	}
+}
+
+func TestSwapSKUsRejectsMissingProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	a, b := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(a, "DESK-1"))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/swap-skus", `{"product_ids":["`+a.String()+`","`+b.String()+`"]}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// productStats godoc
+// @Summary Get dashboard stats
+// @Description Totals, active and out-of-stock counts, inventory value and the category breakdown, cached briefly
+// @Tags products
+// @Produce json
+// @Success 200 {object} models.ProductStats
+// @Router /products/stats [get]
+func (s *Server) productStats(c *gin.Context) {
+	st, err := s.products.ProductStats(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, st)
+}
+
+// creationTimeline godoc
+// @Summary Get the product creation timeline
+// @Description Counts products created per hour, day, week or month in [from, until), oldest bucket first; empty buckets are omitted
+// @Tags products
+// @Produce json
+// @Param interval query string false "hour, day (default), week or month"
+// @Param from query string true "Start of the window, inclusive (RFC 3339)"
+// @Param until query string true "End of the window, exclusive (RFC 3339)"
+// @Success 200 {array} models.TimelineBucket
+// @Router /products/stats/creation-timeline [get]
+func (s *Server) creationTimeline(c *gin.Context) {
+	var f models.CreationTimelineFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	buckets, err := s.products.CreationTimeline(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, buckets)
+}
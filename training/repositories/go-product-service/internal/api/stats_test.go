@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestProductStatsAreCountedAndCached(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("SELECT\\s+COUNT\\(\\*\\) AS total_products").
+		WillReturnRows(sqlmock.NewRows([]string{"total_products", "active_products", "out_of_stock", "inventory_value"}).
+			AddRow(5, 4, 1, 1250.5))
+	mock.ExpectQuery("SELECT category, COUNT\\(\\*\\) AS count").
+		WillReturnRows(sqlmock.NewRows([]string{"category", "count"}).AddRow("furniture", 3).AddRow("lighting", 2))
+
+	var first []byte
+	for i := 0; i < 2; i++ {
+		w := serve(s, http.MethodGet, "/api/v1/products/stats", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+		}
+		if i == 0 {
+			first = w.Body.Bytes()
+		} else if w.Body.String() != string(first) {
+			t.Fatalf("expected the cached stats, got %s", w.Body)
+		}
+	}
+
+	var st models.ProductStats
+	if err := json.Unmarshal(first, &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.TotalProducts != 5 || st.ActiveProducts != 4 || st.OutOfStock != 1 || st.InventoryValue != 1250.5 {
+		t.Fatalf("unexpected totals: %+v", st)
+	}
+	if len(st.Categories) != 2 || st.Categories[0] != (models.CategoryCount{Category: "furniture", Count: 3}) {
+		t.Fatalf("unexpected categories: %+v", st.Categories)
+	}
+}
+
+func TestCreationTimelineBucketsByDay(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 0, 2)
+	mock.ExpectQuery("SELECT date_trunc\\('day', created_at\\) AS bucket, COUNT\\(\\*\\) AS count FROM products WHERE created_at >= \\$1 AND created_at < \\$2 GROUP BY date_trunc\\('day', created_at\\) ORDER BY bucket ASC").
+		WithArgs(from, until).
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}).AddRow(from, 3).AddRow(from.AddDate(0, 0, 1), 2))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/stats/creation-timeline?interval=day&from=2024-03-01T00:00:00Z&until=2024-03-03T00:00:00Z", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var buckets []models.TimelineBucket
+	if err := json.Unmarshal(w.Body.Bytes(), &buckets); err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 || !buckets[0].Bucket.Equal(from) || buckets[0].Count != 3 ||
+		!buckets[1].Bucket.Equal(from.AddDate(0, 0, 1)) || buckets[1].Count != 2 {
+		t.Fatalf("unexpected buckets: %+v", buckets)
+	}
+}
+
+func TestCreationTimelineRejectsUnknownInterval(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	w := serve(s, http.MethodGet, "/api/v1/products/stats/creation-timeline?interval=minute&from=2024-03-01T00:00:00Z&until=2024-03-03T00:00:00Z", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown interval, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// transitionStatus godoc
+// @Summary Change a product's lifecycle status
+// @Description Moves the product to draft, active, archived or discontinued; transitions the lifecycle does not allow return 409
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param transition body models.TransitionRequest true "Target status"
+// @Success 200 {object} dto.Product
+// @Router /products/{id}/transition [post]
+func (s *Server) transitionStatus(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.TransitionRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	p, err := s.products.TransitionStatus(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProduct(c.Request.Context(), p))
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestTransitionActiveToDiscontinued(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	moved := p
+	moved.Status, moved.IsActive = models.StatusDiscontinued, false
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET status = \\$2::varchar, is_active = \\(\\$2::varchar = 'active'\\)").
+		WithArgs(p.ID, models.StatusDiscontinued, models.StatusActive).
+		WillReturnRows(querytest.ProductRows(moved))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/transition", `{"status":"discontinued"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.StatusDiscontinued || got.IsActive {
+		t.Fatalf("expected an inactive discontinued product, got %+v", got)
+	}
+}
+
+func TestTransitionDiscontinuedToActiveConflicts(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Status, p.IsActive = models.StatusDiscontinued, false
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/transition", `{"status":"active"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestTransitionConflictsWhenStatusMovedConcurrently(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET status").WillReturnRows(sqlmock.NewRows(querytest.ProductColumns()))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/transition", `{"status":"archived"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestTransitionRejectsUnknownStatus(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	if w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/transition", `{"status":"deleted"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// adjustStock godoc
+// @Summary Adjust a product's stock
+// @Description Adds the delta to the stock and records a stock movement; a delta that would make stock negative is refused with 409. Deployments may deactivate products that reach zero stock and reactivate restocked ones.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param adjustment body models.StockAdjustmentRequest true "Stock delta"
+// @Success 200 {object} dto.Product
+// @Router /products/{id}/stock-adjustments [post]
+func (s *Server) adjustStock(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.StockAdjustmentRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	p, err := s.products.AdjustStock(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProduct(c.Request.Context(), p))
+}
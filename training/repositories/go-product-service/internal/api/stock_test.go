@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+// expectAdjustment expects a stock adjustment of p by delta that leaves the
+// product active or not, with the activation event it should enqueue
+func expectAdjustment(mock sqlmock.Sqlmock, p models.Product, delta int, activation models.StockActivation, isActive bool, event string) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("WITH current AS").WithArgs(p.ID, delta, activation.DeactivateOnZero, activation.ReactivateOnRestock).
+		WillReturnRows(sqlmock.NewRows([]string{"stock", "is_active", "was_active"}).AddRow(p.Stock+delta, isActive, p.IsActive))
+	mock.ExpectExec("INSERT INTO stock_movements").WithArgs(sqlmock.AnyArg(), p.ID, delta, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(models.EventProductUpdated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if event != "" {
+		mock.ExpectExec("INSERT INTO outbox_events").WithArgs(event, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func adjust(t *testing.T, s *Server, p models.Product, body string) dto.Product {
+	t.Helper()
+	w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/stock-adjustments", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestAdjustStockDeactivatesAtZero(t *testing.T) {
+	activation := models.StockActivation{DeactivateOnZero: true}
+	s, mock := newTestServer(t, Options{}, service.Options{StockActivation: activation})
+	p := testProduct()
+	p.Stock = 3
+	expectAdjustment(mock, p, -3, activation, false, models.EventProductDeactivated)
+
+	got := adjust(t, s, p, `{"delta":-3,"reason":"sale"}`)
+	if got.Stock != 0 || got.IsActive {
+		t.Fatalf("expected an inactive product without stock, got stock %d active %v", got.Stock, got.IsActive)
+	}
+}
+
+func TestAdjustStockReactivatesOnRestock(t *testing.T) {
+	activation := models.StockActivation{DeactivateOnZero: true, ReactivateOnRestock: true}
+	s, mock := newTestServer(t, Options{}, service.Options{StockActivation: activation})
+	p := testProduct()
+	p.Stock, p.IsActive = 0, false
+	expectAdjustment(mock, p, 5, activation, true, models.EventProductReactivated)
+
+	got := adjust(t, s, p, `{"delta":5,"reason":"restock"}`)
+	if got.Stock != 5 || !got.IsActive {
+		t.Fatalf("expected an active product with stock, got stock %d active %v", got.Stock, got.IsActive)
+	}
+}
+
+func TestAdjustStockLeavesActivationAloneByDefault(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Stock = 3
+	expectAdjustment(mock, p, -3, models.StockActivation{}, true, "")
+
+	if got := adjust(t, s, p, `{"delta":-3}`); got.Stock != 0 || !got.IsActive {
+		t.Fatalf("expected an active product without stock, got stock %d active %v", got.Stock, got.IsActive)
+	}
+}
+
+func TestAdjustStockRefusesNegativeStock(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	p.Stock = 2
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").WithArgs(p.ID).
+		WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("WITH current AS").WithArgs(p.ID, -3, false, false).
+		WillReturnRows(sqlmock.NewRows([]string{"stock", "is_active", "was_active"}))
+	mock.ExpectRollback()
+
+	w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/stock-adjustments", `{"delta":-3}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// tagCounts godoc
+// @Summary Count the products using each tag
+// @Description Live products per tag, most used first; tags used by fewer than min_count products are hidden
+// @Tags tags
+// @Produce json
+// @Param filter query models.TagCountFilter false "Minimum count"
+// @Success 200 {array} models.TagCount
+// @Router /tags [get]
+func (s *Server) tagCounts(c *gin.Context) {
+	var f models.TagCountFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	counts, err := s.products.TagCounts(c.Request.Context(), &f)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, counts)
+}
+
+// deleteTag godoc
+// @Summary Delete a tag
+// @Description Removes the tag from every product in one audited transaction; a tag no product carries is refused with 404
+// @Tags tags
+// @Produce json
+// @Param tag path string true "Tag"
+// @Success 200 {object} models.DeleteTagResult
+// @Router /tags/{tag} [delete]
+func (s *Server) deleteTag(c *gin.Context) {
+	result, err := s.products.DeleteTag(c.Request.Context(), c.Param("tag"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
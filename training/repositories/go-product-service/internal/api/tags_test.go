@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func TestTagCountsMostUsedFirst(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectQuery("SELECT t.tag, COUNT\\(\\*\\) AS count FROM product_tags t .+ HAVING COUNT\\(\\*\\) >= \\$1 ORDER BY count DESC").
+		WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"tag", "count"}).AddRow("oak", 5).AddRow("sale", 2))
+
+	w := serve(s, http.MethodGet, "/api/v1/tags?min_count=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got []models.TagCount
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != (models.TagCount{Tag: "oak", Count: 5}) || got[1] != (models.TagCount{Tag: "sale", Count: 2}) {
+		t.Fatalf("unexpected counts: %s", w.Body)
+	}
+}
+
+func TestDeleteTagRemovesItFromEveryProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	a, b := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM product_tags WHERE tag = \\$1 RETURNING product_id").WithArgs("sale").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(a).AddRow(b))
+	mock.ExpectExec("UPDATE products SET updated_at").WithArgs("{\"" + a.String() + "\",\"" + b.String() + "\"}").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodDelete, "/api/v1/tags/sale", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got models.DeleteTagResult
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Tag != "sale" || len(got.Products) != 2 {
+		t.Fatalf("expected the tag removed from both products, got %s", w.Body)
+	}
+}
+
+func TestDeleteUnusedTag(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM product_tags").WithArgs("gone").WillReturnRows(sqlmock.NewRows([]string{"product_id"}))
+	mock.ExpectRollback()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/tags/gone", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/productio"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of the import and export formats
+const (
+	mediaTypeCSV   = "text/csv"
+	mediaTypeJSONL = "application/x-ndjson"
+)
+
+// Import modes
+const (
+	importModeCreate = "create"
+	importModeUpsert = "upsert"
+)
+
+// exportMediaTypes maps each export format to its Content-Type
+var exportMediaTypes = map[string]string{
+	service.FormatCSV:   mediaTypeCSV,
+	service.FormatJSONL: mediaTypeJSONL,
+}
+
+// importProducts godoc
+// @Summary Import products
+// @Description Streams CSV (text/csv) or NDJSON (application/x-ndjson) rows, validated like single creates
+// @Tags products
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param mapping query string false "Stored column mapping for CSV headers"
+// @Param mode query string false "create (default) rejects existing SKUs; upsert updates them and skips unchanged rows"
+// @Success 200 {object} models.ImportReport
+// @Router /products/import [post]
+func (s *Server) importProducts(c *gin.Context) {
+	var upsert bool
+	switch mode := c.DefaultQuery("mode", importModeCreate); mode {
+	case importModeCreate:
+	case importModeUpsert:
+		upsert = true
+	default:
+		s.respondError(c, fmt.Errorf("%w: unknown import mode %q", errBadRequest, mode))
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	var reader productio.RowReader
+	switch mediaType {
+	case mediaTypeCSV:
+		mapping, err := s.products.ImportMapping(c.Request.Context(), c.Query("mapping"))
+		if err != nil {
+			s.respondError(c, err)
+			return
+		}
+		r, err := productio.NewReader(c.Request.Body, mapping)
+		if err != nil {
+			s.respondError(c, err)
+			return
+		}
+		r.Decimal = s.opts.ImportDecimalSeparator
+		reader = r
+	case mediaTypeJSONL:
+		r := productio.NewJSONLReader(c.Request.Body)
+		r.Strict = s.strictJSON(c)
+		reader = r
+	default:
+		s.respondError(c, errUnsupportedMediaType)
+		return
+	}
+
+	report, err := s.products.ImportProducts(c.Request.Context(), reader, upsert)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// exportProducts godoc
+// @Summary Export products
+// @Description Streams every product matching the filter, ignoring its paging
+// @Tags products
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "csv (default) or ndjson"
+// @Param mapping query string false "Stored column mapping for the CSV layout"
+// @Param filter query models.ProductFilter false "Filter"
+// @Success 200
+// @Router /products/export [get]
+func (s *Server) exportProducts(c *gin.Context) {
+	var f models.ProductFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+	format := c.DefaultQuery("format", service.FormatCSV)
+	mediaType, ok := exportMediaTypes[format]
+	if !ok {
+		s.respondError(c, service.ErrUnsupportedFormat)
+		return
+	}
+	mapping, err := s.products.ExportMapping(c.Request.Context(), c.Query("mapping"))
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", mediaType)
+	c.Header("Content-Disposition", `attachment; filename="products.`+format+`"`)
+	if _, err := s.products.ExportProducts(c.Request.Context(), c.Writer, format, mapping, &f); err != nil {
+		s.respondStreamError(c, err)
+	}
+}
+
+// exportIncremental godoc
+// @Summary Export products changed in a window
+// @Description Streams as NDJSON every product whose updated_at is in [since, until), oldest change first, including deleted products as tombstones with deleted_at set
+// @Tags products
+// @Produce application/x-ndjson
+// @Param since query string true "Start of the window, inclusive (RFC 3339)"
+// @Param until query string true "End of the window, exclusive (RFC 3339)"
+// @Success 200
+// @Router /products/export/incremental [get]
+func (s *Server) exportIncremental(c *gin.Context) {
+	var f models.IncrementalExportFilter
+	if !s.bindQuery(c, &f) {
+		return
+	}
+
+	c.Header("Content-Type", mediaTypeJSONL)
+	c.Header("Content-Disposition", `attachment; filename="products-incremental.ndjson"`)
+	if _, err := s.products.ExportIncremental(c.Request.Context(), c.Writer, &f); err != nil {
+		s.respondStreamError(c, err)
+	}
+}
+
+// respondStreamError reports a failed streaming response; once the body has
+// started the status is already sent, so the error can only be logged
+func (s *Server) respondStreamError(c *gin.Context, err error) {
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		s.respondError(c, err)
+		return
+	}
+	s.logger.WithContext(c.Request.Context()).Error("Streaming response failed", err)
+	c.Abort()
+}
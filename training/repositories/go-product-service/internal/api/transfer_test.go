@@ -0,0 +1,276 @@
+package api
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// serveBody sends a request with the given Content-Type
+func serveBody(s *Server, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+	return w
+}
+
+func TestImportProductsCSVReportsRejectedRows(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products .+ ON CONFLICT \\(sku\\) DO NOTHING RETURNING sku").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectExec("INSERT INTO stock_movements").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := "name,description,price,category,sku,stock\n" +
+		"Desk,Oak,120,furniture,DESK-1,3\n" +
+		"Chair,,cheap,furniture,CHAIR-1,1\n" +
+		"Desk copy,,99,furniture,DESK-1,0\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "text/csv; charset=utf-8", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Failed != 2 {
+		t.Fatalf("expected 1 created and 2 failed, got %+v", report)
+	}
+	if report.Errors[0].Line != 3 || report.Errors[1].Line != 4 {
+		t.Fatalf("expected errors on lines 3 and 4, got %+v", report.Errors)
+	}
+}
+
+func TestImportProductsNDJSON(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1").AddRow("LAMP-1"))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}` + "\n\n" +
+		`{"name":"Lamp","price":20,"category":"lighting","sku":"LAMP-1"}` + "\n" +
+		`{"name":"Broken"` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "application/x-ndjson", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 2 || report.Failed != 1 || report.Errors[0].Line != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestImportProductsRejectsUnknownMediaTypeAndMissingHeaders(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serveBody(s, http.MethodPost, "/api/v1/products/import", "application/xml", "<products/>"); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d: %s", w.Code, w.Body)
+	}
+	if w := serveBody(s, http.MethodPost, "/api/v1/products/import", "text/csv", "name,price\nDesk,1\n"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a file missing required columns, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestExportProductsStreamsNDJSON(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	a, b := testProduct(), testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND category = \\$1 ORDER BY").
+		WithArgs("furniture").WillReturnRows(querytest.ProductRows(a, b))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/export?format=ndjson&category=furniture&limit=1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != mediaTypeJSONL {
+		t.Fatalf("expected %s, got %s", mediaTypeJSONL, ct)
+	}
+	lines := 0
+	for sc := bufio.NewScanner(w.Body); sc.Scan(); lines++ {
+	}
+	if lines != 2 {
+		t.Fatalf("expected the export to ignore paging and stream 2 lines, got %d", lines)
+	}
+}
+
+func TestExportProductsCSV(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(p))
+
+	w := serve(s, http.MethodGet, "/api/v1/products/export", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	want := "id,name,description,price,category,sku,stock,is_active,created_at,updated_at\n" +
+		p.ID.String() + ",Desk,,120,furniture,DESK-1,3,true,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z\n"
+	if w.Body.String() != want {
+		t.Fatalf("unexpected CSV:\n%s", w.Body)
+	}
+}
+
+func TestExportProductsRejectsUnknownFormat(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{})
+	if w := serve(s, http.MethodGet, "/api/v1/products/export?format=xlsx", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestImportProductsNDJSONStrictRejectsUnknownFields(t *testing.T) {
+	s, mock := newTestServer(t, Options{StrictJSON: true}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}` + "\n" +
+		`{"name":"Lamp","pric":20,"category":"lighting","sku":"LAMP-1"}` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "application/x-ndjson", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Failed != 1 || report.Errors[0].Line != 2 {
+		t.Fatalf("expected the line with an unknown field to be rejected, got %+v", report)
+	}
+}
+
+func TestReimportingIdenticalDataSkipsEveryRow(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("INSERT INTO products .+ ON CONFLICT \\(sku\\) DO UPDATE .+ WHERE products.content_hash IS DISTINCT FROM EXCLUDED.content_hash").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}))
+	}
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1"}` + "\n" +
+		`{"name":"Lamp","price":20,"category":"lighting","sku":"LAMP-1"}` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import?mode=upsert", "application/x-ndjson", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.ImportSummary != (models.ImportSummary{Skipped: 2}) {
+		t.Fatalf("expected every row skipped, got %+v", report.ImportSummary)
+	}
+}
+
+func TestUpsertImportReportsCreatedAndUpdated(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	mock.ExpectBegin()
+	mock.ExpectQuery("ON CONFLICT \\(sku\\) DO UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}).AddRow(uuid.New(), true))
+	mock.ExpectExec("INSERT INTO stock_movements").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("ON CONFLICT \\(sku\\) DO UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}).AddRow(uuid.New(), false))
+	mock.ExpectCommit()
+
+	body := `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","stock":2}` + "\n" +
+		`{"name":"Lamp","price":25,"category":"lighting","sku":"LAMP-1"}` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import?mode=upsert", "application/x-ndjson", body)
+	var report models.ImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.ImportSummary != (models.ImportSummary{Created: 1, Updated: 1}) {
+		t.Fatalf("expected one created and one updated, got %d %+v", w.Code, report.ImportSummary)
+	}
+	if w := serveBody(s, http.MethodPost, "/api/v1/products/import?mode=merge", "application/x-ndjson", body); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown mode, got %d", w.Code)
+	}
+}
+
+// blockingWriter is a response writer whose first write reports the export
+// as running and then holds it, and its concurrency slot, until release closes
+type blockingWriter struct {
+	*httptest.ResponseRecorder
+	running chan<- struct{}
+	release <-chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingWriter) Write(b []byte) (int, error) {
+	w.once.Do(func() {
+		w.running <- struct{}{}
+		<-w.release
+	})
+	return w.ResponseRecorder.Write(b)
+}
+
+func TestThirdConcurrentExportIsRejected(t *testing.T) {
+	s, mock := newTestServer(t, Options{MaxConcurrentExports: 2}, service.Options{})
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery("SELECT .+ FROM products").WillReturnRows(querytest.ProductRows(testProduct()))
+	}
+
+	running, release := make(chan struct{}), make(chan struct{})
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := &blockingWriter{ResponseRecorder: httptest.NewRecorder(), running: running, release: release}
+			s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/products/export?format=ndjson", nil))
+			codes[i] = w.Code
+		}(i)
+	}
+	<-running
+	<-running
+
+	w := serve(s, http.MethodGet, "/api/v1/products/export/incremental?since=2024-01-01T00:00:00Z&until=2024-02-01T00:00:00Z", "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected 429 with Retry-After for the third export, got %d: %s", w.Code, w.Body)
+	}
+
+	close(release)
+	wg.Wait()
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Fatalf("expected export %d to finish with 200, got %d", i, code)
+		}
+	}
+}
+
+func TestImportProductsCSVUsesConfiguredDecimalSeparator(t *testing.T) {
+	s, mock := newTestServer(t, Options{ImportDecimalSeparator: ","}, service.Options{})
+	args := make([]driver.Value, 13)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[3] = 1234.56
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"sku"}).AddRow("DESK-1"))
+	mock.ExpectCommit()
+
+	body := "name,description,price,category,sku,stock\n" +
+		`Desk,Oak,"1.234,56",furniture,DESK-1,0` + "\n"
+	w := serveBody(s, http.MethodPost, "/api/v1/products/import", "text/csv", body)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"created":1`) {
+		t.Fatalf("expected the comma-decimal row to be created, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// setTranslation godoc
+// @Summary Store a product's translation
+// @Description Creates or replaces the product's name and description in a BCP 47 locale
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param locale path string true "Locale, e.g. de-DE"
+// @Param translation body models.SetTranslationRequest true "Translation"
+// @Success 200 {object} models.ProductTranslation
+// @Router /products/{id}/translations/{locale} [put]
+func (s *Server) setTranslation(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.SetTranslationRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	t, err := s.products.SetTranslation(c.Request.Context(), id, c.Param("locale"), &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// deleteTranslation godoc
+// @Summary Remove a product's translation
+// @Tags products
+// @Param id path string true "Product ID"
+// @Param locale path string true "Locale"
+// @Success 204
+// @Router /products/{id}/translations/{locale} [delete]
+func (s *Server) deleteTranslation(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.products.DeleteTranslation(c.Request.Context(), id, c.Param("locale")); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
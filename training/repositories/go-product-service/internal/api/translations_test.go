@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+)
+
+func TestSetTranslationTouchesParentProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("INSERT INTO product_translations .+ ON CONFLICT \\(product_id, locale\\) DO UPDATE").
+		WithArgs(p.ID, "de-DE", "Schreibtisch", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(touchProduct).WithArgs(p.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"/translations/de-DE", `{"name":"Schreibtisch"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestSetTranslationRejectsInvalidLocale(t *testing.T) {
+	s, _ := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	p := testProduct()
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"/translations/not_a_locale!", `{"name":"Desk"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteTranslationTouchesParentProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	p := testProduct()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("DELETE FROM product_translations").WithArgs(p.ID, "de-DE").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(touchProduct).WithArgs(p.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String()+"/translations/de-DE", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+}
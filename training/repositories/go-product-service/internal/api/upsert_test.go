@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// upsertBody carries every field a create requires
+const upsertBody = `{"name":"Desk","price":120,"category":"furniture","sku":"DESK-1","stock":3}`
+
+// expectUpdate expects the update of a live p
+func expectUpdate(mock sqlmock.Sqlmock, p models.Product) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("UPDATE products SET name").WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+// expectMissing expects the lookup of an ID no live product has
+func expectMissing(mock sqlmock.Sqlmock, id uuid.UUID) {
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1").WithArgs(id).WillReturnRows(querytest.ProductRows())
+}
+
+// expectUpdateMissing expects the update of an ID no live product has to
+// find nothing to lock
+func expectUpdateMissing(mock sqlmock.Sqlmock, id uuid.UUID) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(id).WillReturnRows(querytest.ProductRows())
+	mock.ExpectRollback()
+}
+
+func TestUpsertUpdatesExistingProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	expectUpdate(mock, p)
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"?upsert=true", `{"price":99.5}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != p.ID || got.Price != 99.5 {
+		t.Fatalf("expected the existing product updated: %s", w.Body)
+	}
+}
+
+func TestUpsertCreatesMissingProductUnderPathID(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id, now := uuid.New(), time.Now()
+	expectUpdateMissing(mock, id)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products .+ ON CONFLICT \\(id\\) DO NOTHING").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO stock_movements").WithArgs(sqlmock.AnyArg(), id, 3, models.MovementInitial).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(models.EventProductCreated, id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	w := serve(s, http.MethodPut, "/api/v1/products/"+id.String()+"?upsert=true", upsertBody)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+	var got dto.Product
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != id || got.SKU != "DESK-1" || got.Stock != 3 {
+		t.Fatalf("expected the product created under the path ID: %s", w.Body)
+	}
+}
+
+func TestUpsertFallsBackToUpdateWhenCreatedConcurrently(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	expectUpdateMissing(mock, p.ID)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products .+ ON CONFLICT \\(id\\) DO NOTHING").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}))
+	mock.ExpectCommit()
+	expectUpdate(mock, p)
+
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+p.ID.String()+"?upsert=true", upsertBody); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpdateMissingProductWithoutUpsert(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	expectUpdateMissing(mock, id)
+
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+id.String(), upsertBody); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestUpsertRequiresCreateFields(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	id := uuid.New()
+	expectUpdateMissing(mock, id)
+
+	if w := serve(s, http.MethodPut, "/api/v1/products/"+id.String()+"?upsert=true", `{"price":99.5}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// createVariant godoc
+// @Summary Add a variant to a product
+// @Description Variant SKUs are unique across all variants
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param variant body models.CreateVariantRequest true "Variant"
+// @Success 201 {object} models.ProductVariant
+// @Router /products/{id}/variants [post]
+func (s *Server) createVariant(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req models.CreateVariantRequest
+	if !s.bindJSON(c, &req) {
+		return
+	}
+	v, err := s.products.CreateVariant(c.Request.Context(), id, &req)
+	if err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v)
+}
+
+// deleteVariant godoc
+// @Summary Remove a variant from a product
+// @Tags products
+// @Param id path string true "Product ID"
+// @Param variant_id path string true "Variant ID"
+// @Success 204
+// @Router /products/{id}/variants/{variant_id} [delete]
+func (s *Server) deleteVariant(c *gin.Context) {
+	id, ok := s.pathID(c, "id")
+	if !ok {
+		return
+	}
+	variantID, ok := s.pathID(c, "variant_id")
+	if !ok {
+		return
+	}
+	if err := s.products.DeleteVariant(c.Request.Context(), id, variantID); err != nil {
+		s.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
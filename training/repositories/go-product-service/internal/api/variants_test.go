@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/company/go-product-service/internal/service"
+	"github.com/google/uuid"
+)
+
+// touchProduct matches query.TouchProduct
+const touchProduct = "UPDATE products SET updated_at = GREATEST\\(clock_timestamp\\(\\), updated_at \\+ INTERVAL '1 microsecond'\\)\\s+WHERE id = \\$1"
+
+func TestCreateVariantTouchesParentProduct(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	p := testProduct()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectQuery("INSERT INTO product_variants").WithArgs(p.ID, "DESK-1-OAK", "Oak", nil, 4, []byte("{}")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(uuid.New(), now, now))
+	mock.ExpectExec(touchProduct).WithArgs(p.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"sku":"DESK-1-OAK","name":"Oak","stock":4}`
+	if w := serve(s, http.MethodPost, "/api/v1/products/"+p.ID.String()+"/variants", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteVariantLeavesParentUntouchedWhenDisabled(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{})
+	p := testProduct()
+	variantID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("DELETE FROM product_variants").WithArgs(variantID, p.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String()+"/variants/"+variantID.String(), ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteMissingVariantIsNotFound(t *testing.T) {
+	s, mock := newTestServer(t, Options{}, service.Options{TouchParentOnChildChange: true})
+	p := testProduct()
+	variantID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(p.ID).WillReturnRows(querytest.ProductRows(p))
+	mock.ExpectExec("DELETE FROM product_variants").WithArgs(variantID, p.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if w := serve(s, http.MethodDelete, "/api/v1/products/"+p.ID.String()+"/variants/"+variantID.String(), ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body)
+	}
+}
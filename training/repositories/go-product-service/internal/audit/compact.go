@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/company/go-product-service/internal/jobs"
+)
+
+// compactionLockKey is the advisory lock held while compacting, so only one
+// instance compacts at a time
+const compactionLockKey = 7305412891
+
+// tryCompactionLock takes the advisory lock for the current transaction
+const tryCompactionLock = `SELECT pg_try_advisory_xact_lock($1)`
+
+// deleteExpired removes up to $2 entries created before $1
+const deleteExpired = `DELETE FROM audit_log WHERE id IN (
+	SELECT id FROM audit_log WHERE created_at < $1 ORDER BY created_at LIMIT $2
+)`
+
+// rollUpExpired removes up to $2 entries created before $1, adding them to
+// the per-day counts of their entity type and action, and returns how many
+// entries were removed
+const rollUpExpired = `WITH expired AS (
+	DELETE FROM audit_log WHERE id IN (
+		SELECT id FROM audit_log WHERE created_at < $1 ORDER BY created_at LIMIT $2
+	)
+	RETURNING created_at, entity_type, action
+), rolled AS (
+	INSERT INTO audit_daily_summaries (day, entity_type, action, entries)
+	SELECT (created_at AT TIME ZONE 'UTC')::date, entity_type, action, COUNT(*)
+	FROM expired
+	GROUP BY 1, 2, 3
+	ON CONFLICT (day, entity_type, action) DO UPDATE SET entries = audit_daily_summaries.entries + EXCLUDED.entries
+)
+SELECT COUNT(*) FROM expired`
+
+// Compactor deletes audit entries older than the retention period,
+// optionally rolling them up into daily summaries first
+type Compactor struct {
+	db        *sql.DB
+	retention time.Duration
+	rollUp    bool
+	batchSize int
+
+	compacted atomic.Int64
+}
+
+// NewCompactor creates a compactor removing entries older than retention in
+// batches of batchSize
+func NewCompactor(db *sql.DB, retention time.Duration, rollUp bool, batchSize int) *Compactor {
+	if batchSize < 1 {
+		batchSize = 1000
+	}
+	return &Compactor{db: db, retention: retention, rollUp: rollUp, batchSize: batchSize}
+}
+
+// Compacted returns the number of entries removed since startup
+func (c *Compactor) Compacted() int64 {
+	return c.compacted.Load()
+}
+
+// Run removes every expired entry, one batch per transaction so locks stay
+// short. It returns early without error when another instance holds the
+// compaction lock.
+func (c *Compactor) Run(ctx context.Context) (int64, error) {
+	cutoff := time.Now().UTC().Add(-c.retention)
+	var total int64
+	for {
+		n, locked, err := c.compactBatch(ctx, cutoff)
+		total += n
+		c.compacted.Add(n)
+		if err != nil || !locked || n < int64(c.batchSize) {
+			return total, err
+		}
+	}
+}
+
+// compactBatch removes one batch under the advisory lock; locked is false
+// when the lock is held elsewhere
+func (c *Compactor) compactBatch(ctx context.Context, cutoff time.Time) (n int64, locked bool, err error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, tryCompactionLock, compactionLockKey).Scan(&locked); err != nil || !locked {
+		return 0, false, err
+	}
+
+	if c.rollUp {
+		if err := tx.QueryRowContext(ctx, rollUpExpired, cutoff, c.batchSize).Scan(&n); err != nil {
+			return 0, true, err
+		}
+	} else {
+		res, err := tx.ExecContext(ctx, deleteExpired, cutoff, c.batchSize)
+		if err != nil {
+			return 0, true, err
+		}
+		if n, err = res.RowsAffected(); err != nil {
+			return 0, true, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, true, err
+	}
+	return n, true, nil
+}
+
+// Job schedules the compactor on a jobs.Runner
+func (c *Compactor) Job(interval time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "audit-compaction",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := c.Run(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+)
+
+func TestCompactorRemovesOnlyExpiredEntries(t *testing.T) {
+	db := databasetest.Open(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for _, age := range []time.Duration{40 * 24 * time.Hour, 31 * 24 * time.Hour, 29 * 24 * time.Hour, time.Hour} {
+		if _, err := db.ExecContext(ctx, `INSERT INTO audit_log (actor, action, entity_type, entity_id, created_at)
+VALUES ('tester', 'update', 'product', 'p1', $1)`, now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewCompactor(db, 30*24*time.Hour, true, 1)
+	n, err := c.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || c.Compacted() != 2 {
+		t.Fatalf("expected 2 entries compacted, got %d (counter %d)", n, c.Compacted())
+	}
+
+	var remaining int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log").Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 2 {
+		t.Fatalf("expected the 2 newer entries kept, got %d", remaining)
+	}
+	var summarized int
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(SUM(entries), 0) FROM audit_daily_summaries").Scan(&summarized); err != nil {
+		t.Fatal(err)
+	}
+	if summarized != 2 {
+		t.Fatalf("expected the compacted entries rolled up, got %d", summarized)
+	}
+}
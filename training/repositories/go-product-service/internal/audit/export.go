@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// Export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// exportQuery selects audit entries in [from, to) in a stable order
+const exportQuery = `SELECT id, actor, action, entity_type, entity_id, before, after, created_at
+FROM audit_log
+WHERE created_at >= $1 AND created_at < $2
+ORDER BY created_at ASC, id ASC`
+
+// entryWriter encodes audit entries in an export format
+type entryWriter interface {
+	Write(e *models.AuditEntry) error
+	Flush() error
+}
+
+// Export streams audit entries created in [from, to) to w, row by row,
+// so large ranges are never held in memory
+func Export(ctx context.Context, db *sql.DB, from, to time.Time, format string, w io.Writer) (int64, error) {
+	ew, err := newEntryWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, exportQuery, from, to)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		var e models.AuditEntry
+		var before, after []byte
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Action, &e.EntityType, &e.EntityID, &before, &after, &e.CreatedAt); err != nil {
+			return n, err
+		}
+		e.Before = before
+		e.After = after
+
+		if err := ew.Write(&e); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, ew.Flush()
+}
+
+// ContentType returns the response content type for an export format
+func ContentType(format string) string {
+	if format == FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+func newEntryWriter(format string, w io.Writer) (entryWriter, error) {
+	switch format {
+	case FormatCSV, "":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"id", "created_at", "actor", "action", "entity_type", "entity_id", "before", "after"}); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) Write(e *models.AuditEntry) error {
+	return c.w.Write([]string{
+		e.ID.String(),
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.Actor,
+		e.Action,
+		e.EntityType,
+		e.EntityID,
+		string(e.Before),
+		string(e.After),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlWriter) Write(e *models.AuditEntry) error {
+	return j.enc.Encode(e)
+}
+
+func (j *jsonlWriter) Flush() error {
+	return nil
+}
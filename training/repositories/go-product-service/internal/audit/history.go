@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// HistoryFields is the allowlist of product fields whose history can be requested
+var HistoryFields = map[string]bool{
+	"name":        true,
+	"description": true,
+	"price":       true,
+	"cost":        true,
+	"category":    true,
+	"sku":         true,
+	"barcode":     true,
+	"stock":       true,
+	"is_active":   true,
+	"metadata":    true,
+}
+
+// ErrUnknownField is returned for a field outside HistoryFields
+var ErrUnknownField = errors.New("unknown history field")
+
+// FieldChange is a single change to one field of an entity
+type FieldChange struct {
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+// fieldHistoryQuery selects the product audit entries where the field differs
+// between the before and after snapshots, oldest first
+const fieldHistoryQuery = `SELECT actor, action, before -> $2, after -> $2, created_at
+FROM audit_log
+WHERE entity_type = 'product' AND entity_id = $1
+  AND (before -> $2) IS DISTINCT FROM (after -> $2)
+ORDER BY created_at ASC, id ASC`
+
+// FieldHistory returns every change to field on the product, oldest first;
+// a field that never changed yields an empty list
+func FieldHistory(ctx context.Context, db *sql.DB, productID, field string) ([]FieldChange, error) {
+	if !HistoryFields[field] {
+		return nil, fmt.Errorf("%w %q", ErrUnknownField, field)
+	}
+
+	rows, err := db.QueryContext(ctx, fieldHistoryQuery, productID, field)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []FieldChange{}
+	for rows.Next() {
+		var c FieldChange
+		var before, after []byte
+		if err := rows.Scan(&c.Actor, &c.Action, &before, &after, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		c.Before = nullableJSON(before)
+		c.After = nullableJSON(after)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// ExtractFieldChanges derives a field's history from already loaded audit entries
+func ExtractFieldChanges(entries []models.AuditEntry, field string) []FieldChange {
+	changes := []FieldChange{}
+	for _, e := range entries {
+		before := fieldValue(e.Before, field)
+		after := fieldValue(e.After, field)
+		if bytes.Equal(before, after) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Actor:     e.Actor,
+			Action:    e.Action,
+			Before:    nullableJSON(before),
+			After:     nullableJSON(after),
+			ChangedAt: e.CreatedAt,
+		})
+	}
+	return changes
+}
+
+// fieldValue returns the raw JSON of field in a snapshot, or nil if absent
+func fieldValue(snapshot json.RawMessage, field string) json.RawMessage {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(snapshot, &fields); err != nil {
+		return nil
+	}
+	return fields[field]
+}
+
+// nullableJSON renders a missing value as JSON null
+func nullableJSON(v []byte) json.RawMessage {
+	if len(v) == 0 {
+		return json.RawMessage("null")
+	}
+	return v
+}
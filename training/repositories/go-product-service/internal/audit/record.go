@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// insertEntry records one audit entry
+const insertEntry = `INSERT INTO audit_log (id, actor, action, entity_type, entity_id, before, after, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+// Execer is implemented by *sql.DB and *sql.Tx, so entries can be recorded
+// in the same transaction as the change they describe
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Record inserts e, filling in its ID and CreatedAt when unset
+func Record(ctx context.Context, db Execer, e *models.AuditEntry) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+	_, err := db.ExecContext(ctx, insertEntry, e.ID, e.Actor, e.Action, e.EntityType, e.EntityID, nullJSON(e.Before), nullJSON(e.After), e.CreatedAt)
+	return err
+}
+
+// nullJSON stores an empty document as NULL
+func nullJSON(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,21 @@
+package auth
+
+import "context"
+
+// AnonymousCaller is recorded as the actor of changes made without an identified client
+const AnonymousCaller = "anonymous"
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying the identity of the calling client
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Caller returns the client identity stored in ctx, or AnonymousCaller
+func Caller(ctx context.Context) string {
+	if caller, ok := ctx.Value(callerKey{}).(string); ok && caller != "" {
+		return caller
+	}
+	return AnonymousCaller
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Scopes granted to API clients
+const (
+	ScopeRead    = "products:read"
+	ScopeWrite   = "products:write"
+	ScopeFinance = "products:finance"
+	ScopeAdmin   = "products:admin"
+)
+
+type scopesKey struct{}
+
+// WithScopes returns a copy of ctx carrying the caller's granted scopes
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// Scopes returns the scopes stored in ctx
+func Scopes(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// Authenticated reports whether ctx belongs to an identified client, even
+// one granted no scopes
+func Authenticated(ctx context.Context) bool {
+	_, ok := ctx.Value(scopesKey{}).([]string)
+	return ok
+}
+
+// HasScope reports whether ctx carries scope; the admin scope implies every other scope
+func HasScope(ctx context.Context, scope string) bool {
+	for _, s := range Scopes(ctx) {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeError is returned when the caller lacks the scope an operation requires
+type ScopeError struct {
+	Scope string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("scope %s required", e.Scope)
+}
+
+// Require returns a *ScopeError unless ctx carries scope
+func Require(ctx context.Context, scope string) error {
+	if !HasScope(ctx, scope) {
+		return &ScopeError{Scope: scope}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// ErrInvalidMode is returned for a bulk mode other than all_or_nothing or best_effort
+var ErrInvalidMode = errors.New("invalid bulk mode")
+
+// Options controls a bulk create
+type Options struct {
+	Mode          string
+	DefaultActive bool
+	Privileged    bool
+	// Normalize, TitleCase, PricePlaces and PriceGuard apply the checks of a
+	// single create to every item, as productio.ImportOptions does for rows
+	Normalize   bool
+	TitleCase   bool
+	PricePlaces int
+	PriceGuard  models.PriceGuard
+}
+
+// CreateProducts validates and inserts every request in one transaction.
+// Each row runs under its own savepoint, so a failed row never aborts the
+// others and every item gets a result. In all_or_nothing mode any failure
+// rolls back the whole batch; in best_effort mode the valid rows are
+// committed. Validation errors, SKUs repeated within the batch and SKUs that
+// already exist are item errors; only infrastructure failures return an error.
+func CreateProducts(ctx context.Context, db *sql.DB, validate *validator.Validate, reqs []models.CreateProductRequest, opts Options) (*models.BulkCreateResponse, error) {
+	if opts.Mode != models.BulkModeAllOrNothing && opts.Mode != models.BulkModeBestEffort {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMode, opts.Mode)
+	}
+
+	resp := &models.BulkCreateResponse{Mode: opts.Mode, Results: make([]models.BulkCreateResult, len(reqs))}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	firstSKU := make(map[string]int, len(reqs))
+	for i := range reqs {
+		req := &reqs[i]
+		result := &resp.Results[i]
+		result.Index = i
+		result.SKU = req.SKU
+
+		if err := check(validate, req, opts); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if first, ok := firstSKU[req.SKU]; ok {
+			result.Error = fmt.Sprintf("sku %q duplicates item %d", req.SKU, first)
+			continue
+		}
+		firstSKU[req.SKU] = i
+
+		id := uuid.New()
+		if err := insert(ctx, tx, id, req, req.InitialActive(opts.DefaultActive, opts.Privileged)); err != nil {
+			var pqErr *pq.Error
+			if !errors.As(err, &pqErr) {
+				return nil, err
+			}
+			if pqErr.Code == uniqueViolation {
+				result.Error = fmt.Sprintf("sku %q already exists", req.SKU)
+			} else {
+				result.Error = pqErr.Message
+			}
+			continue
+		}
+		result.Success = true
+		result.ProductID = &id
+	}
+
+	for _, r := range resp.Results {
+		if r.Success {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	if resp.Failed > 0 && opts.Mode == models.BulkModeAllOrNothing {
+		for i := range resp.Results {
+			if resp.Results[i].Success {
+				resp.Results[i].Success = false
+				resp.Results[i].ProductID = nil
+				resp.Results[i].Error = "not created: batch rolled back"
+			}
+		}
+		resp.Created = 0
+		resp.Failed = len(reqs)
+		return resp, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	resp.Committed = true
+	return resp, nil
+}
+
+// check runs the per-item checks of a single create on req
+func check(validate *validator.Validate, req *models.CreateProductRequest, opts Options) error {
+	if opts.Normalize {
+		req.Normalize(opts.TitleCase)
+	}
+	if err := validate.Struct(req); err != nil {
+		return err
+	}
+	if err := req.Metadata.Validate(); err != nil {
+		return err
+	}
+	if err := opts.PriceGuard.Check("list", req.Price); err != nil {
+		return err
+	}
+	if opts.PricePlaces > 0 {
+		return currency.CheckPrecision(req.Price, opts.PricePlaces)
+	}
+	return nil
+}
+
+// insert writes one product and its opening stock movement under a
+// savepoint, rolling back to it on failure so the transaction stays usable
+// for the remaining rows
+func insert(ctx context.Context, tx *sql.Tx, id uuid.UUID, req *models.CreateProductRequest, active bool) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_item"); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, query.InsertProduct,
+		id, req.Name, req.Description, req.Price, req.Cost, req.Category, req.SKU, req.Barcode,
+		req.Stock, active, req.Metadata, req.ContentHash(), auth.Caller(ctx))
+	if m := req.OpeningMovement(id); err == nil && m != nil {
+		_, err = tx.ExecContext(ctx, query.InsertStockMovement, m.ID, m.ProductID, m.Delta, m.Reason)
+	}
+	if err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_item"); rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_item")
+	return err
+}
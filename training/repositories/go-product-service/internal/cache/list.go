@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// ListCache caches product list responses keyed by their canonical filter
+type ListCache[V any] struct {
+	entries *TTLCache[string, V]
+}
+
+// NewListCache creates a list cache holding responses for ttl
+func NewListCache[V any](ttl time.Duration) *ListCache[V] {
+	return &ListCache[V]{entries: NewTTLCache[string, V](ttl)}
+}
+
+// Get returns the cached response for the filter
+func (c *ListCache[V]) Get(f *models.ProductFilter) (V, bool) {
+	return c.entries.Get(FilterKey(f))
+}
+
+// Set caches the response for the filter
+func (c *ListCache[V]) Set(f *models.ProductFilter, value V) {
+	c.entries.Set(FilterKey(f), value)
+}
+
+// Invalidate drops every cached response; call it after any product write
+func (c *ListCache[V]) Invalidate() {
+	c.entries.Purge()
+}
+
+// FilterKey returns a canonical key for a filter. The filter is already bound
+// from the query string, so parameter order doesn't matter; list-valued fields
+// are sorted so that their order doesn't either.
+func FilterKey(f *models.ProductFilter) string {
+	canonical := *f
+	if len(f.MetadataFilters) > 0 {
+		canonical.MetadataFilters = append([]string(nil), f.MetadataFilters...)
+		sort.Strings(canonical.MetadataFilters)
+	}
+
+	data, _ := json.Marshal(canonical)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
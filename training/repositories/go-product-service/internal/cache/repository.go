@@ -0,0 +1,396 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys; list results are keyed by the current list generation, so
+// bumping it invalidates every cached list at once
+const (
+	productKeyPrefix  = "products:id:"
+	skuKeyPrefix      = "products:sku:"
+	listKeyPrefix     = "products:list:"
+	listGenerationKey = "products:list:generation"
+)
+
+// ProductRepository is the method set of repository.ProductRepository that
+// the cache decorates
+type ProductRepository interface {
+	Create(ctx context.Context, p *models.Product, opening *models.StockMovement) error
+	CreateIfMissing(ctx context.Context, p *models.Product, opening *models.StockMovement) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	GetBySKU(ctx context.Context, sku string) (*models.Product, error)
+	List(ctx context.Context, f *models.ProductFilter) ([]models.Product, int64, error)
+	Update(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (*models.Product, error)
+	UpdateUnrecorded(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (before, after *models.Product, err error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	AdjustStock(ctx context.Context, m *models.StockMovement, activation models.StockActivation) (*models.Product, error)
+	PublishDraft(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	TransitionStatus(ctx context.Context, id uuid.UUID, from, to string) (*models.Product, error)
+	SwapSKUs(ctx context.Context, a, b uuid.UUID) ([]models.SKUSwapResult, error)
+	AttachImagesBySKU(ctx context.Context, items []models.BulkImageItem, touch bool) ([]models.BulkImageResult, error)
+	SetPriceTiers(ctx context.Context, productID uuid.UUID, tiers []models.PriceTier, touch bool) error
+	CreateVariant(ctx context.Context, v *models.ProductVariant, touch bool) error
+	DeleteVariant(ctx context.Context, productID, variantID uuid.UUID, touch bool) error
+	SetTranslation(ctx context.Context, t *models.ProductTranslation, touch bool) error
+	DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string, touch bool) error
+	ReconcileStock(ctx context.Context, req *models.ReconcileStockRequest) (*models.ReconcileResult, error)
+	DeleteTag(ctx context.Context, tag string, touch bool) (*models.DeleteTagResult, error)
+	BulkUpdatePrices(ctx context.Context, req *models.BulkPriceUpdateRequest, guard models.PriceGuard, sampleSize int) (*models.BulkPricePreview, []models.PriceChange, error)
+	RenameCategory(ctx context.Context, old, new string) (*models.RenameCategoryResult, []uuid.UUID, error)
+	DeleteCategory(ctx context.Context, category, reassignTo string) (*models.DeleteCategoryResult, []uuid.UUID, error)
+	ReserveBatch(ctx context.Context, req *models.ReserveBatchRequest) ([]models.Reservation, error)
+}
+
+// cachedList is the stored form of a list result
+type cachedList struct {
+	Products []models.Product `json:"products"`
+	Total    int64            `json:"total"`
+}
+
+// CachedProductRepository is a read-through Redis cache in front of a
+// ProductRepository. Redis failures are treated as misses, so reads keep
+// working against Postgres when Redis is down; a failed invalidation leaves
+// an entry stale for at most the TTL.
+type CachedProductRepository struct {
+	ProductRepository
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewCachedProductRepository wraps repo with a cache holding entries for ttl
+func NewCachedProductRepository(repo ProductRepository, client *redis.Client, ttl time.Duration) *CachedProductRepository {
+	return &CachedProductRepository{ProductRepository: repo, client: client, ttl: ttl}
+}
+
+// GetByID returns the cached product or loads and caches it
+func (r *CachedProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	var p models.Product
+	if r.get(ctx, productKeyPrefix+id.String(), &p) {
+		return &p, nil
+	}
+
+	loaded, err := r.ProductRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.set(ctx, productKeyPrefix+id.String(), loaded)
+	return loaded, nil
+}
+
+// GetBySKU resolves the SKU to an ID through the cache. The product found is
+// checked against the SKU, so a mapping left behind by a SKU change is a miss.
+func (r *CachedProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	if raw, err := r.client.Get(ctx, skuKeyPrefix+sku).Result(); err == nil {
+		if id, err := uuid.Parse(raw); err == nil {
+			var p models.Product
+			if r.get(ctx, productKeyPrefix+id.String(), &p) && p.SKU == sku {
+				return &p, nil
+			}
+		}
+	}
+
+	loaded, err := r.ProductRepository.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	r.client.Set(ctx, skuKeyPrefix+sku, loaded.ID.String(), r.ttl)
+	r.set(ctx, productKeyPrefix+loaded.ID.String(), loaded)
+	return loaded, nil
+}
+
+// List returns the cached page for the filter or loads and caches it
+func (r *CachedProductRepository) List(ctx context.Context, f *models.ProductFilter) ([]models.Product, int64, error) {
+	generation, err := r.client.Get(ctx, listGenerationKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return r.ProductRepository.List(ctx, f)
+	}
+	key := listKeyPrefix + strconv.FormatInt(generation, 10) + ":" + FilterKey(f)
+
+	var cached cachedList
+	if r.get(ctx, key, &cached) {
+		return cached.Products, cached.Total, nil
+	}
+
+	products, total, err := r.ProductRepository.List(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	r.set(ctx, key, cachedList{Products: products, Total: total})
+	return products, total, nil
+}
+
+// Create stores the product and invalidates cached lists
+func (r *CachedProductRepository) Create(ctx context.Context, p *models.Product, opening *models.StockMovement) error {
+	if err := r.ProductRepository.Create(ctx, p, opening); err != nil {
+		return err
+	}
+	r.invalidate(ctx, p.ID)
+	return nil
+}
+
+// CreateIfMissing stores the product unless its ID is taken, invalidating
+// cached lists when it was created
+func (r *CachedProductRepository) CreateIfMissing(ctx context.Context, p *models.Product, opening *models.StockMovement) (bool, error) {
+	created, err := r.ProductRepository.CreateIfMissing(ctx, p, opening)
+	if created {
+		r.invalidate(ctx, p.ID)
+	}
+	return created, err
+}
+
+// Update changes the product and invalidates it and cached lists
+func (r *CachedProductRepository) Update(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (*models.Product, error) {
+	p, err := r.ProductRepository.Update(ctx, id, change)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, id)
+	return p, nil
+}
+
+// UpdateUnrecorded changes the product without recording the change and
+// invalidates it and cached lists
+func (r *CachedProductRepository) UpdateUnrecorded(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (before, after *models.Product, err error) {
+	before, after, err = r.ProductRepository.UpdateUnrecorded(ctx, id, change)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.invalidate(ctx, id)
+	return before, after, nil
+}
+
+// Delete removes the product and invalidates it and cached lists
+func (r *CachedProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.ProductRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// AdjustStock changes the product's stock and invalidates it and cached lists
+func (r *CachedProductRepository) AdjustStock(ctx context.Context, m *models.StockMovement, activation models.StockActivation) (*models.Product, error) {
+	p, err := r.ProductRepository.AdjustStock(ctx, m, activation)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, p.ID)
+	return p, nil
+}
+
+// PublishDraft makes the draft live and invalidates it and cached lists
+func (r *CachedProductRepository) PublishDraft(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	p, err := r.ProductRepository.PublishDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, id)
+	return p, nil
+}
+
+// TransitionStatus changes the product's status and invalidates it and cached lists
+func (r *CachedProductRepository) TransitionStatus(ctx context.Context, id uuid.UUID, from, to string) (*models.Product, error) {
+	p, err := r.ProductRepository.TransitionStatus(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, id)
+	return p, nil
+}
+
+// SwapSKUs exchanges the products' SKUs and invalidates both and cached lists
+func (r *CachedProductRepository) SwapSKUs(ctx context.Context, a, b uuid.UUID) ([]models.SKUSwapResult, error) {
+	results, err := r.ProductRepository.SwapSKUs(ctx, a, b)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, a)
+	r.invalidate(ctx, b)
+	return results, nil
+}
+
+// AttachImagesBySKU attaches the images; with touch each product that gained
+// one has a new updated_at, so it and cached lists are invalidated
+func (r *CachedProductRepository) AttachImagesBySKU(ctx context.Context, items []models.BulkImageItem, touch bool) ([]models.BulkImageResult, error) {
+	results, err := r.ProductRepository.AttachImagesBySKU(ctx, items, touch)
+	if err != nil {
+		return nil, err
+	}
+	if touch {
+		for _, result := range results {
+			if result.ProductID != nil {
+				r.invalidate(ctx, *result.ProductID)
+			}
+		}
+	}
+	return results, nil
+}
+
+// SetPriceTiers replaces the product's tiers; with touch the product has a
+// new updated_at, so it and cached lists are invalidated
+func (r *CachedProductRepository) SetPriceTiers(ctx context.Context, productID uuid.UUID, tiers []models.PriceTier, touch bool) error {
+	if err := r.ProductRepository.SetPriceTiers(ctx, productID, tiers, touch); err != nil {
+		return err
+	}
+	if touch {
+		r.invalidate(ctx, productID)
+	}
+	return nil
+}
+
+// CreateVariant adds the variant; with touch the product has a new
+// updated_at, so it and cached lists are invalidated
+func (r *CachedProductRepository) CreateVariant(ctx context.Context, v *models.ProductVariant, touch bool) error {
+	if err := r.ProductRepository.CreateVariant(ctx, v, touch); err != nil {
+		return err
+	}
+	if touch {
+		r.invalidate(ctx, v.ProductID)
+	}
+	return nil
+}
+
+// DeleteVariant removes the variant, invalidating the product like CreateVariant
+func (r *CachedProductRepository) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID, touch bool) error {
+	if err := r.ProductRepository.DeleteVariant(ctx, productID, variantID, touch); err != nil {
+		return err
+	}
+	if touch {
+		r.invalidate(ctx, productID)
+	}
+	return nil
+}
+
+// SetTranslation stores the translation, invalidating the product like CreateVariant
+func (r *CachedProductRepository) SetTranslation(ctx context.Context, t *models.ProductTranslation, touch bool) error {
+	if err := r.ProductRepository.SetTranslation(ctx, t, touch); err != nil {
+		return err
+	}
+	if touch {
+		r.invalidate(ctx, t.ProductID)
+	}
+	return nil
+}
+
+// DeleteTranslation removes the translation, invalidating the product like CreateVariant
+func (r *CachedProductRepository) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string, touch bool) error {
+	if err := r.ProductRepository.DeleteTranslation(ctx, productID, locale, touch); err != nil {
+		return err
+	}
+	if touch {
+		r.invalidate(ctx, productID)
+	}
+	return nil
+}
+
+// ReconcileStock applies the counts and invalidates every product whose stock changed
+func (r *CachedProductRepository) ReconcileStock(ctx context.Context, req *models.ReconcileStockRequest) (*models.ReconcileResult, error) {
+	result, err := r.ProductRepository.ReconcileStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range result.Lines {
+		if line.Variance != 0 {
+			r.invalidate(ctx, line.ProductID)
+		}
+	}
+	return result, nil
+}
+
+// DeleteTag removes the tag everywhere and invalidates the products it was
+// removed from, since lists filtered by the tag no longer hold them
+func (r *CachedProductRepository) DeleteTag(ctx context.Context, tag string, touch bool) (*models.DeleteTagResult, error) {
+	result, err := r.ProductRepository.DeleteTag(ctx, tag, touch)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range result.Products {
+		r.invalidate(ctx, id)
+	}
+	return result, nil
+}
+
+// BulkUpdatePrices applies the price change and invalidates every product
+// whose price changed
+func (r *CachedProductRepository) BulkUpdatePrices(ctx context.Context, req *models.BulkPriceUpdateRequest, guard models.PriceGuard, sampleSize int) (*models.BulkPricePreview, []models.PriceChange, error) {
+	summary, changes, err := r.ProductRepository.BulkUpdatePrices(ctx, req, guard, sampleSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, change := range changes {
+		r.invalidate(ctx, change.Product.ID)
+	}
+	return summary, changes, nil
+}
+
+// RenameCategory renames the category and invalidates every product moved
+func (r *CachedProductRepository) RenameCategory(ctx context.Context, old, new string) (*models.RenameCategoryResult, []uuid.UUID, error) {
+	result, moved, err := r.ProductRepository.RenameCategory(ctx, old, new)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range moved {
+		r.invalidate(ctx, id)
+	}
+	return result, moved, nil
+}
+
+// DeleteCategory deletes the category and invalidates every product reassigned
+func (r *CachedProductRepository) DeleteCategory(ctx context.Context, category, reassignTo string) (*models.DeleteCategoryResult, []uuid.UUID, error) {
+	result, moved, err := r.ProductRepository.DeleteCategory(ctx, category, reassignTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range moved {
+		r.invalidate(ctx, id)
+	}
+	return result, moved, nil
+}
+
+// ReserveBatch reserves the stock and invalidates every reserved product
+func (r *CachedProductRepository) ReserveBatch(ctx context.Context, req *models.ReserveBatchRequest) ([]models.Reservation, error) {
+	reservations, err := r.ProductRepository.ReserveBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range reservations {
+		r.invalidate(ctx, res.ProductID)
+	}
+	return reservations, nil
+}
+
+// invalidate drops the product's entry and moves lists to a new
+// generation; entries under the old generation expire with their TTL
+func (r *CachedProductRepository) invalidate(ctx context.Context, id uuid.UUID) {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, productKeyPrefix+id.String())
+	pipe.Incr(ctx, listGenerationKey)
+	_, _ = pipe.Exec(ctx)
+}
+
+// get decodes the cached value at key into dst, reporting a hit
+func (r *CachedProductRepository) get(ctx context.Context, key string, dst interface{}) bool {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, dst) == nil
+}
+
+// set caches value at key for the TTL
+func (r *CachedProductRepository) set(ctx context.Context, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	r.client.Set(ctx, key, data, r.ttl)
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache is a small in-memory cache whose entries expire after a fixed duration
+type TTLCache[K comparable, V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[K]entry[V]
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// NewTTLCache creates a cache holding entries for ttl
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:     ttl,
+		entries: make(map[K]entry[V]),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value for key if it has not expired
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores value under key for the cache's ttl
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+
+	// Drop expired entries at most once per ttl so the map doesn't grow unbounded
+	if now.After(c.nextSweep) {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+}
+
+// Delete removes key from the cache
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Purge removes every entry from the cache
+func (c *TTLCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]entry[V])
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadReadsTypedValues(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+	t.Setenv("DB_MAX_IDLE_CONNS", "not-a-number")
+	t.Setenv("ENABLE_MIGRATIONS", "false")
+	t.Setenv("MIN_PRICE", "0.5")
+
+	cfg := Load()
+	if cfg.MaxOpenConns != 40 {
+		t.Errorf("expected MaxOpenConns 40, got %d", cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns != 25 {
+		t.Errorf("an unparseable value should keep the default, got %d", cfg.MaxIdleConns)
+	}
+	if cfg.EnableMigrations {
+		t.Error("expected EnableMigrations false")
+	}
+	if cfg.MinPrice != 0.5 {
+		t.Errorf("expected MinPrice 0.5, got %v", cfg.MinPrice)
+	}
+	if cfg.Port != "8080" || cfg.LogLevel != "info" {
+		t.Errorf("string defaults changed: port %q level %q", cfg.Port, cfg.LogLevel)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		tweak func(*Config)
+		want  string
+	}{
+		{"defaults", func(*Config) {}, ""},
+		{"empty database url", func(c *Config) { c.DatabaseURL = "" }, "DATABASE_URL"},
+		{"unknown log level", func(c *Config) { c.LogLevel = "verbose" }, "LOG_LEVEL"},
+		{"negative pool", func(c *Config) { c.MaxOpenConns = -1 }, "DB_MAX_OPEN_CONNS"},
+		{"negative lifetime", func(c *Config) { c.ConnMaxLifetimeSeconds = -5 }, "DB_CONN_MAX_LIFETIME_SECONDS"},
+		{"bad decimal separator", func(c *Config) { c.ImportDecimalSeparator = ";" }, "IMPORT_DECIMAL_SEPARATOR"},
+		{"bad bulk create mode", func(c *Config) { c.BulkCreateMode = "partial" }, "BULK_CREATE_MODE"},
+		{"zero outbox attempts", func(c *Config) { c.OutboxMaxAttempts = 0 }, "OUTBOX_MAX_ATTEMPTS"},
+		{"log export without endpoint", func(c *Config) { c.OTLPLogsEnabled = true }, "OTLP_LOGS_ENABLED"},
+	}
+	for _, tc := range cases {
+		cfg := Load()
+		tc.tweak(cfg)
+		err := cfg.Validate()
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%s: expected error mentioning %s, got %v", tc.name, tc.want, err)
+		}
+	}
+}
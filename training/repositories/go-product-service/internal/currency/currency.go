@@ -0,0 +1,60 @@
+package currency
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// minorUnits lists the ISO 4217 currencies whose minor units differ from two
+var minorUnits = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// MinorUnits returns the number of decimal places used by a currency code,
+// or defaultPlaces when the code is empty
+func MinorUnits(code string, defaultPlaces int) int {
+	if code == "" {
+		return defaultPlaces
+	}
+	if places, ok := minorUnits[strings.ToUpper(code)]; ok {
+		return places
+	}
+	return 2
+}
+
+// PrecisionError reports an amount with more decimal places than allowed
+type PrecisionError struct {
+	Amount float64
+	Places int
+}
+
+func (e *PrecisionError) Error() string {
+	return fmt.Sprintf("price %s has more than %d decimal places", strconv.FormatFloat(e.Amount, 'f', -1, 64), e.Places)
+}
+
+// CheckPrecision rejects amounts with more than places decimal places;
+// it inspects the shortest decimal form so 19.99 is not mistaken for 19.989999...
+func CheckPrecision(amount float64, places int) error {
+	s := strconv.FormatFloat(amount, 'f', -1, 64)
+	if _, frac, ok := strings.Cut(s, "."); ok && len(frac) > places {
+		return &PrecisionError{Amount: amount, Places: places}
+	}
+	return nil
+}
+
+// Round rounds amount to places decimal places
+func Round(amount float64, places int) float64 {
+	factor := math.Pow10(places)
+	return math.Round(amount*factor) / factor
+}
@@ -0,0 +1,27 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPrecision(t *testing.T) {
+	if err := CheckPrecision(19.99, 2); err != nil {
+		t.Fatalf("19.99 at two places: %v", err)
+	}
+	if err := CheckPrecision(20, 0); err != nil {
+		t.Fatalf("20 at zero places: %v", err)
+	}
+	var precisionErr *PrecisionError
+	if err := CheckPrecision(19.999, 2); !errors.As(err, &precisionErr) || precisionErr.Places != 2 {
+		t.Fatalf("expected a PrecisionError for 19.999, got %v", err)
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	for code, want := range map[string]int{"": 4, "USD": 2, "jpy": 0, "KWD": 3, "XYZ": 2} {
+		if got := MinorUnits(code, 4); got != want {
+			t.Errorf("MinorUnits(%q) = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package databasetest provides a migrated PostgreSQL database for tests
+// that need real locking and constraint behavior. Tests using it are
+// skipped unless TEST_DATABASE_URL points at a disposable database.
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database"
+	_ "github.com/lib/pq"
+)
+
+// EnvURL names the variable holding the test database URL
+const EnvURL = "TEST_DATABASE_URL"
+
+// listTables returns the application tables, leaving the migration state alone
+const listTables = `SELECT tablename FROM pg_tables
+WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'`
+
+// Open migrates the test database, empties every table and returns a pool
+// that is closed when the test ends
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	url := URL(t)
+
+	if err := database.Migrate(url, migrationsDir()); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	truncate(t, db)
+	return db
+}
+
+// URL returns the test database URL, skipping the test when it is unset
+func URL(t testing.TB) string {
+	t.Helper()
+	url := os.Getenv(EnvURL)
+	if url == "" {
+		t.Skip(EnvURL + " not set; skipping database test")
+	}
+	return url
+}
+
+// truncate empties every application table
+func truncate(t testing.TB, db *sql.DB) {
+	t.Helper()
+	rows, err := db.QueryContext(context.Background(), listTables)
+	if err != nil {
+		t.Fatalf("list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			t.Fatalf("list tables: %v", err)
+		}
+		tables = append(tables, `"`+name+`"`)
+	}
+	rows.Close()
+	if len(tables) == 0 {
+		return
+	}
+
+	stmt := "TRUNCATE "
+	for i, name := range tables {
+		if i > 0 {
+			stmt += ", "
+		}
+		stmt += name
+	}
+	if _, err := db.Exec(stmt + " CASCADE"); err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+}
+
+// migrationsDir locates the repository's migrations relative to this file
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "migrations")
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// MigrationsDir is where RunMigrations reads migrations, relative to the
+// working directory
+const MigrationsDir = "migrations"
+
+// RunMigrations applies every pending migration in MigrationsDir
+func RunMigrations(url string) error {
+	return Migrate(url, MigrationsDir)
+}
+
+// Migrate applies every pending migration in dir; an up-to-date schema is
+// not an error
+func Migrate(url, dir string) error {
+	m, err := migrate.New("file://"+dir, url)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Package database opens the primary PostgreSQL pool and applies migrations
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/company/go-product-service/internal/dbreload"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// pingTimeout bounds the connectivity check made when opening the pool
+const pingTimeout = 5 * time.Second
+
+// Options tunes the connection pool; zero values keep the database/sql
+// defaults, under which open connections and their lifetime are unlimited
+type Options struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// Traced records a SQL span for every statement
+	Traced bool
+}
+
+// NewPostgresDB opens a pool for url and verifies it can connect. The
+// returned connector lets rotated credentials be swapped in without
+// replacing the pool.
+func NewPostgresDB(url string, opts Options) (*sql.DB, *dbreload.Connector, error) {
+	connector := dbreload.NewConnector(url)
+	var db *sql.DB
+	if opts.Traced {
+		db = telemetry.OpenDB(connector)
+	} else {
+		db = sql.OpenDB(connector)
+	}
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	if opts.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return db, connector, nil
+}
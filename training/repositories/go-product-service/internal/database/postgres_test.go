@@ -0,0 +1,42 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/database"
+	"github.com/company/go-product-service/internal/database/databasetest"
+)
+
+func TestNewPostgresDBAppliesPoolOptions(t *testing.T) {
+	url := databasetest.URL(t)
+
+	db, connector, err := database.NewPostgresDB(url, database.Options{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: time.Minute})
+	if err != nil {
+		t.Fatalf("NewPostgresDB: %v", err)
+	}
+	defer db.Close()
+
+	if connector == nil {
+		t.Fatal("expected a reloadable connector")
+	}
+	if got := db.Stats().MaxOpenConnections; got != 7 {
+		t.Fatalf("expected MaxOpenConnections 7, got %d", got)
+	}
+}
+
+func TestNewPostgresDBFailsFastOnBadDSN(t *testing.T) {
+	_, _, err := database.NewPostgresDB("postgres://nobody@127.0.0.1:1/none?sslmode=disable&connect_timeout=1", database.Options{})
+	if err == nil {
+		t.Fatal("expected an error for an unreachable database")
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	url := databasetest.URL(t)
+	for i := 0; i < 2; i++ {
+		if err := database.Migrate(url, "../../migrations"); err != nil {
+			t.Fatalf("run %d: %v", i+1, err)
+		}
+	}
+}
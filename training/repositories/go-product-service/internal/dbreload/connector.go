@@ -0,0 +1,170 @@
+package dbreload
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"github.com/lib/pq"
+)
+
+// Connector is a driver.Connector whose DSN can be swapped at runtime, so
+// rotated database credentials are picked up without a restart. Every
+// connection is tagged with the generation it was opened under; after a
+// reload, older connections finish whatever they are running, report
+// themselves invalid when returned to the pool and are closed by database/sql
+type Connector struct {
+	dial func(ctx context.Context, dsn string) (driver.Conn, error)
+
+	mu         sync.RWMutex
+	dsn        string
+	generation int64
+}
+
+// NewConnector creates a connector for dsn
+func NewConnector(dsn string) *Connector {
+	return &Connector{dial: dialPostgres, dsn: dsn}
+}
+
+// Open returns a *sql.DB backed by the connector; the same *sql.DB stays in
+// use across reloads
+func Open(dsn string) (*sql.DB, *Connector) {
+	c := NewConnector(dsn)
+	return sql.OpenDB(c), c
+}
+
+// Connect implements driver.Connector
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.RLock()
+	dsn, generation := c.dsn, c.generation
+	c.mu.RUnlock()
+
+	inner, err := c.dial(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: inner, generation: generation, owner: c}, nil
+}
+
+// Driver implements driver.Connector
+func (c *Connector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// Reload verifies that dsn can connect and then makes it the DSN for all
+// new connections, retiring the existing ones as they are released. The
+// current DSN is kept when the new one fails, so a bad rotation never takes
+// the service down.
+func (c *Connector) Reload(ctx context.Context, dsn string) error {
+	probe, err := c.dial(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	probe.Close()
+
+	c.mu.Lock()
+	c.dsn = dsn
+	c.generation++
+	c.mu.Unlock()
+	return nil
+}
+
+// current reports whether generation is the latest one
+func (c *Connector) current(generation int64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation == generation
+}
+
+// dialPostgres opens a Postgres connection to dsn
+func dialPostgres(ctx context.Context, dsn string) (driver.Conn, error) {
+	pc, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return pc.Connect(ctx)
+}
+
+// conn wraps a driver connection with the generation it was opened under,
+// forwarding the optional interfaces database/sql relies on
+type conn struct {
+	driver.Conn
+	generation int64
+	owner      *Connector
+}
+
+// IsValid implements driver.Validator; stale connections are discarded
+// instead of being returned to the idle pool
+func (c *conn) IsValid() bool {
+	if !c.owner.current(c.generation) {
+		return false
+	}
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// ResetSession implements driver.SessionResetter
+func (c *conn) ResetSession(ctx context.Context) error {
+	if !c.owner.current(c.generation) {
+		return driver.ErrBadConn
+	}
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+// PrepareContext implements driver.ConnPrepareContext
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+// BeginTx implements driver.ConnBeginTx
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	if opts.Isolation != 0 || opts.ReadOnly {
+		return nil, errors.New("dbreload: driver does not support transaction options")
+	}
+	return c.Conn.Begin()
+}
+
+// QueryContext implements driver.QueryerContext
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// ExecContext implements driver.ExecerContext
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// Ping implements driver.Pinger
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// CheckNamedValue implements driver.NamedValueChecker
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
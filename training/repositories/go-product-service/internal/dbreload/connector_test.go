@@ -0,0 +1,121 @@
+package dbreload
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakeDialer opens fakeConns, remembering every DSN dialled; a DSN of "bad"
+// fails to connect
+type fakeDialer struct {
+	mu    sync.Mutex
+	dsns  []string
+	conns []*fakeConn
+}
+
+func (d *fakeDialer) dial(_ context.Context, dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dsns = append(d.dsns, dsn)
+	if dsn == "bad" {
+		return nil, errors.New("authentication failed")
+	}
+	c := &fakeConn{dsn: dsn}
+	d.conns = append(d.conns, c)
+	return c, nil
+}
+
+// fakeConn is a driver connection that only records its DSN and closing
+type fakeConn struct {
+	dsn    string
+	closed bool
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+// newFakeConnector returns a connector for dsn that dials through a fakeDialer
+func newFakeConnector(dsn string) (*Connector, *fakeDialer) {
+	d := &fakeDialer{}
+	c := NewConnector(dsn)
+	c.dial = d.dial
+	return c, d
+}
+
+// dsnOf returns the DSN the pooled connection was opened with
+func dsnOf(t *testing.T, c *sql.Conn) string {
+	t.Helper()
+	var dsn string
+	if err := c.Raw(func(dc interface{}) error {
+		dsn = dc.(*conn).Conn.(*fakeConn).dsn
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return dsn
+}
+
+func TestReloadRetiresOldConnectionsAndDialsTheNewDSN(t *testing.T) {
+	ctx := context.Background()
+	connector, dialer := newFakeConnector("old")
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	held, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dsn := dsnOf(t, held); dsn != "old" {
+		t.Fatalf("expected a connection to the old DSN, got %q", dsn)
+	}
+
+	if err := connector.Reload(ctx, "new"); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if err := held.Raw(func(dc interface{}) error {
+		if dc.(*conn).IsValid() {
+			return errors.New("connection opened before the reload still reports itself valid")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	held.Close()
+	if old := dialer.conns[0]; !old.closed {
+		t.Fatal("expected the retired connection closed once released")
+	}
+
+	next, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer next.Close()
+	if dsn := dsnOf(t, next); dsn != "new" {
+		t.Fatalf("expected a connection to the new DSN, got %q", dsn)
+	}
+}
+
+func TestFailedReloadKeepsTheCurrentDSN(t *testing.T) {
+	ctx := context.Background()
+	connector, _ := newFakeConnector("old")
+
+	if err := connector.Reload(ctx, "bad"); err == nil {
+		t.Fatal("expected the unusable DSN refused")
+	}
+	dc, err := connector.Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dc.Close()
+	if !dc.(*conn).IsValid() || dc.(*conn).Conn.(*fakeConn).dsn != "old" {
+		t.Fatal("expected new connections to keep using the old DSN")
+	}
+}
@@ -0,0 +1,53 @@
+package dbroute
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Consistency levels a request can ask for
+const (
+	// ConsistencyStrong reads from the primary and sees every committed write
+	ConsistencyStrong = "strong"
+	// ConsistencyEventual reads from a replica and may lag the primary
+	ConsistencyEventual = "eventual"
+)
+
+type strongKey struct{}
+
+// WithStrongConsistency returns a context whose reads go to the primary;
+// handlers set it for any read-after-write
+func WithStrongConsistency(ctx context.Context) context.Context {
+	return context.WithValue(ctx, strongKey{}, true)
+}
+
+// StrongConsistency reports whether reads for ctx must go to the primary
+func StrongConsistency(ctx context.Context) bool {
+	strong, _ := ctx.Value(strongKey{}).(bool)
+	return strong
+}
+
+// Router picks the database a read runs against. Writes always use Primary;
+// reads use the replica unless the context asks for strong consistency.
+type Router struct {
+	primary *sql.DB
+	replica *sql.DB
+}
+
+// NewRouter creates a router; a nil replica sends every read to the primary
+func NewRouter(primary, replica *sql.DB) *Router {
+	return &Router{primary: primary, replica: replica}
+}
+
+// Primary returns the primary database, for writes and transactions
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Reader returns the database reads for ctx should run against
+func (r *Router) Reader(ctx context.Context) *sql.DB {
+	if r.replica == nil || StrongConsistency(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
@@ -0,0 +1,23 @@
+package dbroute
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestReaderHonorsStrongConsistency(t *testing.T) {
+	primary, replica := &sql.DB{}, &sql.DB{}
+	r := NewRouter(primary, replica)
+	ctx := context.Background()
+
+	if r.Reader(ctx) != replica {
+		t.Fatal("expected plain reads on the replica")
+	}
+	if r.Reader(WithStrongConsistency(ctx)) != primary {
+		t.Fatal("expected strong reads on the primary")
+	}
+	if NewRouter(primary, nil).Reader(ctx) != primary {
+		t.Fatal("expected every read on the primary without a replica")
+	}
+}
@@ -0,0 +1,94 @@
+package decode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StrictHeader lets a client opt into strict decoding for a single request
+const StrictHeader = "X-Strict-JSON"
+
+// UnknownFieldsError lists request body fields that don't exist on the target type
+type UnknownFieldsError struct {
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Fields, ", ")
+}
+
+// JSON decodes body into dst; in strict mode any field not declared on dst is rejected
+// with an *UnknownFieldsError instead of being silently ignored
+func JSON(body io.Reader, dst interface{}, strict bool) error {
+	if !strict {
+		return json.NewDecoder(body).Decode(dst)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	// Collect every unknown top-level field so the client can fix them in one go
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err == nil {
+		known := knownFields(dst)
+		var unknown []string
+		for name := range raw {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return &UnknownFieldsError{Fields: unknown}
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		// Unknown fields in nested objects are only reported by the decoder
+		if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return &UnknownFieldsError{Fields: []string{strings.Trim(name, `"`)}}
+		}
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// knownFields returns the JSON names of the exported fields of dst's struct type
+func knownFields(dst interface{}) map[string]bool {
+	t := reflect.TypeOf(dst)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	known := make(map[string]bool)
+	if t == nil || t.Kind() != reflect.Struct {
+		return known
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if n, _, _ := strings.Cut(tag, ","); n != "" {
+				name = n
+			}
+		}
+		known[name] = true
+	}
+	return known
+}
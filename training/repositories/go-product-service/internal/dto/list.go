@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// ProductList is the API representation of a page of products
+type ProductList struct {
+	Products    []*Product `json:"products"`
+	Total       int64      `json:"total"`
+	Limit       int        `json:"limit"`
+	Offset      int        `json:"offset"`
+	NextCursor  string     `json:"next_cursor,omitempty"`
+	Suggestions []string   `json:"suggestions,omitempty"`
+}
+
+// NewProductList maps a page of products to its API representation
+func NewProductList(ctx context.Context, products []models.Product, total int64, f *models.ProductFilter, nextCursor string) *ProductList {
+	return &ProductList{
+		Products:   NewProducts(ctx, products),
+		Total:      total,
+		Limit:      f.Limit,
+		Offset:     f.Offset,
+		NextCursor: nextCursor,
+	}
+}
+
+// BarcodeLookup is the API representation of a barcode lookup
+type BarcodeLookup struct {
+	Products  []*Product `json:"products"`
+	Unmatched []string   `json:"unmatched"`
+}
+
+// NewBarcodeLookup maps a barcode lookup result to its API representation
+func NewBarcodeLookup(ctx context.Context, r *models.BarcodeLookupResult) *BarcodeLookup {
+	return &BarcodeLookup{Products: NewProducts(ctx, r.Products), Unmatched: r.Unmatched}
+}
+
+// ProductGroup is the API representation of one bucket of grouped products
+type ProductGroup struct {
+	Group string     `json:"group"`
+	Count int64      `json:"count"`
+	Items []*Product `json:"items"`
+}
+
+// NewProductGroups maps product groups to their API representation
+func NewProductGroups(ctx context.Context, groups []models.ProductGroup) []ProductGroup {
+	out := make([]ProductGroup, len(groups))
+	for i, g := range groups {
+		out[i] = ProductGroup{Group: g.Group, Count: g.Count, Items: NewProducts(ctx, g.Items)}
+	}
+	return out
+}
+
+// BulkGet is the API representation of a bulk get
+type BulkGet struct {
+	Products []*Product  `json:"products"`
+	NotFound []uuid.UUID `json:"not_found"`
+}
+
+// NewBulkGet maps a bulk get result to its API representation
+func NewBulkGet(ctx context.Context, r *models.BulkGetResult) *BulkGet {
+	return &BulkGet{Products: NewProducts(ctx, r.Products), NotFound: r.NotFound}
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// Rounding controls how prices are rounded for display; it never affects
+// stored values
+type Rounding struct {
+	// DefaultCurrency applies to products without a currency
+	DefaultCurrency string
+	// DefaultPlaces applies when neither the product nor DefaultCurrency
+	// names a currency
+	DefaultPlaces int
+}
+
+type roundingKey struct{}
+
+// WithRounding returns a context whose responses round prices to the
+// minor units of each product's currency
+func WithRounding(ctx context.Context, r Rounding) context.Context {
+	return context.WithValue(ctx, roundingKey{}, r)
+}
+
+// displayPrice returns price in currency code, rounded when ctx asks for it;
+// every price a response shows goes through here so they round alike
+func displayPrice(ctx context.Context, code *string, price float64) float64 {
+	r, ok := ctx.Value(roundingKey{}).(Rounding)
+	if !ok {
+		return price
+	}
+	c := r.DefaultCurrency
+	if code != nil && *code != "" {
+		c = *code
+	}
+	return currency.Round(price, currency.MinorUnits(c, r.DefaultPlaces))
+}
+
+// displayPricePtr is displayPrice for an optional price
+func displayPricePtr(ctx context.Context, code *string, price *float64) *float64 {
+	if price == nil {
+		return nil
+	}
+	rounded := displayPrice(ctx, code, *price)
+	return &rounded
+}
+
+type velocityKey struct{}
+
+// WithSalesVelocity returns a context whose responses include coverage_days,
+// computed from each product's average daily sales. Products missing from
+// velocities are not selling and have no coverage_days.
+func WithSalesVelocity(ctx context.Context, velocities map[uuid.UUID]float64) context.Context {
+	return context.WithValue(ctx, velocityKey{}, velocities)
+}
+
+// coverageDays returns the product's stock coverage when ctx carries sales velocities
+func coverageDays(ctx context.Context, p *models.Product) *float64 {
+	velocities, ok := ctx.Value(velocityKey{}).(map[uuid.UUID]float64)
+	if !ok {
+		return nil
+	}
+	return models.CoverageDays(p.AvailableQty(), velocities[p.ID])
+}
+
+type maskKey struct{}
+
+// WithIdentifierMasking returns a context whose responses omit SKUs and
+// barcodes unless the caller is authenticated
+func WithIdentifierMasking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, maskKey{}, true)
+}
+
+// maskIdentifiers reports whether ctx hides SKUs and barcodes from its caller
+func maskIdentifiers(ctx context.Context) bool {
+	masked, _ := ctx.Value(maskKey{}).(bool)
+	return masked && !auth.Authenticated(ctx)
+}
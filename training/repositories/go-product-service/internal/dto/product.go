@@ -0,0 +1,168 @@
+package dto
+
+import (
+	"context"
+	"time"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// Product is the API representation of a product; sensitive fields are
+// omitted entirely for callers without the scope to see them
+type Product struct {
+	ID           uuid.UUID       `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Price        float64         `json:"price"`
+	Currency     *string         `json:"currency,omitempty"`
+	SalePrice    *float64        `json:"sale_price,omitempty"`
+	SaleStartsAt *time.Time      `json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time      `json:"sale_ends_at,omitempty"`
+	Cost         *float64        `json:"cost,omitempty"`
+	Margin       *float64        `json:"margin,omitempty"`
+	CreatedBy    *string         `json:"created_by,omitempty"`
+	Category     string          `json:"category"`
+	SKU          string          `json:"sku,omitempty"`
+	Barcode      *string         `json:"barcode,omitempty"`
+	Stock        int             `json:"stock"`
+	Reserved     int             `json:"reserved"`
+	ReorderLevel *int            `json:"reorder_level,omitempty"`
+	CoverageDays *float64        `json:"coverage_days,omitempty"`
+	IsActive     bool            `json:"is_active"`
+	Status       string          `json:"status"`
+	Metadata     models.Metadata `json:"metadata,omitempty"`
+	ContentHash  string          `json:"content_hash"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DeletedAt    *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// NewProduct maps a product to its API representation for the caller in ctx
+func NewProduct(ctx context.Context, p *models.Product) *Product {
+	out := &Product{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		Price:        displayPrice(ctx, p.Currency, p.Price),
+		Currency:     p.Currency,
+		SalePrice:    displayPricePtr(ctx, p.Currency, p.SalePrice),
+		SaleStartsAt: p.SaleStartsAt,
+		SaleEndsAt:   p.SaleEndsAt,
+		Category:     p.Category,
+		SKU:          p.SKU,
+		Barcode:      p.Barcode,
+		Stock:        p.Stock,
+		Reserved:     p.Reserved,
+		ReorderLevel: p.ReorderLevel,
+		IsActive:     p.IsActive,
+		Status:       p.Status,
+		Metadata:     p.Metadata,
+		ContentHash:  p.ContentHash,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		DeletedAt:    p.DeletedAt,
+	}
+
+	// Cost, margin and the creator are only visible to finance
+	if auth.HasScope(ctx, auth.ScopeFinance) {
+		out.Cost = p.Cost
+		out.Margin = p.Margin()
+		out.CreatedBy = p.CreatedBy
+	}
+
+	// Internal identifiers may be hidden from anonymous callers
+	if maskIdentifiers(ctx) {
+		out.SKU = ""
+		out.Barcode = nil
+	}
+
+	// Coverage is computed per response and only shown to readers
+	if auth.HasScope(ctx, auth.ScopeRead) {
+		out.CoverageDays = coverageDays(ctx, p)
+	}
+
+	return out
+}
+
+// NewProducts maps a slice of products to their API representation
+func NewProducts(ctx context.Context, products []models.Product) []*Product {
+	out := make([]*Product, len(products))
+	for i := range products {
+		out[i] = NewProduct(ctx, &products[i])
+	}
+	return out
+}
+
+// FullProduct is the API representation of a product with all its relations
+type FullProduct struct {
+	*Product
+	Images       []models.ProductImage       `json:"images"`
+	Variants     []models.ProductVariant     `json:"variants"`
+	Tags         []string                    `json:"tags"`
+	Translations []models.ProductTranslation `json:"translations"`
+	PriceTiers   []models.PriceTier          `json:"price_tiers"`
+}
+
+// NewFullProduct maps a product and its relations to their API representation
+func NewFullProduct(ctx context.Context, p *models.FullProduct) *FullProduct {
+	return &FullProduct{
+		Product:      NewProduct(ctx, &p.Product),
+		Images:       p.Images,
+		Variants:     p.Variants,
+		Tags:         p.Tags,
+		Translations: p.Translations,
+		PriceTiers:   p.PriceTiers,
+	}
+}
+
+// ScoredProduct is the API representation of a product with its data-quality score
+type ScoredProduct struct {
+	*Product
+	DataQualityScore int `json:"data_quality_score"`
+}
+
+// NewScoredProducts maps scored products to their API representation
+func NewScoredProducts(ctx context.Context, scored []models.ScoredProduct) []ScoredProduct {
+	out := make([]ScoredProduct, len(scored))
+	for i := range scored {
+		out[i] = ScoredProduct{Product: NewProduct(ctx, &scored[i].Product), DataQualityScore: scored[i].DataQualityScore}
+	}
+	return out
+}
+
+// ProductUpdateFrequency is the API representation of a product with its
+// update count in the report window
+type ProductUpdateFrequency struct {
+	*Product
+	UpdateCount int64 `json:"update_count"`
+}
+
+// NewProductUpdateFrequencies maps counted products to their API representation
+func NewProductUpdateFrequencies(ctx context.Context, counted []models.ProductUpdateFrequency) []ProductUpdateFrequency {
+	out := make([]ProductUpdateFrequency, len(counted))
+	for i := range counted {
+		out[i] = ProductUpdateFrequency{Product: NewProduct(ctx, &counted[i].Product), UpdateCount: counted[i].UpdateCount}
+	}
+	return out
+}
+
+// NewEffectivePrice maps an effective price to its API representation,
+// rounding the base, unit and tier prices like a product's; when rounding,
+// the total is taken from the rounded unit price so it matches what the
+// caller is shown
+func NewEffectivePrice(ctx context.Context, ep *models.EffectivePrice) *models.EffectivePrice {
+	out := *ep
+	out.BasePrice = displayPrice(ctx, ep.Currency, ep.BasePrice)
+	out.UnitPrice = displayPrice(ctx, ep.Currency, ep.UnitPrice)
+	if ep.Tier != nil {
+		tier := *ep.Tier
+		tier.Price = displayPrice(ctx, ep.Currency, tier.Price)
+		out.Tier = &tier
+	}
+	if _, ok := ctx.Value(roundingKey{}).(Rounding); ok {
+		out.Total = displayPrice(ctx, ep.Currency, out.UnitPrice*float64(ep.Qty))
+	}
+	return &out
+}
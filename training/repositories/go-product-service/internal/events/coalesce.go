@@ -0,0 +1,144 @@
+package events
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Change is one logical update of a product: the state before the first
+// update and after the last update coalesced into it
+type Change struct {
+	ProductID uuid.UUID
+	Actor     string
+	Before    json.RawMessage
+	After     json.RawMessage
+	Updates   int
+	FirstAt   time.Time
+	LastAt    time.Time
+}
+
+type changeKey struct {
+	productID uuid.UUID
+	actor     string
+}
+
+// pendingChange is a change waiting for its window to close; gen tells a
+// timer whose entry was flushed and replaced apart from the entry it owns
+type pendingChange struct {
+	change Change
+	timer  *time.Timer
+	gen    uint64
+}
+
+// Coalescer merges successive updates to the same product by the same actor
+// within a window into one Change, so retrying clients produce one audit
+// entry and event instead of many. The window starts at the first update and
+// is not extended by later ones, so a constantly updated product still
+// emits a change every window. It only affects history and events; every
+// update is still persisted as it arrives.
+//
+// Emits are serialised, so once FlushProduct returns no change of that
+// product is still on its way out and a write recorded after it is ordered
+// after every coalesced update.
+type Coalescer struct {
+	window time.Duration
+	emit   func(Change)
+
+	emitMu  sync.Mutex
+	mu      sync.Mutex
+	pending map[changeKey]*pendingChange
+	gen     uint64
+}
+
+// NewCoalescer creates a coalescer passing merged changes to emit; with a
+// zero window every update is emitted immediately
+func NewCoalescer(window time.Duration, emit func(Change)) *Coalescer {
+	return &Coalescer{
+		window:  window,
+		emit:    emit,
+		pending: make(map[changeKey]*pendingChange),
+	}
+}
+
+// Update records an update of productID from before to after
+func (c *Coalescer) Update(productID uuid.UUID, actor string, before, after json.RawMessage) {
+	now := time.Now().UTC()
+	if c.window <= 0 {
+		c.emit(Change{ProductID: productID, Actor: actor, Before: before, After: after, Updates: 1, FirstAt: now, LastAt: now})
+		return
+	}
+
+	key := changeKey{productID: productID, actor: actor}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pending[key]; ok {
+		p.change.After = after
+		p.change.Updates++
+		p.change.LastAt = now
+		return
+	}
+	c.gen++
+	gen := c.gen
+	c.pending[key] = &pendingChange{
+		change: Change{ProductID: productID, Actor: actor, Before: before, After: after, Updates: 1, FirstAt: now, LastAt: now},
+		timer:  time.AfterFunc(c.window, func() { c.release(key, gen) }),
+		gen:    gen,
+	}
+}
+
+// release emits the pending change for key when its timer fires. A timer
+// that fired while Flush ran finds either nothing or a newer entry of a
+// later generation, which it leaves to that entry's own timer.
+func (c *Coalescer) release(key changeKey, gen uint64) {
+	c.emitMu.Lock()
+	defer c.emitMu.Unlock()
+
+	c.mu.Lock()
+	p, ok := c.pending[key]
+	if !ok || p.gen != gen {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, key)
+	c.mu.Unlock()
+	c.emit(p.change)
+}
+
+// Flush emits every pending change immediately; call it on shutdown
+func (c *Coalescer) Flush() {
+	c.flush(func(changeKey) bool { return true })
+}
+
+// FlushProduct emits the pending changes of productID, of every actor,
+// before it returns; call it before any other write to the product so its
+// coalesced update is recorded ahead of that write
+func (c *Coalescer) FlushProduct(productID uuid.UUID) {
+	c.flush(func(key changeKey) bool { return key.productID == productID })
+}
+
+// flush emits the pending changes whose key matches, oldest first
+func (c *Coalescer) flush(match func(changeKey) bool) {
+	c.emitMu.Lock()
+	defer c.emitMu.Unlock()
+
+	c.mu.Lock()
+	var flushed []*pendingChange
+	for key, p := range c.pending {
+		if !match(key) {
+			continue
+		}
+		p.timer.Stop()
+		flushed = append(flushed, p)
+		delete(c.pending, key)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(flushed, func(i, j int) bool { return flushed[i].gen < flushed[j].gen })
+	for _, p := range flushed {
+		c.emit(p.change)
+	}
+}
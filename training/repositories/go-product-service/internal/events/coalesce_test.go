@@ -0,0 +1,146 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recorder collects emitted changes
+type recorder struct {
+	mu      sync.Mutex
+	changes []Change
+	emitted chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{emitted: make(chan struct{}, 16)}
+}
+
+func (r *recorder) emit(c Change) {
+	r.mu.Lock()
+	r.changes = append(r.changes, c)
+	r.mu.Unlock()
+	r.emitted <- struct{}{}
+}
+
+func (r *recorder) snapshot() []Change {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Change(nil), r.changes...)
+}
+
+func (r *recorder) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.emitted:
+	case <-time.After(time.Second):
+		t.Fatal("no change emitted")
+	}
+}
+
+func TestCoalescerMergesUpdatesWithinWindow(t *testing.T) {
+	rec := newRecorder()
+	c := NewCoalescer(50*time.Millisecond, rec.emit)
+	id := uuid.New()
+
+	c.Update(id, "client", json.RawMessage(`{"price":1}`), json.RawMessage(`{"price":2}`))
+	c.Update(id, "client", json.RawMessage(`{"price":2}`), json.RawMessage(`{"price":3}`))
+	c.Update(id, "client", json.RawMessage(`{"price":3}`), json.RawMessage(`{"price":4}`))
+	rec.wait(t)
+
+	changes := rec.snapshot()
+	if len(changes) != 1 {
+		t.Fatalf("expected one coalesced change, got %d", len(changes))
+	}
+	got := changes[0]
+	if got.Updates != 3 || string(got.Before) != `{"price":1}` || string(got.After) != `{"price":4}` {
+		t.Fatalf("unexpected change: %+v", got)
+	}
+}
+
+func TestCoalescerKeepsActorsApart(t *testing.T) {
+	rec := newRecorder()
+	c := NewCoalescer(time.Hour, rec.emit)
+	id := uuid.New()
+
+	c.Update(id, "a", nil, json.RawMessage(`1`))
+	c.Update(id, "b", nil, json.RawMessage(`2`))
+	c.Flush()
+
+	if n := len(rec.snapshot()); n != 2 {
+		t.Fatalf("expected a change per actor, got %d", n)
+	}
+}
+
+func TestCoalescerZeroWindowEmitsImmediately(t *testing.T) {
+	rec := newRecorder()
+	c := NewCoalescer(0, rec.emit)
+	c.Update(uuid.New(), "client", nil, nil)
+	c.Update(uuid.New(), "client", nil, nil)
+
+	if n := len(rec.snapshot()); n != 2 {
+		t.Fatalf("expected every update emitted, got %d", n)
+	}
+}
+
+func TestCoalescerStaleTimerAfterFlushKeepsNewChange(t *testing.T) {
+	rec := newRecorder()
+	c := NewCoalescer(time.Hour, rec.emit)
+	id := uuid.New()
+	key := changeKey{productID: id, actor: "client"}
+
+	c.Update(id, "client", nil, json.RawMessage(`"old"`))
+	c.mu.Lock()
+	staleGen := c.pending[key].gen
+	c.mu.Unlock()
+	c.Flush()
+	c.Update(id, "client", nil, json.RawMessage(`"new"`))
+
+	// The first timer fired while Flush held the lock and runs late
+	c.release(key, staleGen)
+
+	changes := rec.snapshot()
+	if len(changes) != 1 || string(changes[0].After) != `"old"` {
+		t.Fatalf("stale timer must not emit the new change, got %+v", changes)
+	}
+	c.mu.Lock()
+	p, ok := c.pending[key]
+	c.mu.Unlock()
+	if !ok || string(p.change.After) != `"new"` {
+		t.Fatal("new change was dropped by the stale timer")
+	}
+
+	c.Flush()
+	if changes := rec.snapshot(); len(changes) != 2 || string(changes[1].After) != `"new"` {
+		t.Fatalf("expected the new change on flush, got %+v", changes)
+	}
+}
+
+func TestCoalescerFlushProductEmitsOnlyThatProduct(t *testing.T) {
+	rec := newRecorder()
+	c := NewCoalescer(time.Hour, rec.emit)
+	id, other := uuid.New(), uuid.New()
+
+	c.Update(id, "a", nil, json.RawMessage(`1`))
+	c.Update(other, "a", nil, json.RawMessage(`2`))
+	c.Update(id, "b", nil, json.RawMessage(`3`))
+	c.FlushProduct(id)
+
+	changes := rec.snapshot()
+	if len(changes) != 2 || changes[0].Actor != "a" || changes[1].Actor != "b" {
+		t.Fatalf("expected both actors' changes of the product, oldest first, got %+v", changes)
+	}
+	for _, change := range changes {
+		if change.ProductID != id {
+			t.Fatalf("flushed another product: %+v", change)
+		}
+	}
+	c.Flush()
+	if changes := rec.snapshot(); len(changes) != 3 || changes[2].ProductID != other {
+		t.Fatalf("expected the other product still pending, got %+v", changes)
+	}
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBatchTimeout bounds how long a synchronous write waits for more
+// messages; the outbox relay delivers one event at a time
+const kafkaBatchTimeout = 10 * time.Millisecond
+
+// KafkaSink produces each event as one message keyed by product ID, so a
+// product's events land on one partition and keep their order
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink creates a sink producing to topic; writes wait for every
+// in-sync replica, so a delivered event survives a broker failure
+func NewKafkaSink(brokers []string, topic string, timeout time.Duration) *KafkaSink {
+	return &KafkaSink{writer: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: kafkaBatchTimeout,
+		WriteTimeout: timeout,
+	}}
+}
+
+// Deliver writes events as JSON messages with the event type in a header
+func (s *KafkaSink) Deliver(ctx context.Context, events []Event) error {
+	messages := make([]kafka.Message, len(events))
+	for i, e := range events {
+		value, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		messages[i] = kafka.Message{
+			Key:     []byte(e.ProductID.String()),
+			Value:   value,
+			Headers: []kafka.Header{{Key: "type", Value: []byte(e.Type)}},
+		}
+	}
+	return s.writer.WriteMessages(ctx, messages...)
+}
+
+// Close flushes pending writes and closes the broker connections
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
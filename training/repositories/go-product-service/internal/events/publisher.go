@@ -0,0 +1,164 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// deliveryTimeout bounds a single delivery started by the batching timer
+const deliveryTimeout = 10 * time.Second
+
+// Event represents a product change delivered to subscribers
+type Event struct {
+	Type       string          `json:"type"`
+	ProductID  uuid.UUID       `json:"product_id"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// Sink delivers events; every delivery is a JSON array, even for one event
+type Sink interface {
+	Deliver(ctx context.Context, events []Event) error
+}
+
+// HTTPSink POSTs each delivery to a webhook URL
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPSink creates a sink posting to url
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{URL: url, Client: &http.Client{Timeout: deliveryTimeout}}
+}
+
+// Deliver posts events as a JSON array and fails on a non-2xx response
+func (s *HTTPSink) Deliver(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("event delivery failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Publisher sends events to a sink. With a zero window every Publish is
+// delivered immediately; otherwise events published within the window are
+// coalesced into one delivery, flushed early once maxBatch are pending.
+// PublishBatch always sends its events as one delivery. Events keep their
+// publish order within and across deliveries.
+type Publisher struct {
+	sink     Sink
+	window   time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending []Event
+	timer   *time.Timer
+
+	// deliverMu serializes deliveries so batches arrive in publish order
+	deliverMu sync.Mutex
+	failed    atomic.Int64
+}
+
+// NewPublisher creates a publisher; window <= 0 disables batching
+func NewPublisher(sink Sink, window time.Duration, maxBatch int) *Publisher {
+	if maxBatch < 1 {
+		maxBatch = 1
+	}
+	return &Publisher{sink: sink, window: window, maxBatch: maxBatch}
+}
+
+// Publish sends e now, or queues it for the current batch window
+func (p *Publisher) Publish(ctx context.Context, e Event) error {
+	if p.window <= 0 {
+		p.deliverMu.Lock()
+		defer p.deliverMu.Unlock()
+		return p.sink.Deliver(ctx, []Event{e})
+	}
+
+	p.mu.Lock()
+	p.pending = append(p.pending, e)
+	full := len(p.pending) >= p.maxBatch
+	if !full && p.timer == nil {
+		p.timer = time.AfterFunc(p.window, p.flushAsync)
+	}
+	p.mu.Unlock()
+
+	if full {
+		return p.Flush(ctx)
+	}
+	return nil
+}
+
+// PublishBatch sends the events of a bulk operation as a single delivery,
+// after any events already waiting in the batch window
+func (p *Publisher) PublishBatch(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return p.deliverPending(ctx, events)
+}
+
+// Flush delivers any pending events now
+func (p *Publisher) Flush(ctx context.Context) error {
+	return p.deliverPending(ctx, nil)
+}
+
+// Failed returns the number of deliveries started by the batch timer that
+// could not be delivered
+func (p *Publisher) Failed() int64 {
+	return p.failed.Load()
+}
+
+// deliverPending delivers the pending events followed by extra. The delivery
+// lock is taken before the queue is drained, so batches cannot overtake
+// each other between being taken and being sent.
+func (p *Publisher) deliverPending(ctx context.Context, extra []Event) error {
+	p.deliverMu.Lock()
+	defer p.deliverMu.Unlock()
+
+	p.mu.Lock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	batch := append(p.pending, extra...)
+	p.pending = nil
+	p.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	return p.sink.Deliver(ctx, batch)
+}
+
+// flushAsync is run by the batch timer
+func (p *Publisher) flushAsync() {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+	if err := p.Flush(ctx); err != nil {
+		p.failed.Add(1)
+	}
+}
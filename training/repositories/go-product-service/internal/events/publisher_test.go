@@ -0,0 +1,112 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memorySink records every delivery
+type memorySink struct {
+	mu         sync.Mutex
+	deliveries [][]Event
+	delivered  chan struct{}
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{delivered: make(chan struct{}, 16)}
+}
+
+func (s *memorySink) Deliver(_ context.Context, events []Event) error {
+	s.mu.Lock()
+	s.deliveries = append(s.deliveries, append([]Event(nil), events...))
+	s.mu.Unlock()
+	s.delivered <- struct{}{}
+	return nil
+}
+
+func (s *memorySink) snapshot() [][]Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]Event(nil), s.deliveries...)
+}
+
+func testEvents(n int) []Event {
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = Event{Type: "product.updated", ProductID: uuid.New()}
+	}
+	return events
+}
+
+func TestPublisherWithoutWindowDeliversEachEvent(t *testing.T) {
+	sink := newMemorySink()
+	p := NewPublisher(sink, 0, 10)
+	for _, e := range testEvents(3) {
+		if err := p.Publish(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := sink.snapshot(); len(got) != 3 {
+		t.Fatalf("expected three deliveries, got %d", len(got))
+	}
+}
+
+func TestPublisherCoalescesWindowInOrder(t *testing.T) {
+	sink := newMemorySink()
+	p := NewPublisher(sink, 20*time.Millisecond, 100)
+	events := testEvents(5)
+	for _, e := range events {
+		if err := p.Publish(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case <-sink.delivered:
+	case <-time.After(time.Second):
+		t.Fatal("window never flushed")
+	}
+
+	got := sink.snapshot()
+	if len(got) != 1 || len(got[0]) != len(events) {
+		t.Fatalf("expected one delivery of %d events, got %v", len(events), got)
+	}
+	for i := range events {
+		if got[0][i].ProductID != events[i].ProductID {
+			t.Fatalf("event %d delivered out of order", i)
+		}
+	}
+}
+
+func TestPublisherFlushesFullBatchEarly(t *testing.T) {
+	sink := newMemorySink()
+	p := NewPublisher(sink, time.Hour, 2)
+	for _, e := range testEvents(2) {
+		if err := p.Publish(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := sink.snapshot(); len(got) != 1 || len(got[0]) != 2 {
+		t.Fatalf("expected the full batch to be delivered at once, got %v", got)
+	}
+}
+
+func TestPublishBatchFollowsPendingEvents(t *testing.T) {
+	sink := newMemorySink()
+	p := NewPublisher(sink, time.Hour, 100)
+	single, bulk := testEvents(1)[0], testEvents(3)
+	if err := p.Publish(context.Background(), single); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.PublishBatch(context.Background(), bulk); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sink.snapshot()
+	if len(got) != 1 || len(got[0]) != 4 || got[0][0].ProductID != single.ProductID || got[0][3].ProductID != bulk[2].ProductID {
+		t.Fatalf("expected the pending event then the bulk events in one delivery, got %v", got)
+	}
+}
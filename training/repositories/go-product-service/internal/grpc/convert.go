@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/grpc/productv1"
+	"github.com/company/go-product-service/internal/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// productToProto maps a product to its protobuf message, masking fields for
+// ctx's caller as dto.NewProduct does
+func productToProto(ctx context.Context, p *models.Product) *productv1.Product {
+	out := &productv1.Product{
+		Id:          p.ID.String(),
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Currency:    p.Currency,
+		Category:    p.Category,
+		Sku:         p.SKU,
+		Barcode:     p.Barcode,
+		Stock:       int32(p.Stock),
+		Reserved:    int32(p.Reserved),
+		IsActive:    p.IsActive,
+		Status:      p.Status,
+		ContentHash: p.ContentHash,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+	}
+
+	// Cost is only visible to finance; the message carries no margin or
+	// creator to mask
+	if auth.HasScope(ctx, auth.ScopeFinance) {
+		out.Cost = p.Cost
+	}
+	return out
+}
+
+// createRequestFromProto maps a create message to the REST request model,
+// so both APIs validate creates identically
+func createRequestFromProto(in *productv1.CreateProductRequest) *models.CreateProductRequest {
+	return &models.CreateProductRequest{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Price:       in.GetPrice(),
+		Cost:        in.Cost,
+		Category:    in.GetCategory(),
+		SKU:         in.GetSku(),
+		Barcode:     in.Barcode,
+		Stock:       int(in.GetStock()),
+		IsActive:    in.IsActive,
+	}
+}
+
+// updateRequestFromProto maps an update message to the REST request model;
+// unset optional fields stay nil and leave the product unchanged
+func updateRequestFromProto(in *productv1.UpdateProductRequest) *models.UpdateProductRequest {
+	req := &models.UpdateProductRequest{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		Cost:        in.Cost,
+		Category:    in.Category,
+		SKU:         in.Sku,
+		Barcode:     in.Barcode,
+		IsActive:    in.IsActive,
+	}
+	if in.Stock != nil {
+		stock := int(*in.Stock)
+		req.Stock = &stock
+	}
+	return req
+}
+
+// filterFromProto maps a list request to a product filter
+func filterFromProto(in *productv1.ListProductsRequest) *models.ProductFilter {
+	return &models.ProductFilter{
+		Category:   in.GetCategory(),
+		Categories: in.GetCategories(),
+		Tags:       in.GetTags(),
+		MinPrice:   in.GetMinPrice(),
+		MaxPrice:   in.GetMaxPrice(),
+		IsActive:   in.IsActive,
+		Search:     in.GetSearch(),
+		Limit:      int(in.GetLimit()),
+		Offset:     int(in.GetOffset()),
+		Cursor:     in.GetCursor(),
+		SortBy:     in.GetSortBy(),
+		SortOrder:  in.GetSortOrder(),
+	}
+}
@@ -0,0 +1,4 @@
+// Package productv1 holds the Go code generated from proto/product/v1/product.proto
+package productv1
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=github.com/company/go-product-service --go-grpc_out=../../.. --go-grpc_opt=module=github.com/company/go-product-service product/v1/product.proto
@@ -0,0 +1,209 @@
+// Package grpc serves the product API over gRPC for internal consumers. It
+// shares the service layer with the REST server, so both surfaces apply the
+// same business rules.
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/grpc/productv1"
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// ProductService is the part of service.ProductService the gRPC API uses.
+// Lookups of unknown IDs must return an error wrapping sql.ErrNoRows.
+type ProductService interface {
+	AssignSKU(ctx context.Context, req *models.CreateProductRequest) error
+	CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, req *models.UpdateProductRequest) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	ListProducts(ctx context.Context, f *models.ProductFilter) ([]models.Product, int64, string, error)
+	Normalize(req models.Normalizer)
+}
+
+// Server implements productv1.ProductServiceServer
+type Server struct {
+	productv1.UnimplementedProductServiceServer
+
+	products ProductService
+	validate *validator.Validate
+	grpc     *grpclib.Server
+	health   *health.Server
+}
+
+// NewServer creates a gRPC server exposing products, the standard health
+// service and server reflection
+func NewServer(products ProductService, validate *validator.Validate) *Server {
+	s := &Server{
+		products: products,
+		validate: validate,
+		grpc:     grpclib.NewServer(),
+		health:   health.NewServer(),
+	}
+	productv1.RegisterProductServiceServer(s.grpc, s)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	return s
+}
+
+// Start listens on addr and serves until Stop is called
+func (s *Server) Start(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(productv1.ProductService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return s.grpc.Serve(lis)
+}
+
+// Stop reports NOT_SERVING to health checks and waits for in-flight calls,
+// or cancels them when ctx expires first
+func (s *Server) Stop(ctx context.Context) {
+	s.health.Shutdown()
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.grpc.Stop()
+	}
+}
+
+// CreateProduct implements productv1.ProductServiceServer
+func (s *Server) CreateProduct(ctx context.Context, in *productv1.CreateProductRequest) (*productv1.Product, error) {
+	req := createRequestFromProto(in)
+	s.products.Normalize(req)
+	// A generated SKU must be in place before the request is validated, as
+	// on the REST API
+	if err := s.products.AssignSKU(ctx, req); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, toStatus(err)
+	}
+	p, err := s.products.CreateProduct(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return productToProto(ctx, p), nil
+}
+
+// GetProduct implements productv1.ProductServiceServer
+func (s *Server) GetProduct(ctx context.Context, in *productv1.GetProductRequest) (*productv1.Product, error) {
+	id, err := parseID(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.products.GetProduct(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return productToProto(ctx, p), nil
+}
+
+// UpdateProduct implements productv1.ProductServiceServer
+func (s *Server) UpdateProduct(ctx context.Context, in *productv1.UpdateProductRequest) (*productv1.Product, error) {
+	id, err := parseID(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	req := updateRequestFromProto(in)
+	s.products.Normalize(req)
+	if err := s.validate.Struct(req); err != nil {
+		return nil, toStatus(err)
+	}
+	p, err := s.products.UpdateProduct(ctx, id, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return productToProto(ctx, p), nil
+}
+
+// DeleteProduct implements productv1.ProductServiceServer
+func (s *Server) DeleteProduct(ctx context.Context, in *productv1.DeleteProductRequest) (*productv1.DeleteProductResponse, error) {
+	id, err := parseID(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.DeleteProduct(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &productv1.DeleteProductResponse{}, nil
+}
+
+// ListProducts implements productv1.ProductServiceServer
+func (s *Server) ListProducts(ctx context.Context, in *productv1.ListProductsRequest) (*productv1.ListProductsResponse, error) {
+	f := filterFromProto(in)
+	f.ApplyDefaults()
+	if err := s.validate.Struct(f); err != nil {
+		return nil, toStatus(err)
+	}
+	products, total, next, err := s.products.ListProducts(ctx, f)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := &productv1.ListProductsResponse{
+		Products:   make([]*productv1.Product, len(products)),
+		Total:      total,
+		NextCursor: next,
+	}
+	for i := range products {
+		out.Products[i] = productToProto(ctx, &products[i])
+	}
+	return out, nil
+}
+
+// parseID validates a product ID from a request
+func parseID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid product id")
+	}
+	return id, nil
+}
+
+// toStatus maps service errors to gRPC status codes
+func toStatus(err error) error {
+	var validationErrs validator.ValidationErrors
+	var priceErr *models.PriceError
+	var precisionErr *currency.PrecisionError
+	var stockErr *inventory.InsufficientStockError
+	var transitionErr *models.TransitionError
+	var tooMany *query.TooManyRowsError
+	switch {
+	case errors.As(err, &validationErrs), errors.As(err, &priceErr), errors.As(err, &precisionErr),
+		errors.Is(err, models.ErrInvalidMetadata), errors.Is(err, models.ErrInvalidFilter), errors.As(err, &tooMany):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "product not found")
+	case errors.Is(err, models.ErrDuplicateSKU):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.As(err, &stockErr), errors.As(err, &transitionErr):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
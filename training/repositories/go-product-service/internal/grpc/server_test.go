@@ -0,0 +1,206 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/grpc/productv1"
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeProducts is an in-memory ProductService
+type fakeProducts struct {
+	products map[uuid.UUID]*models.Product
+	err      error
+}
+
+// AssignSKU generates the SKU of a request that omits one
+func (f *fakeProducts) AssignSKU(_ context.Context, req *models.CreateProductRequest) error {
+	if req.SKU == "" {
+		req.SKU = "GEN-1"
+	}
+	return nil
+}
+
+func (f *fakeProducts) CreateProduct(_ context.Context, req *models.CreateProductRequest) (*models.Product, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p := &models.Product{ID: uuid.New(), Name: req.Name, Price: req.Price, Cost: req.Cost, Category: req.Category, SKU: req.SKU, Stock: req.Stock, IsActive: true}
+	f.products[p.ID] = p
+	return p, nil
+}
+
+func (f *fakeProducts) GetProduct(_ context.Context, id uuid.UUID) (*models.Product, error) {
+	p, ok := f.products[id]
+	if !ok {
+		return nil, fmt.Errorf("get product: %w", sql.ErrNoRows)
+	}
+	return p, nil
+}
+
+func (f *fakeProducts) UpdateProduct(_ context.Context, id uuid.UUID, req *models.UpdateProductRequest) (*models.Product, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p, ok := f.products[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	return p, nil
+}
+
+func (f *fakeProducts) DeleteProduct(_ context.Context, id uuid.UUID) error {
+	delete(f.products, id)
+	return nil
+}
+
+func (f *fakeProducts) ListProducts(_ context.Context, _ *models.ProductFilter) ([]models.Product, int64, string, error) {
+	var out []models.Product
+	for _, p := range f.products {
+		out = append(out, *p)
+	}
+	return out, int64(len(out)), "next", nil
+}
+
+func (f *fakeProducts) Normalize(models.Normalizer) {}
+
+// dial starts s on an in-memory listener and returns a connected client
+func dial(t *testing.T, s *Server) productv1.ProductServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go s.grpc.Serve(lis)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		s.Stop(ctx)
+	})
+
+	conn, err := grpclib.DialContext(context.Background(), "bufnet",
+		grpclib.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return productv1.NewProductServiceClient(conn)
+}
+
+func TestCreateGetAndList(t *testing.T) {
+	svc := &fakeProducts{products: map[uuid.UUID]*models.Product{}}
+	client := dial(t, NewServer(svc, validator.New()))
+	ctx := context.Background()
+
+	created, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{
+		Name: "Desk", Price: 120, Category: "furniture", Sku: "DESK-1", Stock: 3,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	got, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: created.GetId()})
+	if err != nil || got.GetSku() != "DESK-1" || got.GetStock() != 3 {
+		t.Fatalf("GetProduct: %v %v", got, err)
+	}
+
+	list, err := client.ListProducts(ctx, &productv1.ListProductsRequest{})
+	if err != nil || list.GetTotal() != 1 || list.GetNextCursor() != "next" {
+		t.Fatalf("ListProducts: %v %v", list, err)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	svc := &fakeProducts{products: map[uuid.UUID]*models.Product{}}
+	client := dial(t, NewServer(svc, validator.New()))
+	ctx := context.Background()
+	valid := &productv1.CreateProductRequest{Name: "Desk", Price: 120, Category: "furniture", Sku: "DESK-1"}
+
+	cases := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"invalid id", func() error {
+			_, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: "nope"})
+			return err
+		}, codes.InvalidArgument},
+		{"validation", func() error {
+			_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Name: "Desk"})
+			return err
+		}, codes.InvalidArgument},
+		{"not found", func() error {
+			_, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: uuid.NewString()})
+			return err
+		}, codes.NotFound},
+		{"duplicate sku", func() error {
+			svc.err = fmt.Errorf("create product: %w", models.ErrDuplicateSKU)
+			defer func() { svc.err = nil }()
+			_, err := client.CreateProduct(ctx, valid)
+			return err
+		}, codes.AlreadyExists},
+		{"insufficient stock", func() error {
+			svc.err = &inventory.InsufficientStockError{ProductID: uuid.New(), Requested: 2}
+			defer func() { svc.err = nil }()
+			_, err := client.CreateProduct(ctx, valid)
+			return err
+		}, codes.FailedPrecondition},
+		{"internal", func() error {
+			svc.err = fmt.Errorf("connection reset")
+			defer func() { svc.err = nil }()
+			_, err := client.CreateProduct(ctx, valid)
+			return err
+		}, codes.Internal},
+	}
+	for _, tc := range cases {
+		if got := status.Code(tc.call()); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCreateAssignsSKUAndHidesCost(t *testing.T) {
+	svc := &fakeProducts{products: map[uuid.UUID]*models.Product{}}
+	client := dial(t, NewServer(svc, validator.New()))
+
+	cost := 80.0
+	created, err := client.CreateProduct(context.Background(), &productv1.CreateProductRequest{
+		Name: "Desk", Price: 120, Cost: &cost, Category: "furniture",
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if created.GetSku() != "GEN-1" {
+		t.Fatalf("expected the SKU assigned before validation, got %q", created.GetSku())
+	}
+	if created.Cost != nil {
+		t.Fatalf("expected cost hidden without the finance scope, got %v", created.GetCost())
+	}
+}
+
+func TestProductToProtoShowsCostToFinance(t *testing.T) {
+	cost := 80.0
+	p := &models.Product{ID: uuid.New(), Price: 120, Cost: &cost}
+
+	if got := productToProto(context.Background(), p); got.Cost != nil {
+		t.Fatalf("expected cost hidden from an anonymous caller, got %v", got.GetCost())
+	}
+	ctx := auth.WithScopes(context.Background(), []string{auth.ScopeFinance})
+	if got := productToProto(ctx, p); got.GetCost() != 80 {
+		t.Fatalf("expected cost shown to finance, got %v", got.Cost)
+	}
+}
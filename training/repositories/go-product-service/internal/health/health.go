@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pingTimeout bounds a single readiness ping
+const pingTimeout = 2 * time.Second
+
+// Pinger is implemented by *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// ReadinessChecker pings the database at most once per ttl; probes arriving
+// while a ping is in flight wait for it instead of starting their own
+type ReadinessChecker struct {
+	db  Pinger
+	ttl time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+	inflight  chan struct{}
+	now       func() time.Time
+}
+
+// NewReadinessChecker creates a checker that caches the result for ttl
+func NewReadinessChecker(db Pinger, ttl time.Duration) *ReadinessChecker {
+	return &ReadinessChecker{db: db, ttl: ttl, now: time.Now}
+}
+
+// Check returns the cached readiness result, pinging the database when it has expired
+func (c *ReadinessChecker) Check(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.checkedAt.IsZero() && c.now().Sub(c.checkedAt) < c.ttl {
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	if wait := c.inflight; wait != nil {
+		c.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c.mu.Lock()
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	done := make(chan struct{})
+	c.inflight = done
+	c.mu.Unlock()
+
+	pingCtx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	err := c.db.PingContext(pingCtx)
+	cancel()
+
+	c.mu.Lock()
+	c.err = err
+	c.checkedAt = c.now()
+	c.inflight = nil
+	c.mu.Unlock()
+	close(done)
+
+	return err
+}
+
+// LivenessHandler serves /healthz; it never touches dependencies
+func LivenessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+// ReadinessHandler serves /readyz from the checker's cached result
+func ReadinessHandler(checker *ReadinessChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := checker.Check(c.Request.Context()); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+	}
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// countingPinger counts pings and holds each one until release is closed
+type countingPinger struct {
+	pings   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (p *countingPinger) PingContext(context.Context) error {
+	p.pings.Add(1)
+	<-p.release
+	return p.err
+}
+
+func TestConcurrentProbesShareOnePing(t *testing.T) {
+	db := &countingPinger{release: make(chan struct{})}
+	checker := NewReadinessChecker(db, time.Second)
+
+	const probes = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, probes)
+	for i := 0; i < probes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- checker.Check(context.Background())
+		}()
+	}
+	// Let every probe arrive while the first ping is still in flight
+	for db.pings.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(db.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+	if n := db.pings.Load(); n != 1 {
+		t.Fatalf("expected one ping for %d concurrent probes, got %d", probes, n)
+	}
+}
+
+func TestReadinessReflectsAnOutageAfterTheWindow(t *testing.T) {
+	db := &countingPinger{release: make(chan struct{})}
+	close(db.release)
+	checker := NewReadinessChecker(db, time.Second)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	checker.now = func() time.Time { return now }
+
+	if err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	db.err = errors.New("connection refused")
+	now = now.Add(500 * time.Millisecond)
+	if err := checker.Check(context.Background()); err != nil {
+		t.Fatalf("expected the cached result within the window, got %v", err)
+	}
+	now = now.Add(time.Second)
+	if err := checker.Check(context.Background()); err == nil {
+		t.Fatal("expected the outage once the window passed")
+	}
+	if n := db.pings.Load(); n != 2 {
+		t.Fatalf("expected 2 pings, got %d", n)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := &countingPinger{release: make(chan struct{}), err: errors.New("connection refused")}
+	close(db.release)
+	router := gin.New()
+	router.GET("/healthz", LivenessHandler())
+	router.GET("/readyz", ReadinessHandler(NewReadinessChecker(db, time.Second)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK || db.pings.Load() != 0 {
+		t.Fatalf("expected liveness without a ping, got %d after %d pings", w.Code, db.pings.Load())
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 while the database is down, got %d", w.Code)
+	}
+}
@@ -0,0 +1,119 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExpectedIndex requires some index on Table whose leading column is Column;
+// matching by column rather than name tolerates indexes created by hand
+type ExpectedIndex struct {
+	Table  string
+	Column string
+}
+
+func (i ExpectedIndex) String() string {
+	return i.Table + "(" + i.Column + ")"
+}
+
+// ExpectedIndexes are the indexes the hot queries rely on
+var ExpectedIndexes = []ExpectedIndex{
+	{"products", "sku"},
+	{"products", "category"},
+	{"products", "created_at"},
+	{"products", "updated_at"},
+	{"products", "barcode"},
+	{"products", "content_hash"},
+	{"products", "search_vector"},
+	{"product_images", "product_id"},
+	{"product_tags", "tag"},
+	{"stock_movements", "product_id"},
+	{"stock_reservations", "product_id"},
+	{"audit_log", "created_at"},
+}
+
+// listIndexes returns every index definition in the current schema
+const listIndexes = `SELECT tablename, indexdef FROM pg_indexes WHERE schemaname = current_schema()`
+
+// MissingIndexesError lists the expected indexes that do not exist
+type MissingIndexesError struct {
+	Missing []ExpectedIndex
+}
+
+func (e *MissingIndexesError) Error() string {
+	names := make([]string, len(e.Missing))
+	for i, m := range e.Missing {
+		names[i] = m.String()
+	}
+	return fmt.Sprintf("missing indexes: %s", strings.Join(names, ", "))
+}
+
+// VerifyIndexes checks pg_indexes for every expected index and returns a
+// *MissingIndexesError naming those that are absent
+func VerifyIndexes(ctx context.Context, db *sql.DB, expected []ExpectedIndex) error {
+	rows, err := db.QueryContext(ctx, listIndexes)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	present := make(map[ExpectedIndex]bool)
+	for rows.Next() {
+		var table, def string
+		if err := rows.Scan(&table, &def); err != nil {
+			return err
+		}
+		if column := leadingColumn(def); column != "" {
+			present[ExpectedIndex{Table: table, Column: column}] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var missing []ExpectedIndex
+	for _, e := range expected {
+		if !present[e] {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingIndexesError{Missing: missing}
+	}
+	return nil
+}
+
+// leadingColumn extracts the first indexed column from a pg_indexes
+// definition such as "CREATE INDEX i ON public.products USING btree (category, price)";
+// expression indexes yield an empty string
+func leadingColumn(def string) string {
+	_, rest, ok := strings.Cut(def, " USING ")
+	if !ok {
+		return ""
+	}
+	_, cols, ok := strings.Cut(rest, "(")
+	if !ok {
+		return ""
+	}
+	end := strings.IndexAny(cols, ",) (")
+	if end <= 0 || cols[end] == '(' {
+		return ""
+	}
+	return strings.Trim(cols[:end], `"`)
+}
+
+// CheckIndexes verifies the expected indexes at startup. A missing index is
+// returned as an error in strict mode and only passed to warn otherwise; a
+// failure to query pg_indexes is always returned.
+func CheckIndexes(ctx context.Context, db *sql.DB, expected []ExpectedIndex, strict bool, warn func(error)) error {
+	err := VerifyIndexes(ctx, db, expected)
+	var missing *MissingIndexesError
+	if errors.As(err, &missing) && !strict {
+		warn(err)
+		return nil
+	}
+	return err
+}
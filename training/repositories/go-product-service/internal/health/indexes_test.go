@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestVerifyIndexesReportsMissingIndex(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT tablename, indexdef FROM pg_indexes").WillReturnRows(
+		sqlmock.NewRows([]string{"tablename", "indexdef"}).
+			AddRow("products", "CREATE UNIQUE INDEX products_sku_key ON public.products USING btree (sku)").
+			AddRow("products", "CREATE INDEX idx_products_category_price ON public.products USING btree (category, price)"))
+
+	expected := []ExpectedIndex{{"products", "sku"}, {"products", "category"}, {"products", "created_at"}}
+	err = VerifyIndexes(context.Background(), db, expected)
+
+	var missing *MissingIndexesError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingIndexesError, got %v", err)
+	}
+	if len(missing.Missing) != 1 || missing.Missing[0] != (ExpectedIndex{"products", "created_at"}) {
+		t.Fatalf("expected only created_at missing, got %v", missing.Missing)
+	}
+	if err.Error() != "missing indexes: products(created_at)" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestVerifyIndexesPassesWhenAllPresent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT tablename, indexdef FROM pg_indexes").WillReturnRows(
+		sqlmock.NewRows([]string{"tablename", "indexdef"}).
+			AddRow("audit_log", `CREATE INDEX idx_audit_log_created_at ON public.audit_log USING btree ("created_at")`))
+
+	if err := VerifyIndexes(context.Background(), db, []ExpectedIndex{{"audit_log", "created_at"}}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestLeadingColumn(t *testing.T) {
+	cases := map[string]string{
+		"CREATE INDEX i ON public.products USING btree (category, price)":                "category",
+		"CREATE INDEX i ON public.products USING gin (name gin_trgm_ops)":                "name",
+		"CREATE INDEX i ON public.products USING btree (lower((name)::text))":            "",
+		"CREATE INDEX i ON public.products USING btree (sku) WHERE (deleted_at IS NULL)": "sku",
+	}
+	for def, want := range cases {
+		if got := leadingColumn(def); got != want {
+			t.Errorf("leadingColumn(%q) = %q, want %q", def, got, want)
+		}
+	}
+}
+
+func TestCheckIndexesWarnsOrFails(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mock.ExpectQuery("SELECT tablename, indexdef FROM pg_indexes").
+			WillReturnRows(sqlmock.NewRows([]string{"tablename", "indexdef"}))
+
+		var warned error
+		err = CheckIndexes(context.Background(), db, []ExpectedIndex{{"products", "sku"}}, strict, func(err error) { warned = err })
+		db.Close()
+
+		if strict {
+			if err == nil || warned != nil {
+				t.Fatalf("strict mode should fail without warning, got err=%v warned=%v", err, warned)
+			}
+		} else if err != nil || warned == nil {
+			t.Fatalf("lenient mode should warn without failing, got err=%v warned=%v", err, warned)
+		}
+	}
+}
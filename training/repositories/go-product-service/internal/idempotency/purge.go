@@ -0,0 +1,22 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/company/go-product-service/internal/jobs"
+)
+
+// PurgeJob removes expired records from store every interval. Expired keys
+// are already ignored by Get and reused by Put; the sweep only keeps the
+// store from growing with keys that are never retried.
+func PurgeJob(store Store, interval time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "idempotency-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := store.Purge(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,232 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Supported store backends
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// ErrKeyExists is returned when storing a key that is already present
+var ErrKeyExists = errors.New("idempotency key already exists")
+
+// Record is the stored outcome of a request made with an idempotency key.
+// A record without a status code is pending: its request is still running.
+type Record struct {
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// Pending reports whether the record's request has not finished yet
+func (r *Record) Pending() bool {
+	return r.StatusCode == 0
+}
+
+// Store persists idempotency records until their TTL expires
+type Store interface {
+	// Get returns the record for key, or nil if it is missing or expired
+	Get(ctx context.Context, key string) (*Record, error)
+	// Put stores rec under key, returning ErrKeyExists if a live record is present
+	Put(ctx context.Context, key string, rec *Record, ttl time.Duration) error
+	// Complete stores rec under key, replacing the pending record Put stored
+	Complete(ctx context.Context, key string, rec *Record, ttl time.Duration) error
+	// Delete removes key
+	Delete(ctx context.Context, key string) error
+	// Purge removes every expired record, reporting how many it removed
+	Purge(ctx context.Context) (int64, error)
+}
+
+// NewStore creates the store for backend; the memory backend is only
+// correct for a single instance, since keys are not shared behind a load balancer
+func NewStore(backend string, db *sql.DB) (Store, error) {
+	switch backend {
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendPostgres:
+		if db == nil {
+			return nil, errors.New("postgres idempotency store requires a database connection")
+		}
+		return NewPostgresStore(db), nil
+	}
+	return nil, fmt.Errorf("unknown idempotency store backend %q", backend)
+}
+
+type memoryEntry struct {
+	record    Record
+	expiresAt time.Time
+}
+
+// MemoryStore keeps idempotency records in process memory
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the live record for key
+func (s *MemoryStore) Get(_ context.Context, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if !s.now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return nil, nil
+	}
+	rec := e.record
+	return &rec, nil
+}
+
+// Put stores rec under key unless a live record already exists
+func (s *MemoryStore) Put(_ context.Context, key string, rec *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		return ErrKeyExists
+	}
+	s.entries[key] = memoryEntry{record: *rec, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Complete stores rec under key, whatever record it holds
+func (s *MemoryStore) Complete(_ context.Context, key string, rec *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryEntry{record: *rec, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+// Delete removes key
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// Purge removes the expired records
+func (s *MemoryStore) Purge(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var purged int64
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// PostgresStore keeps idempotency records in the idempotency_keys table,
+// shared by every instance of the service
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a store backed by db
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Get returns the live record for key
+func (s *PostgresStore) Get(ctx context.Context, key string) (*Record, error) {
+	var rec Record
+	err := s.db.QueryRowContext(ctx,
+		`SELECT request_hash, status_code, body, created_at
+		 FROM idempotency_keys
+		 WHERE key = $1 AND expires_at > NOW()`, key,
+	).Scan(&rec.RequestHash, &rec.StatusCode, &rec.Body, &rec.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Put stores rec under key unless a live record already exists; an expired
+// record for the same key is replaced
+func (s *PostgresStore) Put(ctx context.Context, key string, rec *Record, ttl time.Duration) error {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (key, request_hash, status_code, body, created_at, expires_at)
+		 VALUES ($1, $2, $3, $4, NOW(), NOW() + $5 * INTERVAL '1 second')
+		 ON CONFLICT (key) DO UPDATE
+		 SET request_hash = EXCLUDED.request_hash,
+		     status_code = EXCLUDED.status_code,
+		     body = EXCLUDED.body,
+		     created_at = EXCLUDED.created_at,
+		     expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= NOW()`,
+		key, rec.RequestHash, rec.StatusCode, rec.Body, ttl.Seconds(),
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrKeyExists
+	}
+	return nil
+}
+
+// Complete stores rec under key, whatever record it holds
+func (s *PostgresStore) Complete(ctx context.Context, key string, rec *Record, ttl time.Duration) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (key, request_hash, status_code, body, created_at, expires_at)
+		 VALUES ($1, $2, $3, $4, NOW(), NOW() + $5 * INTERVAL '1 second')
+		 ON CONFLICT (key) DO UPDATE
+		 SET request_hash = EXCLUDED.request_hash,
+		     status_code = EXCLUDED.status_code,
+		     body = EXCLUDED.body,
+		     created_at = EXCLUDED.created_at,
+		     expires_at = EXCLUDED.expires_at`,
+		key, rec.RequestHash, rec.StatusCode, rec.Body, ttl.Seconds(),
+	)
+	return err
+}
+
+// Delete removes key
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = $1`, key)
+	return err
+}
+
+// Purge removes the expired records
+func (s *PostgresStore) Purge(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
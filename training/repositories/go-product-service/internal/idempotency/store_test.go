@@ -0,0 +1,97 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStorePutGetAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	if rec, err := s.Get(ctx, "k"); err != nil || rec != nil {
+		t.Fatalf("expected no record, got %+v, %v", rec, err)
+	}
+	if err := s.Put(ctx, "k", &Record{RequestHash: "a", StatusCode: 201, Body: []byte(`{}`)}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := s.Get(ctx, "k")
+	if err != nil || rec == nil || rec.RequestHash != "a" || rec.StatusCode != 201 {
+		t.Fatalf("expected the stored record, got %+v, %v", rec, err)
+	}
+	if err := s.Put(ctx, "k", &Record{RequestHash: "b"}, time.Minute); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("expected ErrKeyExists for a live key, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if rec, err := s.Get(ctx, "k"); err != nil || rec != nil {
+		t.Fatalf("expected the record to expire, got %+v, %v", rec, err)
+	}
+	if err := s.Put(ctx, "k", &Record{RequestHash: "b"}, time.Minute); err != nil {
+		t.Fatalf("expected an expired key to be reusable, got %v", err)
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	if err := s.Put(ctx, "k", &Record{RequestHash: "a"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := s.Get(ctx, "k"); rec != nil {
+		t.Fatalf("expected the key to be gone, got %+v", rec)
+	}
+}
+
+func TestNewStoreRejectsUnknownBackends(t *testing.T) {
+	if _, err := NewStore("etcd", nil); err == nil {
+		t.Fatal("expected an error for an unknown backend")
+	}
+	if _, err := NewStore(BackendPostgres, nil); err == nil {
+		t.Fatal("expected an error for postgres without a database")
+	}
+	if s, err := NewStore(BackendMemory, nil); err != nil || s == nil {
+		t.Fatalf("expected a memory store, got %v", err)
+	}
+}
+
+func TestMemoryStoreCompleteAndPurge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	if err := s.Put(ctx, "k", &Record{RequestHash: "a"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := s.Get(ctx, "k"); rec == nil || !rec.Pending() {
+		t.Fatalf("expected a pending record, got %+v", rec)
+	}
+	if err := s.Complete(ctx, "k", &Record{RequestHash: "a", StatusCode: 201}, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := s.Get(ctx, "k"); rec == nil || rec.Pending() || rec.StatusCode != 201 {
+		t.Fatalf("expected the completed record, got %+v", rec)
+	}
+	if err := s.Put(ctx, "old", &Record{RequestHash: "b"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Minute)
+	if n, err := s.Purge(ctx); err != nil || n != 1 {
+		t.Fatalf("expected the expired record purged, got %d, %v", n, err)
+	}
+	if _, ok := s.entries["old"]; ok {
+		t.Fatal("expected the expired key removed")
+	}
+	if rec, _ := s.Get(ctx, "k"); rec == nil {
+		t.Fatal("expected the live record kept")
+	}
+}
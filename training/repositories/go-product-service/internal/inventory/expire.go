@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/company/go-product-service/internal/jobs"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// DefaultExpiryBatchSize bounds how many reservations one sweep transaction releases
+const DefaultExpiryBatchSize = 500
+
+// deleteExpiredReservations removes up to $2 reservations that expired before
+// $1, skipping rows another sweeper already holds
+const deleteExpiredReservations = `DELETE FROM stock_reservations WHERE id IN (
+	SELECT id FROM stock_reservations WHERE expires_at <= $1
+	ORDER BY expires_at LIMIT $2 FOR UPDATE SKIP LOCKED
+)
+RETURNING product_id, qty`
+
+// releaseStock returns qty of a product's reserved stock
+const releaseStock = `UPDATE products
+SET reserved = GREATEST(reserved - $2, 0), updated_at = NOW()
+WHERE id = $1`
+
+// ExpireReservations releases every reservation that has expired, one batch
+// per transaction, and returns how many reservations were released. Products
+// are updated in LockOrder so a sweep cannot deadlock with ReserveBatch.
+func ExpireReservations(ctx context.Context, db *sql.DB, batchSize int) (int64, error) {
+	if batchSize < 1 {
+		batchSize = DefaultExpiryBatchSize
+	}
+	now := time.Now().UTC()
+	var total int64
+	for {
+		n, err := expireBatch(ctx, db, now, batchSize)
+		total += n
+		if err != nil || n < int64(batchSize) {
+			return total, err
+		}
+	}
+}
+
+// expireBatch deletes one batch of expired reservations and releases their
+// stock in the same transaction
+func expireBatch(ctx context.Context, db *sql.DB, now time.Time, batchSize int) (int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, deleteExpiredReservations, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	released := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var productID uuid.UUID
+		var qty int
+		if err := rows.Scan(&productID, &qty); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		released[productID] += qty
+		n++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	items := make([]models.StockLineItem, 0, len(released))
+	for productID, qty := range released {
+		items = append(items, models.StockLineItem{ProductID: productID, Qty: qty})
+	}
+	for _, i := range LockOrder(items) {
+		if _, err := tx.ExecContext(ctx, releaseStock, items[i].ProductID, items[i].Qty); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// ExpiryJob schedules the reservation sweeper on a jobs.Runner
+func ExpiryJob(db *sql.DB, interval time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "reservation-expiry",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := ExpireReservations(ctx, db, DefaultExpiryBatchSize)
+			return err
+		},
+	}
+}
@@ -0,0 +1,179 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// errDeadlock is what lockDB returns where Postgres would report a deadlock
+var errDeadlock = errors.New("deadlock detected")
+
+// lockDB is an in-memory products table with Postgres-like row locks for the
+// reservation statements: an UPDATE locks its row until the transaction
+// ends, and a wait that would close a cycle of transactions fails with
+// errDeadlock instead of blocking forever. Locks are held across a short
+// pause so that concurrent batches really interleave.
+type lockDB struct {
+	mu       sync.Mutex
+	released *sync.Cond
+	stock    map[uuid.UUID]int
+	reserved map[uuid.UUID]int
+	owner    map[uuid.UUID]*lockConn
+	waiting  map[*lockConn]uuid.UUID
+}
+
+// newLockDB opens a database over the given stock levels
+func newLockDB(stock map[uuid.UUID]int) (*sql.DB, *lockDB) {
+	l := &lockDB{
+		stock:    stock,
+		reserved: make(map[uuid.UUID]int),
+		owner:    make(map[uuid.UUID]*lockConn),
+		waiting:  make(map[*lockConn]uuid.UUID),
+	}
+	l.released = sync.NewCond(&l.mu)
+	return sql.OpenDB(l), l
+}
+
+// Reserved returns the committed reserved quantity of a product
+func (l *lockDB) Reserved(id uuid.UUID) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.reserved[id]
+}
+
+func (l *lockDB) Connect(context.Context) (driver.Conn, error) { return &lockConn{db: l}, nil }
+func (l *lockDB) Driver() driver.Driver                        { return nil }
+
+// lockConn is one connection; it runs at most one transaction at a time
+type lockConn struct {
+	db   *lockDB
+	held []uuid.UUID
+	undo map[uuid.UUID]int
+}
+
+func (c *lockConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *lockConn) Close() error                        { return nil }
+func (c *lockConn) Begin() (driver.Tx, error) {
+	c.undo = make(map[uuid.UUID]int)
+	return c, nil
+}
+
+func (c *lockConn) Commit() error {
+	c.end(false)
+	return nil
+}
+
+func (c *lockConn) Rollback() error {
+	c.end(true)
+	return nil
+}
+
+// end releases the transaction's locks, undoing its writes on a rollback
+func (c *lockConn) end(rollback bool) {
+	l := c.db
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rollback {
+		for id, qty := range c.undo {
+			l.reserved[id] -= qty
+		}
+	}
+	for _, id := range c.held {
+		delete(l.owner, id)
+	}
+	c.held, c.undo = nil, nil
+	l.released.Broadcast()
+}
+
+// lock takes the row lock on id, waiting for its holder unless that holder
+// is itself waiting, directly or not, for a lock c holds
+func (c *lockConn) lock(id uuid.UUID) error {
+	l := c.db
+	for {
+		holder := l.owner[id]
+		if holder == nil || holder == c {
+			break
+		}
+		for h := holder; h != nil; {
+			next, ok := l.waiting[h]
+			if !ok {
+				break
+			}
+			if h = l.owner[next]; h == c {
+				return errDeadlock
+			}
+		}
+		l.waiting[c] = id
+		l.released.Wait()
+		delete(l.waiting, c)
+	}
+	if l.owner[id] == nil {
+		l.owner[id] = c
+		c.held = append(c.held, id)
+	}
+	return nil
+}
+
+func (c *lockConn) ExecContext(_ context.Context, q string, args []driver.NamedValue) (driver.Result, error) {
+	if !strings.Contains(q, "SET reserved = reserved + $2") {
+		return driver.RowsAffected(1), nil
+	}
+	id, err := uuid.Parse(args[0].Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	qty := int(args[1].Value.(int64))
+
+	l := c.db
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := c.lock(id); err != nil {
+		return nil, err
+	}
+	// Hold the lock briefly so other batches reach their next row meanwhile
+	l.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	l.mu.Lock()
+
+	if l.stock[id]-l.reserved[id] < qty {
+		return driver.RowsAffected(0), nil
+	}
+	l.reserved[id] += qty
+	c.undo[id] += qty
+	return driver.RowsAffected(1), nil
+}
+
+func (c *lockConn) QueryContext(_ context.Context, q string, args []driver.NamedValue) (driver.Rows, error) {
+	id, err := uuid.Parse(args[0].Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	l := c.db
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &valueRows{value: int64(l.stock[id] - l.reserved[id])}, nil
+}
+
+// valueRows is a result of one row with one column
+type valueRows struct {
+	value int64
+	done  bool
+}
+
+func (r *valueRows) Columns() []string { return []string{"available"} }
+func (r *valueRows) Close() error      { return nil }
+func (r *valueRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = r.value, true
+	return nil
+}
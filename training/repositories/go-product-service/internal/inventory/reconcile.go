@@ -0,0 +1,114 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// UnknownSKUError identifies a counted SKU that matches no live product
+type UnknownSKUError struct {
+	Index int    `json:"index"`
+	SKU   string `json:"sku"`
+}
+
+func (e *UnknownSKUError) Error() string {
+	return fmt.Sprintf("count %d: no product with SKU %q", e.Index, e.SKU)
+}
+
+// resolveSKUs finds the live products with the given SKUs
+const resolveSKUs = `SELECT id, sku FROM products WHERE sku = ANY($1) AND deleted_at IS NULL`
+
+// lockForCount locks a product for reconciliation, provided it still carries
+// the SKU it was resolved by
+const lockForCount = `SELECT stock FROM products WHERE id = $1 AND sku = $2 AND deleted_at IS NULL FOR UPDATE`
+
+// setStock overwrites a product's stock with the counted quantity
+const setStock = `UPDATE products SET stock = $2, updated_at = NOW() WHERE id = $1`
+
+// Reconcile sets each counted product's stock to its counted quantity and
+// records the variance as a reconciliation movement, all in one
+// transaction: an unknown SKU rolls back the whole batch. SKUs are resolved
+// to product IDs first and rows are then locked in LockOrder, the same order
+// ReserveBatch uses, so overlapping stock updates cannot deadlock; lines
+// follow the request.
+func Reconcile(ctx context.Context, db *sql.DB, req *models.ReconcileStockRequest) (*models.ReconcileResult, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	skus := make([]string, len(req.Counts))
+	for i, count := range req.Counts {
+		skus[i] = count.SKU
+	}
+	rows, err := tx.QueryContext(ctx, resolveSKUs, pq.Array(skus))
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uuid.UUID, len(skus))
+	for rows.Next() {
+		var id uuid.UUID
+		var sku string
+		if err := rows.Scan(&id, &sku); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids[sku] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.StockLineItem, len(req.Counts))
+	for i, count := range req.Counts {
+		id, ok := ids[count.SKU]
+		if !ok {
+			return nil, &UnknownSKUError{Index: i, SKU: count.SKU}
+		}
+		items[i] = models.StockLineItem{ProductID: id, Qty: count.CountedQty}
+	}
+
+	result := &models.ReconcileResult{Lines: make([]models.ReconcileLine, len(req.Counts))}
+	for _, i := range LockOrder(items) {
+		count := req.Counts[i]
+		line := models.ReconcileLine{ProductID: items[i].ProductID, SKU: count.SKU, Counted: count.CountedQty}
+		err := tx.QueryRowContext(ctx, lockForCount, line.ProductID, count.SKU).Scan(&line.Previous)
+		if err == sql.ErrNoRows {
+			return nil, &UnknownSKUError{Index: i, SKU: count.SKU}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line.Variance = line.Counted - line.Previous
+		if line.Variance != 0 {
+			if _, err := tx.ExecContext(ctx, setStock, line.ProductID, line.Counted); err != nil {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, query.InsertStockMovement, uuid.New(), line.ProductID, line.Variance, models.MovementReconciliation); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Lines[i] = line
+		result.TotalVariance += line.Variance
+		if line.Variance < 0 {
+			result.AbsoluteVariance -= line.Variance
+		} else {
+			result.AbsoluteVariance += line.Variance
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+)
+
+func TestReconcileSetsStockAndRecordsVariance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	low, high := orderedIDs()
+	mock.ExpectBegin()
+	// "A-1" sorts first by SKU but belongs to the higher ID, so it is locked second
+	mock.ExpectQuery("SELECT id, sku FROM products").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(high, "A-1").AddRow(low, "B-1"))
+	mock.ExpectQuery("SELECT stock FROM products").WithArgs(low, "B-1").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+	mock.ExpectExec("UPDATE products SET stock").WithArgs(low, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs(sqlmock.AnyArg(), low, -3, models.MovementReconciliation).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT stock FROM products").WithArgs(high, "A-1").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(4))
+	mock.ExpectExec("UPDATE products SET stock").WithArgs(high, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs(sqlmock.AnyArg(), high, 1, models.MovementReconciliation).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := Reconcile(context.Background(), db, &models.ReconcileStockRequest{
+		Counts: []models.StockCount{{SKU: "A-1", CountedQty: 5}, {SKU: "B-1", CountedQty: 7}},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if result.TotalVariance != -2 || result.AbsoluteVariance != 4 {
+		t.Fatalf("unexpected totals: %+v", result)
+	}
+	if result.Lines[0].SKU != "A-1" || result.Lines[0].Variance != 1 || result.Lines[1].Previous != 10 {
+		t.Fatalf("lines should follow the request: %+v", result.Lines)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReconcileUnknownSKURollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	low, _ := orderedIDs()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, sku FROM products").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "sku"}).AddRow(low, "B-1"))
+	mock.ExpectRollback()
+
+	_, err = Reconcile(context.Background(), db, &models.ReconcileStockRequest{
+		Counts: []models.StockCount{{SKU: "B-1", CountedQty: 1}, {SKU: "GONE", CountedQty: 2}},
+	})
+	var unknown *UnknownSKUError
+	if !errors.As(err, &unknown) || unknown.Index != 1 || unknown.SKU != "GONE" {
+		t.Fatalf("expected UnknownSKUError for GONE, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/google/uuid"
+)
+
+// DefaultReservationTTL is used when a reservation request doesn't set one
+const DefaultReservationTTL = 15 * time.Minute
+
+// InsufficientStockError identifies the line item that could not be reserved
+type InsufficientStockError struct {
+	Index     int       `json:"index"`
+	ProductID uuid.UUID `json:"product_id"`
+	Requested int       `json:"requested"`
+	Available int       `json:"available"`
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("item %d: product %s has %d available, %d requested", e.Index, e.ProductID, e.Available, e.Requested)
+}
+
+// reserveStock holds qty of an active product if enough is unreserved
+const reserveStock = `UPDATE products
+SET reserved = reserved + $2, updated_at = NOW()
+WHERE id = $1 AND is_active AND deleted_at IS NULL AND stock - reserved >= $2`
+
+// availableStock returns a product's unreserved stock
+const availableStock = `SELECT GREATEST(stock - reserved, 0) FROM products WHERE id = $1 AND is_active AND deleted_at IS NULL`
+
+// selectReserved reads a product back after its reservation, under the row
+// lock the reservation took
+const selectReserved = "SELECT " + query.ProductColumns + " FROM products WHERE id = $1"
+
+// insertReservation records a reservation
+const insertReservation = `INSERT INTO stock_reservations (id, product_id, qty, reference, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)`
+
+// RecordFunc records a write of a product, from before to after, in the
+// transaction that made it
+type RecordFunc func(tx *sql.Tx, before, after *models.Product) error
+
+// LockOrder returns the indexes of items sorted by product ID. Multi-row stock
+// updates must touch rows in this order so that concurrent transactions over
+// overlapping products always acquire row locks in the same sequence and
+// cannot deadlock.
+func LockOrder(items []models.StockLineItem) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return bytes.Compare(items[order[a]].ProductID[:], items[order[b]].ProductID[:]) < 0
+	})
+	return order
+}
+
+// ReserveBatch reserves every line item in one transaction: either all items
+// are reserved, or none are and an *InsufficientStockError names an item that
+// lacked stock. Repeated product IDs are coalesced into one reservation for
+// their combined quantity, reported at the index of their first occurrence.
+// Rows are locked in LockOrder; results follow the order of first occurrence.
+// A non-nil record is passed every reserved product, its state before the
+// reservation differing only in the reserved quantity.
+func ReserveBatch(ctx context.Context, db *sql.DB, req *models.ReserveBatchRequest, record RecordFunc) ([]models.Reservation, error) {
+	items, first, _ := models.CoalesceLineItems(req.Items)
+
+	ttl := DefaultReservationTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	now := time.Now().UTC()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	reservations := make([]models.Reservation, len(items))
+	for _, i := range LockOrder(items) {
+		item := items[i]
+		res, err := tx.ExecContext(ctx, reserveStock, item.ProductID, item.Qty)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			var available int
+			if err := tx.QueryRowContext(ctx, availableStock, item.ProductID).Scan(&available); err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+			return nil, &InsufficientStockError{Index: first[i], ProductID: item.ProductID, Requested: item.Qty, Available: available}
+		}
+		if record != nil {
+			after, err := query.ScanProduct(tx.QueryRowContext(ctx, selectReserved, item.ProductID))
+			if err != nil {
+				return nil, err
+			}
+			before := *after
+			before.Reserved -= item.Qty
+			if err := record(tx, &before, after); err != nil {
+				return nil, err
+			}
+		}
+
+		r := models.Reservation{
+			ID:        uuid.New(),
+			ProductID: item.ProductID,
+			Qty:       item.Qty,
+			Reference: req.Reference,
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		}
+		if _, err := tx.ExecContext(ctx, insertReservation, r.ID, r.ProductID, r.Qty, r.Reference, r.ExpiresAt, r.CreatedAt); err != nil {
+			return nil, err
+		}
+		reservations[i] = r
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
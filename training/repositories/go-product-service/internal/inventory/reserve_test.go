@@ -0,0 +1,209 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// orderedIDs returns two IDs where low sorts before high in LockOrder
+func orderedIDs() (low, high uuid.UUID) {
+	low = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	high = uuid.MustParse("ffffffff-0000-0000-0000-000000000001")
+	return low, high
+}
+
+func TestLockOrderSortsByProductID(t *testing.T) {
+	low, high := orderedIDs()
+	items := []models.StockLineItem{{ProductID: high, Qty: 1}, {ProductID: low, Qty: 1}, {ProductID: high, Qty: 2}}
+
+	order := LockOrder(items)
+	want := []int{1, 0, 2}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestReserveBatchLocksInIDOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	low, high := orderedIDs()
+	reserve := regexp.QuoteMeta("UPDATE products\nSET reserved = reserved + $2")
+	mock.ExpectBegin()
+	mock.ExpectExec(reserve).WithArgs(low, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(reserve).WithArgs(high, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	reservations, err := ReserveBatch(context.Background(), db, &models.ReserveBatchRequest{
+		Items: []models.StockLineItem{{ProductID: high, Qty: 1}, {ProductID: low, Qty: 2}, {ProductID: high, Qty: 2}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("ReserveBatch: %v", err)
+	}
+	// Results follow first occurrence, not lock order
+	if len(reservations) != 2 || reservations[0].ProductID != high || reservations[0].Qty != 3 || reservations[1].ProductID != low {
+		t.Fatalf("unexpected reservations: %+v", reservations)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReserveBatchRollsBackOnInsufficientStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	low, high := orderedIDs()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products").WithArgs(low, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_reservations").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE products").WithArgs(high, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GREATEST(stock - reserved, 0)")).WithArgs(high).
+		WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(4))
+	mock.ExpectRollback()
+
+	_, err = ReserveBatch(context.Background(), db, &models.ReserveBatchRequest{
+		Items: []models.StockLineItem{{ProductID: high, Qty: 5}, {ProductID: low, Qty: 1}},
+	}, nil)
+	var stockErr *InsufficientStockError
+	if !errors.As(err, &stockErr) {
+		t.Fatalf("expected InsufficientStockError, got %v", err)
+	}
+	if stockErr.Index != 0 || stockErr.ProductID != high || stockErr.Available != 4 || stockErr.Requested != 5 {
+		t.Fatalf("unexpected error detail: %+v", stockErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpireReservationsReleasesReservedStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	low, high := orderedIDs()
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM stock_reservations").WithArgs(sqlmock.AnyArg(), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "qty"}).
+			AddRow(high, 2).AddRow(low, 1).AddRow(high, 4))
+	mock.ExpectExec(regexp.QuoteMeta("SET reserved = GREATEST(reserved - $2, 0)")).WithArgs(low, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("SET reserved = GREATEST(reserved - $2, 0)")).WithArgs(high, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM stock_reservations").WithArgs(sqlmock.AnyArg(), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "qty"}))
+	mock.ExpectRollback()
+
+	n, err := ExpireReservations(context.Background(), db, 3)
+	if err != nil {
+		t.Fatalf("ExpireReservations: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 released reservations, got %d", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentReserveBatchesNeverOversell(t *testing.T) {
+	low, high := orderedIDs()
+	db, state := newLockDB(map[uuid.UUID]int{low: 10, high: 10})
+	defer db.Close()
+
+	const batches = 30
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var reserved, shortfalls int
+	for i := 0; i < batches; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ReserveBatch(context.Background(), db, &models.ReserveBatchRequest{
+				Items: []models.StockLineItem{{ProductID: low, Qty: 1}, {ProductID: high, Qty: 1}},
+			}, nil)
+			var stockErr *InsufficientStockError
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				reserved++
+			case errors.As(err, &stockErr):
+				shortfalls++
+			default:
+				t.Errorf("ReserveBatch: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != 10 || shortfalls != batches-10 {
+		t.Fatalf("expected 10 batches reserved and %d short, got %d and %d", batches-10, reserved, shortfalls)
+	}
+	if state.Reserved(low) != 10 || state.Reserved(high) != 10 {
+		t.Fatalf("oversold: reserved %d and %d of 10 each", state.Reserved(low), state.Reserved(high))
+	}
+}
+
+func TestOpposingReserveBatchesDoNotDeadlock(t *testing.T) {
+	ids := make([]uuid.UUID, 4)
+	stock := make(map[uuid.UUID]int, len(ids))
+	for i := range ids {
+		ids[i] = uuid.New()
+		stock[ids[i]] = 1000
+	}
+	db, state := newLockDB(stock)
+	defer db.Close()
+
+	// Half the batches list the products forwards and half backwards, the
+	// orders that deadlock when rows are locked as listed
+	forwards := make([]models.StockLineItem, len(ids))
+	backwards := make([]models.StockLineItem, len(ids))
+	for i, id := range ids {
+		forwards[i] = models.StockLineItem{ProductID: id, Qty: 1}
+		backwards[len(ids)-1-i] = models.StockLineItem{ProductID: id, Qty: 1}
+	}
+
+	const batches = 40
+	var wg sync.WaitGroup
+	for i := 0; i < batches; i++ {
+		items := forwards
+		if i%2 == 1 {
+			items = backwards
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ReserveBatch(context.Background(), db, &models.ReserveBatchRequest{Items: items}, nil); err != nil {
+				t.Errorf("ReserveBatch: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if got := state.Reserved(id); got != batches {
+			t.Errorf("product %s: expected %d reserved, got %d", id, batches, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// ContentHashBackfillJob names the checkpoint of the content hash backfill
+const ContentHashBackfillJob = "content_hash_backfill"
+
+// ContentHashBackfill builds a resumable job that fills in content_hash for
+// rows written before it existed, batchSize rows at a time in id order
+func ContentHashBackfill(db *sql.DB, store CheckpointStore, batchSize int, interval time.Duration) Job {
+	if batchSize < 1 {
+		batchSize = 500
+	}
+	return Resumable(ContentHashBackfillJob, interval, store, func(ctx context.Context, cursor string) (string, bool, error) {
+		return backfillContentHashes(ctx, db, cursor, batchSize)
+	})
+}
+
+// backfillContentHashes hashes one batch of products after cursor and returns
+// the last id it handled
+func backfillContentHashes(ctx context.Context, db *sql.DB, cursor string, limit int) (string, bool, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, name, description, price, category, sku, stock
+		 FROM products
+		 WHERE content_hash = '' AND ($1 = '' OR id > $1::uuid)
+		 ORDER BY id
+		 LIMIT $2`,
+		cursor, limit,
+	)
+	if err != nil {
+		return cursor, false, err
+	}
+
+	var batch []models.Product
+	for rows.Next() {
+		var p models.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Category, &p.SKU, &p.Stock); err != nil {
+			rows.Close()
+			return cursor, false, err
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return cursor, false, err
+	}
+
+	for _, p := range batch {
+		if _, err := db.ExecContext(ctx,
+			`UPDATE products SET content_hash = $2 WHERE id = $1 AND content_hash = ''`,
+			p.ID, p.ComputeContentHash(),
+		); err != nil {
+			return cursor, false, err
+		}
+		cursor = p.ID.String()
+	}
+	return cursor, len(batch) < limit, nil
+}
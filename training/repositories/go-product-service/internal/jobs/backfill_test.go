@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestContentHashBackfillCheckpointsLastID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	first, second := uuid.New(), uuid.New()
+	cols := []string{"id", "name", "description", "price", "category", "sku", "stock"}
+	mock.ExpectQuery("SELECT id, name, description, price, category, sku, stock").
+		WithArgs("", 2).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow(first, "Mug", "", 9.5, "kitchen", "MUG-1", 3).
+			AddRow(second, "Cup", "", 4.0, "kitchen", "CUP-1", 0))
+
+	want := (&models.Product{Name: "Mug", Price: 9.5, Category: "kitchen", SKU: "MUG-1", Stock: 3}).ComputeContentHash()
+	mock.ExpectExec("UPDATE products SET content_hash").WithArgs(first, want).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE products SET content_hash").WithArgs(second, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	next, done, err := backfillContentHashes(context.Background(), db, "", 2)
+	if err != nil {
+		t.Fatalf("backfill: %v", err)
+	}
+	if done {
+		t.Fatal("a full batch should not report done")
+	}
+	if next != second.String() {
+		t.Fatalf("expected cursor %s, got %s", second, next)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContentHashBackfillDoneOnShortBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cursor := uuid.New().String()
+	mock.ExpectQuery("SELECT id, name").WithArgs(cursor, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "price", "category", "sku", "stock"}))
+
+	next, done, err := backfillContentHashes(context.Background(), db, cursor, 10)
+	if err != nil || !done || next != cursor {
+		t.Fatalf("expected done at %s, got %s done=%v err=%v", cursor, next, done, err)
+	}
+}
+
+// cancelOnSave cancels the run after its first checkpoint, as a shutdown
+// arriving between batches would
+type cancelOnSave struct {
+	CheckpointStore
+	cancel context.CancelFunc
+}
+
+func (s *cancelOnSave) Save(ctx context.Context, job, cursor string) error {
+	err := s.CheckpointStore.Save(ctx, job, cursor)
+	s.cancel()
+	return err
+}
+
+func TestContentHashBackfillResumesAfterCancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cols := []string{"id", "name", "description", "price", "category", "sku", "stock"}
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT id, name").WithArgs("", 1).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(first, "Mug", "", 9.5, "kitchen", "MUG-1", 3))
+	mock.ExpectExec("UPDATE products SET content_hash").WithArgs(first, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	store := NewMemoryCheckpointStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	job := ContentHashBackfill(db, &cancelOnSave{CheckpointStore: store, cancel: cancel}, 1, time.Minute)
+	if err := job.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to stop on cancellation, got %v", err)
+	}
+	if cursor, _ := store.Load(context.Background(), ContentHashBackfillJob); cursor != first.String() {
+		t.Fatalf("expected checkpoint %s, got %q", first, cursor)
+	}
+
+	// The next start picks up after the checkpointed row
+	mock.ExpectQuery("SELECT id, name").WithArgs(first.String(), 1).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(second, "Cup", "", 4.0, "kitchen", "CUP-1", 0))
+	mock.ExpectExec("UPDATE products SET content_hash").WithArgs(second, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT id, name").WithArgs(second.String(), 1).WillReturnRows(sqlmock.NewRows(cols))
+
+	if err := ContentHashBackfill(db, store, 1, time.Minute).Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if cursor, _ := store.Load(context.Background(), ContentHashBackfillJob); cursor != "" {
+		t.Fatalf("expected the checkpoint cleared once done, got %q", cursor)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
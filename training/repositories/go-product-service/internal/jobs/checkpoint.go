@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+// checkpointTimeout bounds how long saving a checkpoint may take once the job is cancelled
+const checkpointTimeout = 5 * time.Second
+
+// CheckpointStore persists the resume cursor of long-running jobs
+type CheckpointStore interface {
+	Load(ctx context.Context, job string) (string, error)
+	Save(ctx context.Context, job, cursor string) error
+}
+
+// BatchFunc processes one batch starting after cursor and returns the cursor
+// to resume from, and whether the job has no more work
+type BatchFunc func(ctx context.Context, cursor string) (next string, done bool, err error)
+
+// Resumable builds a job that processes batches until done or cancelled,
+// checkpointing its cursor after every batch so the next run resumes there
+func Resumable(name string, interval time.Duration, store CheckpointStore, batch BatchFunc) Job {
+	return Job{
+		Name:     name,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			cursor, err := store.Load(ctx, name)
+			if err != nil {
+				return err
+			}
+
+			for {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				next, done, err := batch(ctx, cursor)
+				if err != nil {
+					return err
+				}
+
+				// Persist progress even if shutdown cancelled ctx during the batch
+				saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkpointTimeout)
+				if done {
+					next = ""
+				}
+				err = store.Save(saveCtx, name, next)
+				cancel()
+				if err != nil {
+					return err
+				}
+
+				if done {
+					return nil
+				}
+				cursor = next
+			}
+		},
+	}
+}
+
+// SQLCheckpointStore keeps checkpoints in the job_checkpoints table
+type SQLCheckpointStore struct {
+	db *sql.DB
+}
+
+// NewSQLCheckpointStore creates a checkpoint store backed by db
+func NewSQLCheckpointStore(db *sql.DB) *SQLCheckpointStore {
+	return &SQLCheckpointStore{db: db}
+}
+
+// Load returns the saved cursor for job, or an empty string if none exists
+func (s *SQLCheckpointStore) Load(ctx context.Context, job string) (string, error) {
+	var cursor string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT cursor FROM job_checkpoints WHERE job_name = $1`, job,
+	).Scan(&cursor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// Save stores the cursor for job
+func (s *SQLCheckpointStore) Save(ctx context.Context, job, cursor string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO job_checkpoints (job_name, cursor, updated_at)
+		 VALUES ($1, $2, NOW())
+		 ON CONFLICT (job_name) DO UPDATE SET cursor = EXCLUDED.cursor, updated_at = NOW()`,
+		job, cursor,
+	)
+	return err
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory for single-instance use
+type MemoryCheckpointStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{cursors: make(map[string]string)}
+}
+
+// Load returns the saved cursor for job
+func (s *MemoryCheckpointStore) Load(_ context.Context, job string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[job], nil
+}
+
+// Save stores the cursor for job
+func (s *MemoryCheckpointStore) Save(_ context.Context, job, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[job] = cursor
+	return nil
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestResumableResumesFromCheckpointAfterCancel(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var seen []string
+	batch := func(ctx context.Context, cursor string) (string, bool, error) {
+		seen = append(seen, cursor)
+		n, _ := strconv.Atoi(cursor)
+		n++
+		// Shutdown arrives while the third batch is in flight
+		if n == 3 {
+			cancel()
+		}
+		return strconv.Itoa(n), n == 5, nil
+	}
+
+	job := Resumable("backfill", 1, store, batch)
+	if err := job.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation, got %v", err)
+	}
+	if cursor, _ := store.Load(context.Background(), "backfill"); cursor != "3" {
+		t.Fatalf("expected checkpoint 3 after cancel, got %q", cursor)
+	}
+
+	seen = nil
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "3" || seen[1] != "4" {
+		t.Fatalf("expected resume from 3, got batches %v", seen)
+	}
+	if cursor, _ := store.Load(context.Background(), "backfill"); cursor != "" {
+		t.Fatalf("expected checkpoint cleared once done, got %q", cursor)
+	}
+}
+
+func TestResumableDoesNotCheckpointFailedBatch(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	_ = store.Save(context.Background(), "backfill", "7")
+
+	boom := errors.New("boom")
+	job := Resumable("backfill", 1, store, func(ctx context.Context, cursor string) (string, bool, error) {
+		return "8", false, boom
+	})
+	if err := job.Run(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+	if cursor, _ := store.Load(context.Background(), "backfill"); cursor != "7" {
+		t.Fatalf("expected checkpoint to stay at 7, got %q", cursor)
+	}
+}
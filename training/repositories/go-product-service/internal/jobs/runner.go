@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRunnerStarted is returned when scheduling a job on a runner that is already running
+var ErrRunnerStarted = errors.New("job runner already started")
+
+// ErrInvalidInterval is returned when scheduling a job without a positive interval
+var ErrInvalidInterval = errors.New("job interval must be positive")
+
+// Job is a unit of background work run periodically by a Runner
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Metrics holds per-job execution statistics
+type Metrics struct {
+	Name         string        `json:"name"`
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Skipped      int64         `json:"skipped"`
+	LastRunAt    time.Time     `json:"last_run_at"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+}
+
+type scheduledJob struct {
+	job     Job
+	pending bool
+	metrics Metrics
+}
+
+// Runner executes scheduled jobs on a shared pool of workers
+type Runner struct {
+	workers int
+	queue   chan *scheduledJob
+
+	mu      sync.Mutex
+	jobs    []*scheduledJob
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewRunner creates a runner with the given number of workers
+func NewRunner(workers int) *Runner {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Runner{
+		workers: workers,
+		queue:   make(chan *scheduledJob),
+	}
+}
+
+// Schedule registers a job; it must be called before Start
+func (r *Runner) Schedule(job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started {
+		return ErrRunnerStarted
+	}
+	if job.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	r.jobs = append(r.jobs, &scheduledJob{job: job, metrics: Metrics{Name: job.Name}})
+	return nil
+}
+
+// Start launches the workers and a ticker per scheduled job
+func (r *Runner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started {
+		return
+	}
+	r.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	for i := 0; i < r.workers; i++ {
+		r.wg.Add(1)
+		go r.work(ctx)
+	}
+	for _, sj := range r.jobs {
+		r.wg.Add(1)
+		go r.tick(ctx, sj)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return or for ctx to expire
+func (r *Runner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Metrics returns a snapshot of every job's statistics
+func (r *Runner) Metrics() []Metrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Metrics, len(r.jobs))
+	for i, sj := range r.jobs {
+		out[i] = sj.metrics
+	}
+	return out
+}
+
+// tick enqueues a job every interval, skipping ticks while a previous run is pending
+func (r *Runner) tick(ctx context.Context, sj *scheduledJob) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(sj.job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		if sj.pending {
+			sj.metrics.Skipped++
+			r.mu.Unlock()
+			continue
+		}
+		sj.pending = true
+		r.mu.Unlock()
+
+		select {
+		case r.queue <- sj:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// work runs queued jobs until the runner is stopped
+func (r *Runner) work(ctx context.Context) {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sj := <-r.queue:
+			r.run(ctx, sj)
+		}
+	}
+}
+
+// run executes a single job and records its metrics
+func (r *Runner) run(ctx context.Context, sj *scheduledJob) {
+	start := time.Now()
+	err := sj.job.Run(ctx)
+	duration := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sj.pending = false
+	sj.metrics.Runs++
+	sj.metrics.LastRunAt = start
+	sj.metrics.LastDuration = duration
+	sj.metrics.LastError = ""
+	if err != nil {
+		sj.metrics.Failures++
+		sj.metrics.LastError = err.Error()
+	}
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestRunnerStopsJobsWithoutLeaking(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var runs atomic.Int64
+	started := make(chan struct{}, 1)
+
+	r := NewRunner(2)
+	err := r.Schedule(Job{
+		Name:     "blocking",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	r.Start()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("job never ran")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if runs.Load() == 0 {
+		t.Fatal("expected at least one run")
+	}
+
+	m := r.Metrics()
+	if len(m) != 1 || m[0].Runs == 0 || m[0].Failures == 0 {
+		t.Fatalf("unexpected metrics after cancelled run: %+v", m)
+	}
+}
+
+func TestRunnerStopTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	r := NewRunner(1)
+	_ = r.Schedule(Job{
+		Name:     "stubborn",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		},
+	})
+	r.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	close(release)
+}
+
+func TestScheduleRejectsInvalidJobs(t *testing.T) {
+	r := NewRunner(1)
+	if err := r.Schedule(Job{Name: "zero"}); !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+	if err := r.Schedule(Job{Name: "negative", Interval: -time.Second}); !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+
+	r.Start()
+	defer r.Stop(context.Background())
+	if err := r.Schedule(Job{Name: "late", Interval: time.Second}); !errors.Is(err, ErrRunnerStarted) {
+		t.Fatalf("expected ErrRunnerStarted, got %v", err)
+	}
+}
+
+func TestNewRunnerClampsWorkers(t *testing.T) {
+	if r := NewRunner(0); r.workers != 1 {
+		t.Fatalf("expected 1 worker, got %d", r.workers)
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// Identity headers set by the API gateway once it has verified the client's
+// token; the gateway strips them from incoming requests, so they can be trusted
+const (
+	ClientIDHeader = "X-Client-ID"
+	ScopesHeader   = "X-Scopes"
+)
+
+// Authenticate stores the client identity and comma-separated scopes asserted
+// by the gateway in the request context. Requests without a client ID stay
+// anonymous.
+func Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client := strings.TrimSpace(c.GetHeader(ClientIDHeader))
+		if client == "" {
+			c.Next()
+			return
+		}
+
+		scopes := []string{}
+		for _, s := range strings.Split(c.GetHeader(ScopesHeader), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				scopes = append(scopes, s)
+			}
+		}
+		ctx := auth.WithCaller(c.Request.Context(), client)
+		c.Request = c.Request.WithContext(auth.WithScopes(ctx, scopes))
+		c.Next()
+	}
+}
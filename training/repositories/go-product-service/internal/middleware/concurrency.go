@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConcurrencyLimit allows at most limit requests through at once; up to
+// queue further requests wait for a free slot for as long as their context
+// allows, and anything beyond that is rejected with 429 Too Many Requests.
+// It is meant for DB-heavy routes such as exports, so they cannot take
+// every pooled connection from transactional traffic.
+func ConcurrencyLimit(limit, queue int) gin.HandlerFunc {
+	slots := make(chan struct{}, limit)
+	var waiting atomic.Int64
+
+	reject := func(c *gin.Context) {
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many concurrent requests, try again later"})
+	}
+
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			if waiting.Add(1) > int64(queue) {
+				waiting.Add(-1)
+				reject(c)
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+				waiting.Add(-1)
+			case <-c.Request.Context().Done():
+				waiting.Add(-1)
+				reject(c)
+				return
+			}
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
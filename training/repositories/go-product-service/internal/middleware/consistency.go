@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/gin-gonic/gin"
+)
+
+// ConsistencyHeader lets a client ask for reads from the primary
+const ConsistencyHeader = "X-Consistency"
+
+// ReadConsistency routes a request's reads to the primary when the client
+// sends "X-Consistency: strong" or the request is not a GET or HEAD, so a
+// write handler that reads back its own result never sees replica lag.
+// Plain GETs default to replica reads.
+func ReadConsistency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		strong := strings.EqualFold(c.GetHeader(ConsistencyHeader), dbroute.ConsistencyStrong) ||
+			(method != http.MethodGet && method != http.MethodHead)
+		if strong {
+			c.Request = c.Request.WithContext(dbroute.WithStrongConsistency(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
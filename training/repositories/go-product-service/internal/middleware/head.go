@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// headWriter discards the body while counting its length, and holds the
+// status back until the handler finishes so Content-Length can still be set
+type headWriter struct {
+	gin.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *headWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *headWriter) WriteHeaderNow() {}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	w.size += len(b)
+	return len(b), nil
+}
+
+func (w *headWriter) WriteString(s string) (int, error) {
+	w.size += len(s)
+	return len(s), nil
+}
+
+func (w *headWriter) Status() int {
+	return w.status
+}
+
+func (w *headWriter) Size() int {
+	return w.size
+}
+
+func (w *headWriter) Written() bool {
+	return false
+}
+
+// HeadResponse lets a GET handler serve HEAD: the handler runs unchanged
+// and its headers are sent, including the Content-Length its body would
+// have had, but the body itself is dropped
+func HeadResponse() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &headWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.size > 0 && w.Header().Get("Content-Length") == "" {
+			w.Header().Set("Content-Length", strconv.Itoa(w.size))
+		}
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/idempotency"
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key of a retryable write
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// pendingTTL bounds how long a request that never finishes, because its
+// instance died, holds its key; it outlasts the longest request timeout
+const pendingTTL = 15 * time.Minute
+
+// recordingWriter keeps a copy of the body written through it
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response of a POST retried with the same
+// Idempotency-Key, so a client can safely resend a create whose response it
+// lost. Responses are kept in store for ttl; reusing a key for a different
+// request is rejected with 422. The key is claimed with a pending record
+// before the request runs, so a duplicate arriving while it is still running
+// is rejected with 409 instead of running twice. Keys are scoped to the
+// caller, so it must run after Authenticate, and server errors are not
+// stored so they can be retried.
+func Idempotency(store idempotency.Store, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		// Keys are chosen by clients, so two clients may pick the same one
+		key = auth.Caller(ctx) + ":" + key
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(append([]byte(c.Request.Method+" "+c.Request.URL.RequestURI()+"\n"), body...))
+		hash := hex.EncodeToString(sum[:])
+
+		err = store.Put(ctx, key, &idempotency.Record{RequestHash: hash}, pendingTTL)
+		if errors.Is(err, idempotency.ErrKeyExists) {
+			replayIdempotent(c, store, key, hash)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		// Releasing the key lets the client retry a server error
+		if w.Status() >= http.StatusInternalServerError {
+			err = store.Delete(ctx, key)
+		} else {
+			err = store.Complete(ctx, key, &idempotency.Record{RequestHash: hash, StatusCode: w.Status(), Body: w.body.Bytes()}, ttl)
+		}
+		if err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+// replayIdempotent answers a request whose key is already claimed: with the
+// stored response of the same request, 409 while that request is still
+// running, or 422 when the key belongs to a different request
+func replayIdempotent(c *gin.Context, store idempotency.Store, key, hash string) {
+	rec, err := store.Get(c.Request.Context(), key)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+	switch {
+	case rec != nil && rec.RequestHash != hash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "idempotency key was used for a different request"})
+	case rec == nil || rec.Pending():
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "a request with this idempotency key is still in progress"})
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(rec.StatusCode, "application/json; charset=utf-8", rec.Body)
+		c.Abort()
+	}
+}
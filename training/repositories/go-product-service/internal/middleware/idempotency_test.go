@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/idempotency"
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyRejectsDuplicateWhileFirstRuns(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Idempotency(idempotency.NewMemoryStore(), time.Hour))
+	started, release := make(chan struct{}), make(chan struct{})
+	runs := 0
+	r.POST("/orders", func(c *gin.Context) {
+		runs++
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"qty":1}`))
+		req.Header.Set(IdempotencyKeyHeader, "order-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- post() }()
+	<-started
+	if w := post(); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a duplicate of a running request, got %d: %s", w.Code, w.Body)
+	}
+	close(release)
+	if w := <-first; w.Code != http.StatusCreated {
+		t.Fatalf("expected the first request to finish with 201, got %d: %s", w.Code, w.Body)
+	}
+
+	if w := post(); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected the finished response replayed, got %d: %s", w.Code, w.Body)
+	}
+	if runs != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", runs)
+	}
+}
+
+func TestIdempotencyReleasesKeyAfterServerError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Idempotency(idempotency.NewMemoryStore(), time.Hour))
+	status := http.StatusInternalServerError
+	r.POST("/orders", func(c *gin.Context) { c.Status(status) })
+	post := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req.Header.Set(IdempotencyKeyHeader, "order-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post(); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", code)
+	}
+	status = http.StatusCreated
+	if code := post(); code != http.StatusCreated {
+		t.Fatalf("expected the retry after a server error to run, got %d", code)
+	}
+}
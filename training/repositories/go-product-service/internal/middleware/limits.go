@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limits bounds the request body size and handling time of a route;
+// zero values fall back to the defaults
+type Limits struct {
+	MaxBodyBytes int64
+	Timeout      time.Duration
+}
+
+// RouteKey identifies a route by method and gin path pattern, e.g. "POST /api/v1/products/import"
+func RouteKey(method, path string) string {
+	return method + " " + path
+}
+
+// RequestLimits applies the default limits to every route, or the override
+// registered for the matched route when one exists
+func RequestLimits(defaults Limits, overrides map[string]Limits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limits := defaults
+		if o, ok := overrides[RouteKey(c.Request.Method, c.FullPath())]; ok {
+			if o.MaxBodyBytes > 0 {
+				limits.MaxBodyBytes = o.MaxBodyBytes
+			}
+			if o.Timeout > 0 {
+				limits.Timeout = o.Timeout
+			}
+		}
+
+		if limits.MaxBodyBytes > 0 && c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBodyBytes)
+		}
+
+		if limits.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), limits.Timeout)
+			defer cancel()
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
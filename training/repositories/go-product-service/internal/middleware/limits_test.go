@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestLimitsTimeoutOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestLimits(Limits{Timeout: time.Second}, map[string]Limits{
+		RouteKey(http.MethodGet, "/slow/:id"): {Timeout: time.Hour},
+	}))
+	deadlines := map[string]time.Duration{}
+	record := func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		deadlines[c.Request.URL.Path] = time.Until(deadline)
+	}
+	r.GET("/fast", record)
+	r.GET("/slow/:id", record)
+
+	for _, path := range []string{"/fast", "/slow/1"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	if d := deadlines["/fast"]; d <= 0 || d > time.Second {
+		t.Fatalf("expected the default 1s timeout, got %s", d)
+	}
+	if d := deadlines["/slow/1"]; d <= time.Minute {
+		t.Fatalf("expected the overridden timeout, got %s", d)
+	}
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// MaskIdentifiers makes product responses omit SKUs and barcodes for
+// unauthenticated callers; it must run after the authentication middleware
+func MaskIdentifiers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(dto.WithIdentifierMasking(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// DisplayRounding makes product responses round prices to their currency's
+// minor units, so list and detail views render the same value
+func DisplayRounding(r dto.Rounding) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(dto.WithRounding(c.Request.Context(), r))
+		c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func securityRouter(s Security) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(SecurityHeaders(s))
+	r.Any("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return r
+}
+
+func TestSecurityHeadersOnForwardedHTTPS(t *testing.T) {
+	r := securityRouter(Security{HSTSMaxAge: 24 * time.Hour, ContentSecurityPolicy: "default-src 'none'", TrustForwardedProto: true})
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	for name, want := range map[string]string{
+		"Strict-Transport-Security": "max-age=86400; includeSubDomains",
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Content-Security-Policy":   "default-src 'none'",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestSecurityHeadersOmitHSTSOverPlainHTTP(t *testing.T) {
+	// Without TrustForwardedProto the header is a client's claim and ignored
+	r := securityRouter(Security{HSTSMaxAge: time.Hour})
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Fatalf("expected no HSTS over plain HTTP, got %q", got)
+	}
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Fatalf("expected nosniff, got %q", got)
+	}
+}
+
+func TestSecurityHeadersRedirectPlainHTTP(t *testing.T) {
+	r := securityRouter(Security{RedirectHTTP: true, TrustForwardedProto: true})
+	for method, want := range map[string]int{http.MethodGet: http.StatusMovedPermanently, http.MethodPost: http.StatusPermanentRedirect} {
+		req := httptest.NewRequest(method, "http://api.example.com/ping?x=1", nil)
+		req.Header.Set("X-Forwarded-Proto", "http")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != want || w.Header().Get("Location") != "https://api.example.com/ping?x=1" {
+			t.Errorf("%s: expected %d to https, got %d to %q", method, want, w.Code, w.Header().Get("Location"))
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected HTTPS requests served, got %d", w.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions
+const (
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionRenameCategory = "rename_category"
+	AuditActionDeleteCategory = "delete_category"
+	AuditActionSwapSKU        = "swap_sku"
+	AuditActionDeleteTag      = "delete_tag"
+)
+
+// Audited entity types
+const (
+	AuditEntityProduct  = "product"
+	AuditEntityCategory = "category"
+	AuditEntityTag      = "tag"
+)
+
+// AuditEntry represents a single recorded change
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	Actor      string          `json:"actor" db:"actor"`
+	Action     string          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   string          `json:"entity_id" db:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"`
+	After      json.RawMessage `json:"after,omitempty" db:"after"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditExportFilter represents the date range and format of an audit log export
+type AuditExportFilter struct {
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" validate:"required"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" validate:"required,gtfield=From"`
+	Format string    `form:"format,default=csv" validate:"oneof=csv jsonl"`
+}
+
+// UpdateFrequencyFilter represents the window and change-count range of the
+// update frequency report; an unset Max has no upper bound, and Min=0 with
+// Max=0 lists products that never changed in the window
+type UpdateFrequencyFilter struct {
+	Since  time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00" validate:"required"`
+	Min    int       `form:"min,default=0" validate:"gte=0"`
+	Max    *int      `form:"max" validate:"omitempty,gtefield=Min"`
+	Limit  int       `form:"limit,default=50" validate:"min=1,max=500"`
+	Offset int       `form:"offset,default=0" validate:"gte=0"`
+}
+
+// ProductUpdateFrequency represents a product with its number of audited
+// updates since the start of the window
+type ProductUpdateFrequency struct {
+	Product
+	UpdateCount int64 `json:"update_count" db:"update_count"`
+}
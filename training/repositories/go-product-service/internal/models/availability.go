@@ -0,0 +1,46 @@
+package models
+
+import "github.com/google/uuid"
+
+// StockLineItem represents a requested quantity of a single product
+type StockLineItem struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Qty       int       `json:"qty" validate:"required,gt=0"`
+}
+
+// CheckAvailabilityRequest represents the request payload for a bulk availability check
+type CheckAvailabilityRequest struct {
+	Items []StockLineItem `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// AvailabilityResult reports whether a line item can be fulfilled
+type AvailabilityResult struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	Qty          int       `json:"qty"`
+	Available    bool      `json:"available"`
+	AvailableQty int       `json:"available_qty"`
+}
+
+// AvailableQty returns the stock that can still be sold, net of active reservations
+func (p *Product) AvailableQty() int {
+	available := p.Stock - p.Reserved
+	if !p.IsActive || available < 0 {
+		return 0
+	}
+	return available
+}
+
+// CheckAvailability evaluates each line item against the given products;
+// items for unknown products are reported as unavailable
+func CheckAvailability(products map[uuid.UUID]*Product, items []StockLineItem) []AvailabilityResult {
+	results := make([]AvailabilityResult, len(items))
+	for i, item := range items {
+		result := AvailabilityResult{ProductID: item.ProductID, Qty: item.Qty}
+		if p, ok := products[item.ProductID]; ok {
+			result.AvailableQty = p.AvailableQty()
+			result.Available = result.AvailableQty >= item.Qty
+		}
+		results[i] = result
+	}
+	return results
+}
@@ -0,0 +1,57 @@
+package models
+
+import "strings"
+
+// BarcodeLookupRequest represents the request payload for fetching products by barcode
+type BarcodeLookupRequest struct {
+	Barcodes []string `json:"barcodes" validate:"required,min=1,max=500,dive,required,max=50"`
+}
+
+// BarcodeLookupResult reports the products matching a barcode lookup
+type BarcodeLookupResult struct {
+	Products  []Product `json:"products"`
+	Unmatched []string  `json:"unmatched"`
+}
+
+// NormalizeBarcode strips surrounding whitespace and leading zeros so that
+// EAN-13, UPC-A and zero-padded scans of the same code compare equal
+func NormalizeBarcode(barcode string) string {
+	trimmed := strings.TrimLeft(strings.TrimSpace(barcode), "0")
+	if trimmed == "" && barcode != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// NormalizeBarcodes normalizes and de-duplicates barcodes, preserving order
+func NormalizeBarcodes(barcodes []string) []string {
+	seen := make(map[string]bool, len(barcodes))
+	out := make([]string, 0, len(barcodes))
+	for _, b := range barcodes {
+		n := NormalizeBarcode(b)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return out
+}
+
+// UnmatchedBarcodes returns the requested barcodes that no product carries
+func UnmatchedBarcodes(requested []string, products []Product) []string {
+	found := make(map[string]bool, len(products))
+	for _, p := range products {
+		if p.Barcode != nil {
+			found[NormalizeBarcode(*p.Barcode)] = true
+		}
+	}
+
+	unmatched := []string{}
+	for _, b := range requested {
+		if !found[NormalizeBarcode(b)] {
+			unmatched = append(unmatched, b)
+		}
+	}
+	return unmatched
+}
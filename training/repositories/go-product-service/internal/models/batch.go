@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateIDError reports the IDs repeated in a batch when the service is
+// configured to reject duplicates instead of coalescing them
+type DuplicateIDError struct {
+	IDs []uuid.UUID
+}
+
+func (e *DuplicateIDError) Error() string {
+	return fmt.Sprintf("batch contains %d duplicate id(s), first %s", len(e.IDs), e.IDs[0])
+}
+
+// UniqueIDs returns ids without repeats, keeping the first occurrence order,
+// along with the IDs that were repeated
+func UniqueIDs(ids []uuid.UUID) (unique, duplicates []uuid.UUID) {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique = make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			duplicates = append(duplicates, id)
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique, duplicates
+}
+
+// CoalesceLineItems merges line items for the same product by summing their
+// quantities, so a repeated ID is applied once with the combined amount.
+// Items keep the order of each product's first occurrence; first[i] is the
+// request index where coalesced item i first appeared.
+func CoalesceLineItems(items []StockLineItem) (coalesced []StockLineItem, first []int, duplicates []uuid.UUID) {
+	position := make(map[uuid.UUID]int, len(items))
+	for i, item := range items {
+		if pos, ok := position[item.ProductID]; ok {
+			coalesced[pos].Qty += item.Qty
+			duplicates = append(duplicates, item.ProductID)
+			continue
+		}
+		position[item.ProductID] = len(coalesced)
+		coalesced = append(coalesced, item)
+		first = append(first, i)
+	}
+	return coalesced, first, duplicates
+}
+
+// BulkIDsRequest represents the request payload for a batch of product IDs;
+// repeated IDs are coalesced unless the service rejects them
+type BulkIDsRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,min=1,max=500"`
+}
+
+// BulkGetResult holds the live products of a bulk get, in the order their IDs
+// were first requested, and the IDs that matched none
+type BulkGetResult struct {
+	Products []Product
+	NotFound []uuid.UUID
+}
+
+// BulkDeleteResult reports which products a bulk delete removed and which
+// IDs matched no live product
+type BulkDeleteResult struct {
+	Deleted  []uuid.UUID `json:"deleted"`
+	NotFound []uuid.UUID `json:"not_found"`
+}
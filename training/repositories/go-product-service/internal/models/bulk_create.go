@@ -0,0 +1,36 @@
+package models
+
+import "github.com/google/uuid"
+
+// Bulk create modes
+const (
+	BulkModeAllOrNothing = "all_or_nothing"
+	BulkModeBestEffort   = "best_effort"
+)
+
+// MaxBulkCreateItems caps the number of products accepted by a bulk create
+const MaxBulkCreateItems = 500
+
+// BulkCreateQuery selects the mode of a bulk create; the body is a JSON array
+// of CreateProductRequest, and an empty mode uses the configured default
+type BulkCreateQuery struct {
+	Mode string `form:"mode" validate:"omitempty,oneof=all_or_nothing best_effort"`
+}
+
+// BulkCreateResult reports the outcome of a single item in a bulk create
+type BulkCreateResult struct {
+	Index     int        `json:"index"`
+	SKU       string     `json:"sku"`
+	Success   bool       `json:"success"`
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// BulkCreateResponse reports every item and whether the batch was committed
+type BulkCreateResponse struct {
+	Mode      string             `json:"mode"`
+	Committed bool               `json:"committed"`
+	Created   int                `json:"created"`
+	Failed    int                `json:"failed"`
+	Results   []BulkCreateResult `json:"results"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Bulk price adjustment modes
+const (
+	PriceAdjustPercent = "percent"
+	PriceAdjustAmount  = "amount"
+	PriceAdjustSet     = "set"
+)
+
+// BulkPriceUpdateRequest represents a price change applied to many products at once
+type BulkPriceUpdateRequest struct {
+	Category   string      `json:"category" validate:"required_without=ProductIDs,max=100"`
+	ProductIDs []uuid.UUID `json:"product_ids" validate:"required_without=Category,max=1000"`
+	Mode       string      `json:"mode" validate:"required,oneof=percent amount set"`
+	Value      float64     `json:"value"`
+}
+
+// NewPrice returns the price after applying the adjustment, rounded to cents
+func (r *BulkPriceUpdateRequest) NewPrice(price float64) float64 {
+	var next float64
+	switch r.Mode {
+	case PriceAdjustPercent:
+		next = price * (1 + r.Value/100)
+	case PriceAdjustAmount:
+		next = price + r.Value
+	case PriceAdjustSet:
+		next = r.Value
+	default:
+		next = price
+	}
+	return math.Round(next*100) / 100
+}
+
+// PriceChange is a product whose price a bulk update changed
+type PriceChange struct {
+	Product  Product
+	OldPrice float64
+}
+
+// PricePreviewItem shows the effect of a bulk price change on a single product
+type PricePreviewItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	Rejected  bool      `json:"rejected"`
+}
+
+// BulkPricePreview summarizes what a bulk price change would do
+type BulkPricePreview struct {
+	Matched  int                `json:"matched"`
+	Changed  int                `json:"changed"`
+	Rejected int                `json:"rejected"`
+	Sample   []PricePreviewItem `json:"sample"`
+}
+
+// PreviewBulkPriceUpdate computes the preview for the matched products without
+// modifying them; prices the guard refuses are counted as rejected, exactly
+// as the apply path refuses them
+func PreviewBulkPriceUpdate(products []Product, req *BulkPriceUpdateRequest, guard PriceGuard, sampleSize int) *BulkPricePreview {
+	preview := &BulkPricePreview{Matched: len(products), Sample: []PricePreviewItem{}}
+	for _, p := range products {
+		item := PricePreviewItem{
+			ProductID: p.ID,
+			SKU:       p.SKU,
+			OldPrice:  p.Price,
+			NewPrice:  req.NewPrice(p.Price),
+		}
+		switch {
+		case !guard.Allows(item.NewPrice):
+			item.Rejected = true
+			preview.Rejected++
+		case item.NewPrice != item.OldPrice:
+			preview.Changed++
+		}
+
+		if len(preview.Sample) < sampleSize {
+			preview.Sample = append(preview.Sample, item)
+		}
+	}
+	return preview
+}
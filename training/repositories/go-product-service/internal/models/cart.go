@@ -0,0 +1,50 @@
+package models
+
+import "github.com/google/uuid"
+
+// CartSnapshotRequest represents the line items of a cart at checkout
+type CartSnapshotRequest struct {
+	Items []StockLineItem `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// CartSnapshotItem reports the price and availability of one cart line
+type CartSnapshotItem struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	Qty          int       `json:"qty"`
+	Found        bool      `json:"found"`
+	Price        float64   `json:"price"`
+	Currency     string    `json:"currency"`
+	SalePrice    *float64  `json:"sale_price,omitempty"`
+	AvailableQty int       `json:"available_qty"`
+	InStock      bool      `json:"in_stock"`
+}
+
+// BuildCartSnapshot evaluates each line against the given products, using
+// the same availability rule as CheckAvailability so both endpoints agree.
+// salePrices holds the active sale price of products currently on sale;
+// products without a currency are reported in defaultCurrency.
+func BuildCartSnapshot(products map[uuid.UUID]*Product, salePrices map[uuid.UUID]float64, items []StockLineItem, defaultCurrency string) []CartSnapshotItem {
+	availability := CheckAvailability(products, items)
+	snapshot := make([]CartSnapshotItem, len(items))
+	for i, item := range items {
+		line := CartSnapshotItem{
+			ProductID:    item.ProductID,
+			Qty:          item.Qty,
+			AvailableQty: availability[i].AvailableQty,
+			InStock:      availability[i].Available,
+		}
+		if p, ok := products[item.ProductID]; ok {
+			line.Found = true
+			line.Price = p.Price
+			line.Currency = defaultCurrency
+			if p.Currency != nil && *p.Currency != "" {
+				line.Currency = *p.Currency
+			}
+			if sale, ok := salePrices[item.ProductID]; ok {
+				line.SalePrice = &sale
+			}
+		}
+		snapshot[i] = line
+	}
+	return snapshot
+}
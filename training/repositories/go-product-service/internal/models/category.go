@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCategoryTaken is returned when renaming a category to one already in use
+var ErrCategoryTaken = errors.New("category name already in use")
+
+// ErrReassignToSelf is returned when a category's products would be
+// reassigned to the category being deleted
+var ErrReassignToSelf = errors.New("cannot reassign products to the category being deleted")
+
+// RenameCategoryRequest represents the request payload for renaming a category
+type RenameCategoryRequest struct {
+	Old string `json:"old" validate:"required,max=100"`
+	New string `json:"new" validate:"required,max=100,nefield=Old"`
+}
+
+// RenameCategoryResult reports the outcome of a category rename
+type RenameCategoryResult struct {
+	Old      string `json:"old"`
+	New      string `json:"new"`
+	Affected int64  `json:"affected"`
+}
+
+// CategoryUsage reports how many products reference a category
+type CategoryUsage struct {
+	Category string `json:"category" db:"category"`
+	Active   int64  `json:"active" db:"active"`
+	Total    int64  `json:"total" db:"total"`
+}
+
+// CategoryInUseError is returned when deleting a category that live products
+// still use without naming a category to reassign them to
+type CategoryInUseError struct {
+	Usage CategoryUsage
+}
+
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("category %q is used by %d products", e.Usage.Category, e.Usage.Total)
+}
+
+// DeleteCategoryQuery represents the options for deleting a category; the
+// products still using it are moved to ReassignTo, which may already be in use
+type DeleteCategoryQuery struct {
+	ReassignTo string `form:"reassign_to" validate:"max=100"`
+}
+
+// DeleteCategoryResult reports the outcome of a category deletion
+type DeleteCategoryResult struct {
+	Category   string `json:"category"`
+	ReassignTo string `json:"reassign_to,omitempty"`
+	Affected   int64  `json:"affected"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrColumnMappingNotFound is returned when a column mapping name does not exist
+var ErrColumnMappingNotFound = errors.New("column mapping not found")
+
+// MappedColumn maps a single product field to a CSV header
+type MappedColumn struct {
+	Field  string `json:"field" validate:"required"`
+	Header string `json:"header" validate:"required,max=255"`
+}
+
+// ColumnMapping represents a reusable, named CSV column layout
+type ColumnMapping struct {
+	ID        uuid.UUID     `json:"id" db:"id"`
+	Name      string        `json:"name" db:"name" validate:"required,min=1,max=100"`
+	Columns   MappedColumns `json:"columns" db:"columns" validate:"required,min=1,dive"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// CreateColumnMappingRequest represents the request payload for saving a column mapping
+type CreateColumnMappingRequest struct {
+	Name    string         `json:"name" validate:"required,min=1,max=100"`
+	Columns []MappedColumn `json:"columns" validate:"required,min=1,dive"`
+}
+
+// MappedColumns is an ordered column list stored as JSONB
+type MappedColumns []MappedColumn
+
+// Value implements driver.Valuer
+func (c MappedColumns) Value() (driver.Value, error) {
+	return json.Marshal([]MappedColumn(c))
+}
+
+// Scan implements sql.Scanner
+func (c *MappedColumns) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into MappedColumns", src)
+	}
+	return json.Unmarshal(data, (*[]MappedColumn)(c))
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+)
+
+// ComputeContentHash returns the hash of the product's current content
+func (p *Product) ComputeContentHash() string {
+	return contentHash(p.Name, p.Description, p.Price, p.Category, p.SKU, p.Stock)
+}
+
+// ContentHash returns the hash a product created from this request would have
+func (r *CreateProductRequest) ContentHash() string {
+	return contentHash(r.Name, r.Description, r.Price, r.Category, r.SKU, r.Stock)
+}
+
+// contentHash is a SHA-256 over the content fields, keyed by name and sorted so
+// the result doesn't depend on field order
+func contentHash(name, description string, price float64, category, sku string, stock int) string {
+	fields := map[string]string{
+		"name":        name,
+		"description": description,
+		"price":       strconv.FormatFloat(price, 'f', -1, 64),
+		"category":    category,
+		"sku":         sku,
+		"stock":       strconv.Itoa(stock),
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// Length-prefix values so adjacent fields can't run into each other
+		v := fields[k]
+		h.Write([]byte(k + ":" + strconv.Itoa(len(v)) + ":" + v + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
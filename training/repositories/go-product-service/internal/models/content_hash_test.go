@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIdenticalContentSharesHash(t *testing.T) {
+	a := Product{ID: uuid.New(), Name: "Desk", Description: "Oak", Price: 120, Category: "furniture", SKU: "DESK-1", Stock: 3, IsActive: true}
+	b := Product{ID: uuid.New(), Name: "Desk", Description: "Oak", Price: 120, Category: "furniture", SKU: "DESK-1", Stock: 3, IsActive: false}
+	if a.ComputeContentHash() != b.ComputeContentHash() {
+		t.Fatal("expected products with the same content to share a hash")
+	}
+	req := CreateProductRequest{Name: "Desk", Description: "Oak", Price: 120, Category: "furniture", SKU: "DESK-1", Stock: 3}
+	if req.ContentHash() != a.ComputeContentHash() {
+		t.Fatal("expected a create request to hash like the product it creates")
+	}
+
+	for name, change := range map[string]func(p *Product){
+		"name":        func(p *Product) { p.Name = "Desk2" },
+		"description": func(p *Product) { p.Description = "Pine" },
+		"price":       func(p *Product) { p.Price = 120.01 },
+		"category":    func(p *Product) { p.Category = "office" },
+		"sku":         func(p *Product) { p.SKU = "DESK-2" },
+		"stock":       func(p *Product) { p.Stock = 4 },
+	} {
+		c := a
+		change(&c)
+		if c.ComputeContentHash() == a.ComputeContentHash() {
+			t.Errorf("changing %s must change the hash", name)
+		}
+	}
+}
+
+func TestContentHashKeepsFieldsApart(t *testing.T) {
+	a := Product{Name: "ab", Description: "c"}
+	b := Product{Name: "a", Description: "bc"}
+	if a.ComputeContentHash() == b.ComputeContentHash() {
+		t.Fatal("values shifted between fields must not collide")
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// DefaultCoverageWindowDays is the sales velocity window used when none is configured
+const DefaultCoverageWindowDays = 30
+
+// CoverageDays returns how many days available stock lasts at dailyVelocity
+// units sold per day. A product that is not selling has infinite coverage,
+// reported as nil.
+func CoverageDays(available int, dailyVelocity float64) *float64 {
+	if dailyVelocity <= 0 {
+		return nil
+	}
+	if available < 0 {
+		available = 0
+	}
+	days := float64(available) / dailyVelocity
+	return &days
+}
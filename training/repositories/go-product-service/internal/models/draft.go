@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotDraft is returned when publishing a product that is not a draft
+var ErrNotDraft = errors.New("product is not a draft")
+
+// draftSKUPrefix marks the placeholder SKU of a draft started without one;
+// SKUs are unique, so each such draft needs its own
+const draftSKUPrefix = "draft-"
+
+// DraftSKU returns the placeholder SKU of a draft started without one
+func DraftSKU(id uuid.UUID) string {
+	return draftSKUPrefix + id.String()
+}
+
+// CreateDraftRequest represents the relaxed payload for starting a product;
+// only the name is required and the rest is checked when it is published
+type CreateDraftRequest struct {
+	Name        string   `json:"name" validate:"required,min=1,max=255"`
+	Description string   `json:"description" validate:"max=1000"`
+	Price       *float64 `json:"price,omitempty" validate:"omitempty,gte=0"`
+	Cost        *float64 `json:"cost,omitempty" validate:"omitempty,gte=0"`
+	Category    string   `json:"category" validate:"max=100"`
+	SKU         string   `json:"sku" validate:"max=50"`
+	Barcode     *string  `json:"barcode,omitempty" validate:"omitempty,max=50"`
+	Stock       int      `json:"stock" validate:"gte=0"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+}
+
+// Product builds the inactive draft product stored for the request; a
+// missing price is stored as zero until the draft is completed
+func (r *CreateDraftRequest) Product() *Product {
+	p := &Product{
+		Name:        r.Name,
+		Description: r.Description,
+		Cost:        r.Cost,
+		Category:    r.Category,
+		SKU:         r.SKU,
+		Barcode:     r.Barcode,
+		Stock:       r.Stock,
+		IsActive:    false,
+		Status:      StatusDraft,
+		Metadata:    r.Metadata,
+	}
+	if r.Price != nil {
+		p.Price = *r.Price
+	}
+	return p
+}
+
+// PublishRequest returns the product as a create request, so publishing
+// runs exactly the validation a normal create does; a placeholder SKU counts
+// as missing
+func (p *Product) PublishRequest() *CreateProductRequest {
+	sku := p.SKU
+	if strings.HasPrefix(sku, draftSKUPrefix) {
+		sku = ""
+	}
+	return &CreateProductRequest{
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Cost:        p.Cost,
+		Category:    p.Category,
+		SKU:         sku,
+		Barcode:     p.Barcode,
+		Stock:       p.Stock,
+		Metadata:    p.Metadata,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+// GroupedProductsFilter represents options for listing products bucketed by a field
+type GroupedProductsFilter struct {
+	GroupBy       string `form:"group_by" validate:"required,oneof=category is_active"`
+	ItemsPerGroup int    `form:"items_per_group,default=5" validate:"min=1,max=20"`
+}
+
+// ProductGroup represents one bucket of a grouped product listing
+type ProductGroup struct {
+	Group string    `json:"group"`
+	Count int64     `json:"count"`
+	Items []Product `json:"items"`
+}
+
+// CategorySampleRequest represents a request for a capped sample of products
+// from each of several categories
+type CategorySampleRequest struct {
+	Categories  []string `json:"categories" validate:"required,min=1,max=50,dive,required,max=100"`
+	Limit       int      `json:"limit" validate:"min=1,max=20"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+	InStockOnly bool     `json:"in_stock_only"`
+}
+
+// GroupSamples folds rows ordered by group into one ProductGroup per
+// requested category, keeping the request order; categories with no
+// matching products are returned with no items
+func GroupSamples(categories []string, products []Product) []ProductGroup {
+	byCategory := make(map[string][]Product, len(categories))
+	for _, p := range products {
+		byCategory[p.Category] = append(byCategory[p.Category], p)
+	}
+
+	groups := make([]ProductGroup, 0, len(categories))
+	seen := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		items := byCategory[c]
+		if items == nil {
+			items = []Product{}
+		}
+		groups = append(groups, ProductGroup{Group: c, Count: int64(len(items)), Items: items})
+	}
+	return groups
+}
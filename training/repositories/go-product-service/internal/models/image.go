@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkImageItems caps the number of rows accepted by a bulk image import
+const MaxBulkImageItems = 500
+
+// ProductImage represents an image attached to a product
+type ProductImage struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	URL       string    `json:"url" db:"url" validate:"required,url,max=2048"`
+	Position  int       `json:"position" db:"position" validate:"gte=0"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// BulkImageItem represents a single SKU to image URL row in a bulk image import
+type BulkImageItem struct {
+	SKU      string `json:"sku" validate:"required,max=50"`
+	URL      string `json:"url" validate:"required,url,max=2048"`
+	Position int    `json:"position" validate:"gte=0"`
+}
+
+// BulkImageRequest represents the request payload for attaching images by SKU
+type BulkImageRequest struct {
+	Items []BulkImageItem `json:"items" validate:"required,min=1,max=500,dive"`
+}
+
+// BulkImageResult reports the outcome of a single row in a bulk image import
+type BulkImageResult struct {
+	Index     int        `json:"index"`
+	SKU       string     `json:"sku"`
+	Success   bool       `json:"success"`
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	ImageID   *uuid.UUID `json:"image_id,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
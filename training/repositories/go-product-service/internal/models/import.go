@@ -0,0 +1,55 @@
+package models
+
+// Upsert outcomes reported for each imported row
+const (
+	UpsertCreated = "created"
+	UpsertUpdated = "updated"
+	UpsertSkipped = "skipped"
+)
+
+// ImportSummary counts the outcome of every row in an import
+type ImportSummary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// Add records a single row outcome
+func (s *ImportSummary) Add(outcome string) {
+	switch outcome {
+	case UpsertCreated:
+		s.Created++
+	case UpsertUpdated:
+		s.Updated++
+	case UpsertSkipped:
+		s.Skipped++
+	default:
+		s.Failed++
+	}
+}
+
+// ImportRowError describes a streamed import row that was not created
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	SKU   string `json:"sku,omitempty"`
+	Error string `json:"error"`
+}
+
+// ImportReport summarizes a streamed import. Errors lists the failed rows up
+// to the configured limit; Failed always counts every one of them.
+type ImportReport struct {
+	ImportSummary
+	Errors          []ImportRowError `json:"errors"`
+	ErrorsTruncated bool             `json:"errors_truncated,omitempty"`
+}
+
+// AddError records a failed row, keeping at most maxErrors of them
+func (r *ImportReport) AddError(e ImportRowError, maxErrors int) {
+	r.Failed++
+	if len(r.Errors) >= maxErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+	r.Errors = append(r.Errors, e)
+}
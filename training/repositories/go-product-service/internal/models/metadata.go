@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Metadata limits
+const (
+	MaxMetadataDepth = 5
+	MaxMetadataBytes = 16 * 1024
+	MaxMetadataKey   = 100
+)
+
+// ErrInvalidMetadata is returned for metadata beyond the size or nesting limits
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+// Metadata holds free-form product attributes stored as JSONB
+type Metadata map[string]interface{}
+
+// Value implements driver.Valuer
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner
+func (m *Metadata) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Metadata", src)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// Validate enforces the size and nesting limits on metadata
+func (m Metadata) Validate() error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxMetadataBytes {
+		return fmt.Errorf("%w: exceeds %d bytes", ErrInvalidMetadata, MaxMetadataBytes)
+	}
+	for k, v := range m {
+		if err := validateMetadataValue(k, v, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMetadataValue(key string, v interface{}, depth int) error {
+	if key == "" {
+		return fmt.Errorf("%w: keys must not be empty", ErrInvalidMetadata)
+	}
+	if len(key) > MaxMetadataKey {
+		return fmt.Errorf("%w: key %q exceeds %d characters", ErrInvalidMetadata, key, MaxMetadataKey)
+	}
+	if depth > MaxMetadataDepth {
+		return fmt.Errorf("%w: exceeds a nesting depth of %d", ErrInvalidMetadata, MaxMetadataDepth)
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if err := validateMetadataValue(k, child, depth+1); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range val {
+			if err := validateMetadataValue(key, child, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Metadata comparison operators
+var metadataOperators = map[string]bool{
+	"eq":  true,
+	"ne":  true,
+	"gt":  true,
+	"gte": true,
+	"lt":  true,
+	"lte": true,
+}
+
+// MetadataCondition is a typed comparison against a single metadata key,
+// written in a query string as key:op:value (e.g. screen_size:gt:15)
+type MetadataCondition struct {
+	Key     string
+	Op      string
+	Value   string
+	Numeric bool
+}
+
+// ParseMetadataConditions parses key:op:value expressions; a value that parses as
+// a number is compared numerically, and a double-quoted value is always compared as text
+func ParseMetadataConditions(exprs []string) ([]MetadataCondition, error) {
+	conditions := make([]MetadataCondition, 0, len(exprs))
+	for _, expr := range exprs {
+		parts := strings.SplitN(expr, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%w: metadata filter %q: expected key:op:value", ErrInvalidFilter, expr)
+		}
+
+		c := MetadataCondition{Key: parts[0], Op: parts[1], Value: parts[2]}
+		if c.Key == "" || len(c.Key) > MaxMetadataKey {
+			return nil, fmt.Errorf("%w: metadata filter %q: bad key", ErrInvalidFilter, expr)
+		}
+		if !metadataOperators[c.Op] {
+			return nil, fmt.Errorf("%w: metadata filter %q: unknown operator %q", ErrInvalidFilter, expr, c.Op)
+		}
+
+		if unquoted, err := strconv.Unquote(c.Value); err == nil && strings.HasPrefix(c.Value, `"`) {
+			c.Value = unquoted
+		} else if _, err := strconv.ParseFloat(c.Value, 64); err == nil {
+			c.Numeric = true
+		}
+
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
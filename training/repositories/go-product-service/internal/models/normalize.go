@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Normalizer is implemented by the requests whose free-text input can be normalized
+type Normalizer interface {
+	Normalize(titleCase bool)
+}
+
+// Normalize trims surrounding whitespace from the string fields and, when
+// titleCase is set, title-cases the name; run it before validation so that
+// length checks apply to the normalized values
+func (r *CreateProductRequest) Normalize(titleCase bool) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.Category = strings.TrimSpace(r.Category)
+	r.SKU = strings.TrimSpace(r.SKU)
+	if r.Barcode != nil {
+		trimmed := strings.TrimSpace(*r.Barcode)
+		r.Barcode = &trimmed
+	}
+	if titleCase {
+		r.Name = toTitle(r.Name)
+	}
+}
+
+// Normalize applies the same normalization as CreateProductRequest.Normalize to the fields being updated
+func (r *UpdateProductRequest) Normalize(titleCase bool) {
+	trim := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		t := strings.TrimSpace(*s)
+		return &t
+	}
+	r.Name = trim(r.Name)
+	r.Description = trim(r.Description)
+	r.Category = trim(r.Category)
+	r.SKU = trim(r.SKU)
+	r.Barcode = trim(r.Barcode)
+	if titleCase && r.Name != nil {
+		titled := toTitle(*r.Name)
+		r.Name = &titled
+	}
+}
+
+// toTitle upper-cases the first letter of each word and lower-cases the rest
+func toTitle(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + strings.ToLower(w[size:])
+	}
+	return strings.Join(words, " ")
+}
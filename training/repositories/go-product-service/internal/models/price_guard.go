@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// PriceGuard is the single check every price-producing path goes through:
+// create, update, bulk adjustment, discount and sale pricing
+type PriceGuard struct {
+	// Min is the lowest permitted price; with AllowMin unset the price must
+	// be strictly greater, so the zero value rejects anything <= 0
+	Min      float64
+	AllowMin bool
+}
+
+// PriceError reports a price, or effective price, below the guard's minimum
+type PriceError struct {
+	Source string
+	Price  float64
+	Min    float64
+	Equal  bool
+}
+
+func (e *PriceError) Error() string {
+	op := ">"
+	if e.Equal {
+		op = ">="
+	}
+	return fmt.Sprintf("%s price %s must be %s %s", e.Source, strconv.FormatFloat(e.Price, 'f', -1, 64), op, strconv.FormatFloat(e.Min, 'f', -1, 64))
+}
+
+// Allows reports whether price satisfies the guard
+func (g PriceGuard) Allows(price float64) bool {
+	if g.AllowMin {
+		return price >= g.Min
+	}
+	return price > g.Min
+}
+
+// Check returns a *PriceError naming source (e.g. "bulk", "sale") when
+// price does not satisfy the guard
+func (g PriceGuard) Check(source string, price float64) error {
+	if g.Allows(price) {
+		return nil
+	}
+	return &PriceError{Source: source, Price: price, Min: g.Min, Equal: g.AllowMin}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTiers is returned for price tiers that are not monotonic by quantity
+var ErrInvalidTiers = errors.New("invalid price tiers")
+
+// PriceTier represents the unit price that applies from a minimum quantity
+type PriceTier struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	MinQty    int       `json:"min_qty" db:"min_qty" validate:"min=2"`
+	Price     float64   `json:"price" db:"price" validate:"gt=0"`
+}
+
+// SetPriceTiersRequest represents the request payload for replacing a product's tiers
+type SetPriceTiersRequest struct {
+	Tiers []PriceTier `json:"tiers" validate:"max=20,dive"`
+}
+
+// EffectivePriceQuery represents the quantity to price
+type EffectivePriceQuery struct {
+	Qty int `form:"qty,default=1" validate:"min=1"`
+}
+
+// EffectivePrice represents the unit price for a quantity and how it was reached
+type EffectivePrice struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	Qty       int        `json:"qty"`
+	Currency  *string    `json:"currency,omitempty"`
+	BasePrice float64    `json:"base_price"`
+	UnitPrice float64    `json:"unit_price"`
+	Total     float64    `json:"total"`
+	Tier      *PriceTier `json:"tier,omitempty"`
+	OnSale    bool       `json:"on_sale"`
+}
+
+// ValidateTiers requires tiers sorted by strictly increasing quantity with
+// prices that never increase, and every tier cheaper than the base price
+func ValidateTiers(basePrice float64, tiers []PriceTier) error {
+	prevQty, prevPrice := 1, basePrice
+	for i, t := range tiers {
+		if t.MinQty <= prevQty {
+			return fmt.Errorf("%w: tier %d min_qty %d must be greater than %d", ErrInvalidTiers, i, t.MinQty, prevQty)
+		}
+		if t.Price > prevPrice {
+			return fmt.Errorf("%w: tier %d price %.2f must not exceed %.2f", ErrInvalidTiers, i, t.Price, prevPrice)
+		}
+		prevQty, prevPrice = t.MinQty, t.Price
+	}
+	return nil
+}
+
+// ComputeEffectivePrice returns the unit price of qty units at now: the
+// lower of the active sale price (or list price) and the deepest tier the
+// quantity reaches. tiers must be sorted by MinQty, as ValidateTiers requires.
+func ComputeEffectivePrice(p *Product, tiers []PriceTier, qty int, now time.Time) *EffectivePrice {
+	out := &EffectivePrice{ProductID: p.ID, Qty: qty, Currency: p.Currency, BasePrice: p.Price, UnitPrice: p.Price}
+	if p.OnSale(now) {
+		out.UnitPrice = *p.SalePrice
+		out.OnSale = true
+	}
+
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if qty >= tiers[i].MinQty {
+			if tiers[i].Price < out.UnitPrice {
+				tier := tiers[i]
+				out.Tier = &tier
+				out.UnitPrice = tier.Price
+				out.OnSale = false
+			}
+			break
+		}
+	}
+
+	out.Total = math.Round(out.UnitPrice*float64(qty)*100) / 100
+	return out
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventPriceDropped is published to the price watchers of a product whose
+// price decreased
+const EventPriceDropped = "product.price_dropped"
+
+// ErrPriceWatchNotFound is returned when deleting a watch that does not exist
+var ErrPriceWatchNotFound = errors.New("price watch not found")
+
+// PriceWatch represents a subscription to price drops on a product
+type PriceWatch struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Email     string    `json:"email" db:"email" validate:"required,email,max=255"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreatePriceWatchRequest represents the request payload for watching a product's price
+type CreatePriceWatchRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+// PriceDropEvent is published to watchers when a product's price decreases
+type PriceDropEvent struct {
+	ProductID uuid.UUID `json:"product_id"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	Emails    []string  `json:"emails"`
+}
+
+// NewPriceDropEvent returns the event for a price change, or nil unless the price decreased
+func NewPriceDropEvent(productID uuid.UUID, oldPrice, newPrice float64, watches []PriceWatch) *PriceDropEvent {
+	if newPrice >= oldPrice || len(watches) == 0 {
+		return nil
+	}
+
+	emails := make([]string, len(watches))
+	for i, w := range watches {
+		emails[i] = w.Email
+	}
+	return &PriceDropEvent{
+		ProductID: productID,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		Emails:    emails,
+	}
+}
@@ -1,55 +1,183 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrDuplicateSKU is returned when a write would give two live products the same SKU
+var ErrDuplicateSKU = errors.New("a product with this SKU already exists")
+
+// ErrInvalidFilter is returned for a filter expression that cannot be parsed
+var ErrInvalidFilter = errors.New("invalid filter")
+
 // Product represents a product in the system
 type Product struct {
-	ID          uuid.UUID `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name" validate:"required,min=1,max=255"`
-	Description string    `json:"description" db:"description" validate:"max=1000"`
-	Price       float64   `json:"price" db:"price" validate:"required,gt=0"`
-	Category    string    `json:"category" db:"category" validate:"required,max=100"`
-	SKU         string    `json:"sku" db:"sku" validate:"required,max=50"`
-	Stock       int       `json:"stock" db:"stock" validate:"gte=0"`
-	IsActive    bool      `json:"is_active" db:"is_active"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID           uuid.UUID  `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name" validate:"required,min=1,max=255"`
+	Description  string     `json:"description" db:"description" validate:"max=1000"`
+	Price        float64    `json:"price" db:"price" validate:"required,gt=0"`
+	Currency     *string    `json:"currency,omitempty" db:"currency" validate:"omitempty,len=3"`
+	SalePrice    *float64   `json:"sale_price,omitempty" db:"sale_price"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty" db:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty" db:"sale_ends_at"`
+	Cost         *float64   `json:"cost,omitempty" db:"cost" validate:"omitempty,gte=0"`
+	Category     string     `json:"category" db:"category" validate:"required,max=100"`
+	SKU          string     `json:"sku" db:"sku" validate:"required,max=50"`
+	Barcode      *string    `json:"barcode,omitempty" db:"barcode" validate:"omitempty,max=50"`
+	Stock        int        `json:"stock" db:"stock" validate:"gte=0"`
+	Reserved     int        `json:"reserved" db:"reserved"`
+	ReorderLevel *int       `json:"reorder_level,omitempty" db:"reorder_level" validate:"omitempty,gte=0"`
+	IsActive     bool       `json:"is_active" db:"is_active"`
+	Status       string     `json:"status" db:"status"`
+	Metadata     Metadata   `json:"metadata,omitempty" db:"metadata"`
+	ContentHash  string     `json:"content_hash" db:"content_hash"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedBy    *string    `json:"created_by,omitempty" db:"created_by"`
+}
+
+// Margin returns the profit margin (price - cost) / price, or nil when cost is unknown
+func (p *Product) Margin() *float64 {
+	if p.Cost == nil || p.Price == 0 {
+		return nil
+	}
+	margin := (p.Price - *p.Cost) / p.Price
+	return &margin
 }
 
 // CreateProductRequest represents the request payload for creating a product
 type CreateProductRequest struct {
-	Name        string  `json:"name" validate:"required,min=1,max=255"`
-	Description string  `json:"description" validate:"max=1000"`
-	Price       float64 `json:"price" validate:"required,gt=0"`
-	Category    string  `json:"category" validate:"required,max=100"`
-	SKU         string  `json:"sku" validate:"required,max=50"`
-	Stock       int     `json:"stock" validate:"gte=0"`
+	Name        string   `json:"name" validate:"required,min=1,max=255"`
+	Description string   `json:"description" validate:"max=1000"`
+	Price       float64  `json:"price" validate:"gte=0"`
+	Cost        *float64 `json:"cost,omitempty" validate:"omitempty,gte=0"`
+	Category    string   `json:"category" validate:"required,max=100"`
+	SKU         string   `json:"sku" validate:"required,max=50"`
+	Barcode     *string  `json:"barcode,omitempty" validate:"omitempty,max=50"`
+	Stock       int      `json:"stock" validate:"gte=0"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+
+	OpeningStockReason string `json:"opening_stock_reason,omitempty" validate:"omitempty,max=50"`
+}
+
+// InitialActive resolves the active state of a new product: the configured
+// default applies unless a privileged client set IsActive explicitly
+func (r *CreateProductRequest) InitialActive(defaultActive, privileged bool) bool {
+	if privileged && r.IsActive != nil {
+		return *r.IsActive
+	}
+	return defaultActive
 }
 
 // UpdateProductRequest represents the request payload for updating a product
 type UpdateProductRequest struct {
 	Name        *string  `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
 	Description *string  `json:"description,omitempty" validate:"omitempty,max=1000"`
-	Price       *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
+	Price       *float64 `json:"price,omitempty" validate:"omitempty,gte=0"`
+	Cost        *float64 `json:"cost,omitempty" validate:"omitempty,gte=0"`
 	Category    *string  `json:"category,omitempty" validate:"omitempty,max=100"`
 	SKU         *string  `json:"sku,omitempty" validate:"omitempty,max=50"`
+	Barcode     *string  `json:"barcode,omitempty" validate:"omitempty,max=50"`
 	Stock       *int     `json:"stock,omitempty" validate:"omitempty,gte=0"`
 	IsActive    *bool    `json:"is_active,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+
+	ReorderLevel *int `json:"reorder_level,omitempty" validate:"omitempty,gte=0"`
+
+	SalePrice    *float64   `json:"sale_price,omitempty" validate:"omitempty,gte=0"`
+	SaleStartsAt *time.Time `json:"sale_starts_at,omitempty" validate:"required_with=SaleEndsAt"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at,omitempty" validate:"omitempty,gtfield=SaleStartsAt"`
 }
 
-// ProductFilter represents filtering options for products
+// SortRelevance orders a search by full-text rank, best match first
+const SortRelevance = "relevance"
+
+// Search match modes; an empty mode combines prefix and word matching
+const (
+	MatchModePrefix = "prefix"
+	MatchModeWord   = "word"
+	MatchModePhrase = "phrase"
+)
+
+// ProductFilter represents filtering options for products; it binds from the
+// query string on GET and from a JSON body on POST /products/search
 type ProductFilter struct {
-	Category  string  `form:"category"`
-	MinPrice  float64 `form:"min_price"`
-	MaxPrice  float64 `form:"max_price"`
-	IsActive  *bool   `form:"is_active"`
-	Search    string  `form:"search"`
-	Limit     int     `form:"limit,default=10" validate:"max=100"`
-	Offset    int     `form:"offset,default=0"`
-	SortBy    string  `form:"sort_by,default=created_at"`
-	SortOrder string  `form:"sort_order,default=desc" validate:"oneof=asc desc"`
+	Category           string   `json:"category,omitempty" form:"category"`
+	Categories         []string `json:"categories,omitempty" form:"categories" validate:"max=200,dive,max=100"`
+	Tags               []string `json:"tags,omitempty" form:"tags" validate:"max=200,dive,max=50"`
+	MinPrice           float64  `json:"min_price,omitempty" form:"min_price"`
+	MaxPrice           float64  `json:"max_price,omitempty" form:"max_price"`
+	MinMargin          *float64 `json:"min_margin,omitempty" form:"min_margin"`
+	MaxMargin          *float64 `json:"max_margin,omitempty" form:"max_margin"`
+	IsActive           *bool    `json:"is_active,omitempty" form:"is_active"`
+	Search             string   `json:"search,omitempty" form:"search"`
+	MatchMode          string   `json:"match_mode,omitempty" form:"match_mode" validate:"omitempty,oneof=prefix word phrase"`
+	ContentHash        string   `json:"content_hash,omitempty" form:"content_hash" validate:"omitempty,len=64,hexadecimal"`
+	MetadataKey        string   `json:"metadata_key,omitempty" form:"metadata_key" validate:"required_with=MetadataValue,max=100"`
+	MetadataValue      string   `json:"metadata_value,omitempty" form:"metadata_value" validate:"required_with=MetadataKey"`
+	MetadataFilters    []string `json:"metadata_filters,omitempty" form:"metadata_filter" validate:"max=10"`
+	PriceVsCategoryAvg string   `json:"price_vs_category_avg,omitempty" form:"price_vs_category_avg"`
+	MaxCoverageDays    *float64 `json:"max_coverage_days,omitempty" form:"max_coverage_days" validate:"omitempty,gte=0"`
+	SavedQuery         string   `json:"-" form:"saved_query" validate:"omitempty,max=100"`
+	Limit              int      `json:"limit,omitempty" form:"limit,default=10" validate:"max=100"`
+	Offset             int      `json:"offset,omitempty" form:"offset,default=0"`
+	Cursor             string   `json:"cursor,omitempty" form:"cursor" validate:"omitempty,max=512"`
+	SortBy             string   `json:"sort_by,omitempty" form:"sort_by,default=created_at" validate:"omitempty,oneof=name price category sku stock created_at updated_at relevance"`
+	SortOrder          string   `json:"sort_order,omitempty" form:"sort_order,default=desc" validate:"oneof=asc desc"`
+
+	// CoverageWindowDays is the velocity window for MaxCoverageDays, set
+	// from configuration rather than by the caller
+	CoverageWindowDays int `json:"-" form:"-"`
+}
+
+// ApplyDefaults fills in the defaults the form tags give a GET request, so a
+// filter decoded from JSON behaves identically
+func (f *ProductFilter) ApplyDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 10
+	}
+	if f.SortBy == "" {
+		f.SortBy = "created_at"
+	}
+	if f.SortOrder == "" {
+		f.SortOrder = "desc"
+	}
+}
+
+// Orders of similar products: closest in price first, or shuffled
+const (
+	SimilarOrderPrice  = "price"
+	SimilarOrderRandom = "random"
+)
+
+// SimilarProductsFilter represents options for finding products similar to a given product
+type SimilarProductsFilter struct {
+	PriceBand float64 `form:"price_band,default=0.2" validate:"gt=0,lte=1"`
+	Limit     int     `form:"limit,default=8" validate:"min=1,max=50"`
+	Order     string  `form:"order,default=price" validate:"oneof=price random"`
 }
+
+// PageFilter represents plain limit/offset paging for report endpoints
+type PageFilter struct {
+	Limit  int `form:"limit,default=10" validate:"min=1,max=100"`
+	Offset int `form:"offset,default=0" validate:"gte=0"`
+}
+
+// IncrementalExportFilter represents the updated_at window of an incremental export
+type IncrementalExportFilter struct {
+	Since time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00" validate:"required"`
+	Until time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00" validate:"required,gtfield=Since"`
+}
+
+// Product lifecycle events, written to the outbox in the same transaction
+// as the product write they describe
+const (
+	EventProductCreated = "product.created"
+	EventProductUpdated = "product.updated"
+	EventProductDeleted = "product.deleted"
+)
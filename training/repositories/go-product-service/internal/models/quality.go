@@ -0,0 +1,23 @@
+package models
+
+// QualityWeights are the points deducted from a perfect score of 100 for
+// each data gap
+type QualityWeights struct {
+	MissingDescription int
+	NoImage            int
+	ZeroStock          int
+	NoTags             int
+}
+
+// LowQualityFilter represents options for listing products by data-quality score
+type LowQualityFilter struct {
+	MaxScore int `form:"max_score,default=50" validate:"min=0,max=100"`
+	Limit    int `form:"limit,default=10" validate:"min=1,max=100"`
+	Offset   int `form:"offset,default=0" validate:"gte=0"`
+}
+
+// ScoredProduct represents a product with its computed data-quality score
+type ScoredProduct struct {
+	Product
+	DataQualityScore int `json:"data_quality_score" db:"data_quality_score"`
+}
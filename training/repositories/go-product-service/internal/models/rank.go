@@ -0,0 +1,21 @@
+package models
+
+import "github.com/google/uuid"
+
+// RankQuery represents the ordering and scope a product is ranked within;
+// an empty Within ranks it among every live product
+type RankQuery struct {
+	SortBy    string `form:"sort_by,default=price" validate:"oneof=name price category sku stock created_at updated_at"`
+	SortOrder string `form:"sort_order,default=asc" validate:"oneof=asc desc"`
+	Within    string `form:"within" validate:"omitempty,oneof=category status"`
+}
+
+// ProductRank represents a product's 1-based position among Total products
+type ProductRank struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Rank      int64     `json:"rank"`
+	Total     int64     `json:"total"`
+	SortBy    string    `json:"sort_by"`
+	SortOrder string    `json:"sort_order"`
+	Within    string    `json:"within,omitempty"`
+}
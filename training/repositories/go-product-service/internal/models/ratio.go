@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RatioCondition compares a computed ratio against a threshold, e.g. ">1.2"
+type RatioCondition struct {
+	Op    string
+	Value float64
+}
+
+// ParseRatioCondition parses an expression made of one of >, >=, <, <= and a positive number
+func ParseRatioCondition(expr string) (*RatioCondition, error) {
+	expr = strings.TrimSpace(expr)
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if rest, ok := strings.CutPrefix(expr, op); ok {
+			value, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+			if err != nil || value <= 0 {
+				return nil, fmt.Errorf("%w: ratio %q: expected a positive number", ErrInvalidFilter, rest)
+			}
+			return &RatioCondition{Op: op, Value: value}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: ratio condition %q: expected an operator such as >1.2", ErrInvalidFilter, expr)
+}
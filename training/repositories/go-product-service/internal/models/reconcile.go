@@ -0,0 +1,32 @@
+package models
+
+import "github.com/google/uuid"
+
+// StockCount is the counted quantity of one SKU from a physical inventory count
+type StockCount struct {
+	SKU        string `json:"sku" validate:"required,max=50"`
+	CountedQty int    `json:"counted_qty" validate:"gte=0"`
+}
+
+// ReconcileStockRequest represents the request payload for reconciling stock
+// against a physical count; each SKU may appear only once
+type ReconcileStockRequest struct {
+	Counts []StockCount `json:"counts" validate:"required,min=1,max=500,unique=SKU,dive"`
+}
+
+// ReconcileLine reports the correction made to one product
+type ReconcileLine struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	Previous  int       `json:"previous"`
+	Counted   int       `json:"counted"`
+	Variance  int       `json:"variance"`
+}
+
+// ReconcileResult summarizes a reconciliation; TotalVariance nets gains
+// against losses while AbsoluteVariance adds up every discrepancy
+type ReconcileResult struct {
+	Lines            []ReconcileLine `json:"lines"`
+	TotalVariance    int             `json:"total_variance"`
+	AbsoluteVariance int             `json:"absolute_variance"`
+}
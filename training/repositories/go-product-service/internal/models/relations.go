@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductVariant represents a purchasable variation of a product, such as a size or colour
+type ProductVariant struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ProductID  uuid.UUID `json:"product_id" db:"product_id"`
+	SKU        string    `json:"sku" db:"sku" validate:"required,max=50"`
+	Name       string    `json:"name" db:"name" validate:"required,max=255"`
+	Price      *float64  `json:"price,omitempty" db:"price" validate:"omitempty,gt=0"`
+	Stock      int       `json:"stock" db:"stock" validate:"gte=0"`
+	Attributes Metadata  `json:"attributes,omitempty" db:"attributes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateVariantRequest represents the request payload for adding a variant to a product
+type CreateVariantRequest struct {
+	SKU        string   `json:"sku" validate:"required,max=50"`
+	Name       string   `json:"name" validate:"required,max=255"`
+	Price      *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
+	Stock      int      `json:"stock" validate:"gte=0"`
+	Attributes Metadata `json:"attributes,omitempty"`
+}
+
+// ErrVariantNotFound is returned when deleting a variant the product does not have
+var ErrVariantNotFound = errors.New("variant not found")
+
+// ErrDuplicateVariantSKU is returned when a variant would reuse another variant's SKU
+var ErrDuplicateVariantSKU = errors.New("a variant with this SKU already exists")
+
+// ProductTag represents a tag attached to a product
+type ProductTag struct {
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Tag       string    `json:"tag" db:"tag" validate:"required,max=50"`
+}
+
+// TagCount represents how many live products carry a tag
+type TagCount struct {
+	Tag   string `json:"tag" db:"tag"`
+	Count int64  `json:"count" db:"count"`
+}
+
+// ErrTagNotFound is returned when deleting a tag no product carries
+var ErrTagNotFound = errors.New("tag not found")
+
+// DeleteTagResult reports the products a deleted tag was removed from
+type DeleteTagResult struct {
+	Tag      string      `json:"tag"`
+	Products []uuid.UUID `json:"products"`
+}
+
+// TagCountFilter represents options for listing tag usage; tags used by
+// fewer than MinCount products are hidden
+type TagCountFilter struct {
+	MinCount int `form:"min_count,default=1" validate:"min=1"`
+}
+
+// ProductTranslation represents a product's name and description in another locale
+type ProductTranslation struct {
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	Locale      string    `json:"locale" db:"locale" validate:"required,bcp47_language_tag"`
+	Name        string    `json:"name" db:"name" validate:"required,max=255"`
+	Description string    `json:"description" db:"description" validate:"max=1000"`
+}
+
+// SetTranslationRequest represents the request payload for storing a
+// product's translation; the locale is taken from the path
+type SetTranslationRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+// ErrTranslationNotFound is returned when deleting a translation the product does not have
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// FullProduct is a product together with all of its related entities
+type FullProduct struct {
+	Product
+	Images       []ProductImage       `json:"images"`
+	Variants     []ProductVariant     `json:"variants"`
+	Tags         []string             `json:"tags"`
+	Translations []ProductTranslation `json:"translations"`
+	PriceTiers   []PriceTier          `json:"price_tiers"`
+}
+
+// ProductRelations holds related entities loaded in bulk for a set of products
+type ProductRelations struct {
+	Images       []ProductImage
+	Variants     []ProductVariant
+	Tags         []ProductTag
+	Translations []ProductTranslation
+	PriceTiers   []PriceTier
+}
+
+// AssembleFullProducts groups bulk-loaded relations onto their products;
+// products without a given relation get an empty list rather than null
+func AssembleFullProducts(products []Product, rel *ProductRelations) []FullProduct {
+	index := make(map[uuid.UUID]int, len(products))
+	full := make([]FullProduct, len(products))
+	for i, p := range products {
+		index[p.ID] = i
+		full[i] = FullProduct{
+			Product:      p,
+			Images:       []ProductImage{},
+			Variants:     []ProductVariant{},
+			Tags:         []string{},
+			Translations: []ProductTranslation{},
+			PriceTiers:   []PriceTier{},
+		}
+	}
+
+	for _, img := range rel.Images {
+		if i, ok := index[img.ProductID]; ok {
+			full[i].Images = append(full[i].Images, img)
+		}
+	}
+	for _, v := range rel.Variants {
+		if i, ok := index[v.ProductID]; ok {
+			full[i].Variants = append(full[i].Variants, v)
+		}
+	}
+	for _, t := range rel.Tags {
+		if i, ok := index[t.ProductID]; ok {
+			full[i].Tags = append(full[i].Tags, t.Tag)
+		}
+	}
+	for _, t := range rel.Translations {
+		if i, ok := index[t.ProductID]; ok {
+			full[i].Translations = append(full[i].Translations, t)
+		}
+	}
+	for _, t := range rel.PriceTiers {
+		if i, ok := index[t.ProductID]; ok {
+			full[i].PriceTiers = append(full[i].PriceTiers, t)
+		}
+	}
+	return full
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// ReorderFilter represents options for listing reorder suggestions
+type ReorderFilter struct {
+	Limit  int `form:"limit,default=50" validate:"min=1,max=500"`
+	Offset int `form:"offset,default=0" validate:"gte=0"`
+}
+
+// ReorderSuggestion represents a product at or below its reorder level and
+// the quantity to order to cover the target number of days
+type ReorderSuggestion struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	SKU           string    `json:"sku"`
+	Name          string    `json:"name"`
+	Available     int       `json:"available"`
+	ReorderLevel  int       `json:"reorder_level"`
+	DailyVelocity float64   `json:"daily_velocity"`
+	SuggestedQty  int       `json:"suggested_qty"`
+}
+
+// SuggestReorder returns the suggestion for p given its average daily sales.
+// The order brings available stock up to coverDays of sales, and always
+// above the reorder level so a product with no recent sales is not left at it.
+func SuggestReorder(p *Product, dailyVelocity float64, coverDays int) ReorderSuggestion {
+	level := 0
+	if p.ReorderLevel != nil {
+		level = *p.ReorderLevel
+	}
+	available := p.AvailableQty()
+
+	target := int(math.Ceil(dailyVelocity * float64(coverDays)))
+	if target <= level {
+		target = level + 1
+	}
+	qty := target - available
+	if qty < 0 {
+		qty = 0
+	}
+
+	return ReorderSuggestion{
+		ProductID:     p.ID,
+		SKU:           p.SKU,
+		Name:          p.Name,
+		Available:     available,
+		ReorderLevel:  level,
+		DailyVelocity: dailyVelocity,
+		SuggestedQty:  qty,
+	}
+}
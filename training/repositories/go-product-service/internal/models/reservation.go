@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation represents stock held for an order until it is committed, released or expires
+type Reservation struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Qty       int       `json:"qty" db:"qty"`
+	Reference string    `json:"reference,omitempty" db:"reference"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReserveBatchRequest represents the request payload for reserving several line items together
+type ReserveBatchRequest struct {
+	Items      []StockLineItem `json:"items" validate:"required,min=1,max=100,dive"`
+	Reference  string          `json:"reference" validate:"max=255"`
+	TTLSeconds int             `json:"ttl_seconds" validate:"omitempty,min=1,max=86400"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxSaleWindow caps how far ahead upcoming sales can be listed
+const MaxSaleWindow = 90 * 24 * time.Hour
+
+// UpcomingSalesFilter represents options for listing sales that have not started yet
+type UpcomingSalesFilter struct {
+	Within string `form:"within,default=7d"`
+	Limit  int    `form:"limit,default=50" validate:"min=1,max=500"`
+	Offset int    `form:"offset,default=0" validate:"gte=0"`
+}
+
+// ParseWindow parses a look-ahead window such as "7d", "12h" or "90m"; days
+// are accepted in addition to the units of time.ParseDuration
+func ParseWindow(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 || d > MaxSaleWindow {
+		return 0, fmt.Errorf("invalid window %q: must be a positive duration up to 90d", s)
+	}
+	return d, nil
+}
+
+// OnSale reports whether the product's sale window contains now; a sale
+// without an end runs until it is removed
+func (p *Product) OnSale(now time.Time) bool {
+	if p.SalePrice == nil || p.SaleStartsAt == nil || now.Before(*p.SaleStartsAt) {
+		return false
+	}
+	return p.SaleEndsAt == nil || now.Before(*p.SaleEndsAt)
+}
+
+// ActiveSalePrices returns the sale price of every product on sale at now
+func ActiveSalePrices(products map[uuid.UUID]*Product, now time.Time) map[uuid.UUID]float64 {
+	prices := make(map[uuid.UUID]float64)
+	for id, p := range products {
+		if p.OnSale(now) {
+			prices[id] = *p.SalePrice
+		}
+	}
+	return prices
+}
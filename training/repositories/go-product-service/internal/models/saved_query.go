@@ -0,0 +1,69 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Saved query errors
+var (
+	// ErrSavedQueryNotFound is returned when a saved query name does not exist
+	ErrSavedQueryNotFound = errors.New("saved query not found")
+	// ErrSavedQueryExists is returned when creating a saved query under a taken name
+	ErrSavedQueryExists = errors.New("saved query already exists")
+)
+
+// SavedQuery is a named product filter shared across the team
+type SavedQuery struct {
+	Name        string      `json:"name" db:"name"`
+	Description string      `json:"description" db:"description"`
+	Filter      SavedFilter `json:"filter" db:"filter"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// SaveQueryRequest represents the request payload for creating or replacing
+// a saved query; names are URL-safe so they can be used as query parameters
+type SaveQueryRequest struct {
+	Name        string      `json:"name" validate:"required,max=100,hostname_rfc1123"`
+	Description string      `json:"description" validate:"max=1000"`
+	Filter      SavedFilter `json:"filter"`
+}
+
+// SavedFilter is a ProductFilter stored as JSONB
+type SavedFilter ProductFilter
+
+// Value implements driver.Valuer
+func (f SavedFilter) Value() (driver.Value, error) {
+	return json.Marshal(ProductFilter(f))
+}
+
+// Scan implements sql.Scanner
+func (f *SavedFilter) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into SavedFilter", src)
+	}
+	return json.Unmarshal(data, (*ProductFilter)(f))
+}
+
+// Apply returns the saved filter with the paging of the request filter: the
+// caller picks the page while every condition comes from the saved query
+func (q *SavedQuery) Apply(request *ProductFilter) *ProductFilter {
+	f := ProductFilter(q.Filter)
+	f.Limit = request.Limit
+	f.Offset = request.Offset
+	f.Cursor = request.Cursor
+	f.CoverageWindowDays = request.CoverageWindowDays
+	f.SavedQuery = ""
+	f.ApplyDefaults()
+	return &f
+}
@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+// SwapSKUsRequest represents the request payload for exchanging two products' SKUs
+type SwapSKUsRequest struct {
+	ProductIDs []uuid.UUID `json:"product_ids" validate:"required,len=2,unique"`
+}
+
+// SKUSwapResult reports the SKUs each product holds after a swap
+type SKUSwapResult struct {
+	ProductID uuid.UUID `json:"product_id"`
+	OldSKU    string    `json:"old_sku"`
+	NewSKU    string    `json:"new_sku"`
+}
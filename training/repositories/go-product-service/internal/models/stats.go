@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// CategoryCount represents the number of products in a category
+type CategoryCount struct {
+	Category string `json:"category" db:"category"`
+	Count    int64  `json:"count" db:"count"`
+}
+
+// ProductStats represents the catalog summary shown on the admin dashboard
+type ProductStats struct {
+	TotalProducts  int64           `json:"total_products" db:"total_products"`
+	ActiveProducts int64           `json:"active_products" db:"active_products"`
+	OutOfStock     int64           `json:"out_of_stock" db:"out_of_stock"`
+	InventoryValue float64         `json:"inventory_value" db:"inventory_value"`
+	Categories     []CategoryCount `json:"categories"`
+}
+
+// CreationTimelineFilter represents the bucket size and created_at window of
+// the creation timeline
+type CreationTimelineFilter struct {
+	Interval string    `form:"interval,default=day" validate:"oneof=hour day week month"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" validate:"required"`
+	Until    time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00" validate:"required,gtfield=From"`
+}
+
+// TimelineBucket represents the number of products created in one interval
+type TimelineBucket struct {
+	Bucket time.Time `json:"bucket" db:"bucket"`
+	Count  int64     `json:"count" db:"count"`
+}
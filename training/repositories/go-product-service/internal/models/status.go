@@ -0,0 +1,45 @@
+package models
+
+import "fmt"
+
+// Product lifecycle statuses; only active products have IsActive set
+const (
+	StatusDraft        = "draft"
+	StatusActive       = "active"
+	StatusArchived     = "archived"
+	StatusDiscontinued = "discontinued"
+)
+
+// statusTransitions lists the statuses reachable from each status;
+// discontinued products can be archived but never sold again
+var statusTransitions = map[string][]string{
+	StatusDraft:        {StatusActive, StatusArchived},
+	StatusActive:       {StatusArchived, StatusDiscontinued},
+	StatusArchived:     {StatusActive, StatusDiscontinued},
+	StatusDiscontinued: {StatusArchived},
+}
+
+// TransitionRequest represents a request to move a product to another status
+type TransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft active archived discontinued"`
+}
+
+// TransitionError reports a status change the lifecycle does not allow
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot transition product from %s to %s", e.From, e.To)
+}
+
+// CheckTransition returns a *TransitionError unless from may move to to
+func CheckTransition(from, to string) error {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{From: from, To: to}
+}
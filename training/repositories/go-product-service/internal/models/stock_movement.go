@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock movement reasons
+const (
+	MovementInitial        = "initial"
+	MovementSale           = "sale"
+	MovementRestock        = "restock"
+	MovementAdjustment     = "adjustment"
+	MovementReconciliation = "reconciliation"
+)
+
+// StockMovement represents a single change to a product's stock
+
+type StockMovement struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProductID uuid.UUID `json:"product_id" db:"product_id"`
+	Delta     int       `json:"delta" db:"delta"`
+	Reason    string    `json:"reason" db:"reason" validate:"required,max=50"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OpeningMovement returns the stock movement recording a new product's opening
+// stock, or nil when it starts with none
+func (r *CreateProductRequest) OpeningMovement(productID uuid.UUID) *StockMovement {
+	if r.Stock == 0 {
+		return nil
+	}
+
+	reason := r.OpeningStockReason
+	if reason == "" {
+		reason = MovementInitial
+	}
+	return &StockMovement{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Delta:     r.Stock,
+		Reason:    reason,
+	}
+}
+
+// NeverSoldFilter represents options for listing active products created
+// before a cutoff date that have never recorded a sale
+type NeverSoldFilter struct {
+	CreatedBefore time.Time `form:"created_before" time_format:"2006-01-02" validate:"required"`
+	Limit         int       `form:"limit,default=50" validate:"min=1,max=500"`
+	Offset        int       `form:"offset,default=0" validate:"gte=0"`
+}
+
+// StockAdjustmentRequest represents the request payload for changing a
+// product's stock by a delta; the reason defaults to adjustment
+type StockAdjustmentRequest struct {
+	Delta  int    `json:"delta" validate:"required"`
+	Reason string `json:"reason" validate:"omitempty,oneof=sale restock adjustment"`
+}
+
+// Movement returns the stock movement recording the adjustment
+func (r *StockAdjustmentRequest) Movement(productID uuid.UUID) *StockMovement {
+	reason := r.Reason
+	if reason == "" {
+		reason = MovementAdjustment
+	}
+	return &StockMovement{ID: uuid.New(), ProductID: productID, Delta: r.Delta, Reason: reason}
+}
+
+// StockActivation selects the IsActive changes a stock adjustment makes on
+// its own: deactivating a product whose stock reaches zero, and reactivating
+// one restocked from zero
+type StockActivation struct {
+	DeactivateOnZero    bool
+	ReactivateOnRestock bool
+}
+
+// Product activation events emitted by automatic stock transitions
+const (
+	EventProductDeactivated = "product.deactivated"
+	EventProductReactivated = "product.reactivated"
+)
+
+// ActivationEvent returns the event for a change in IsActive, or an empty string if it didn't change
+func ActivationEvent(wasActive, isActive bool) string {
+	switch {
+	case wasActive && !isActive:
+		return EventProductDeactivated
+	case !wasActive && isActive:
+		return EventProductReactivated
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIncompleteUpsert is returned when an upserting update for a missing
+// product lacks fields a create requires
+var ErrIncompleteUpsert = errors.New("upsert requires every field a create requires")
+
+// UpdateQuery represents the query options of a product update; with Upsert
+// an update to a missing ID creates the product instead of returning 404
+type UpdateQuery struct {
+	Upsert bool `form:"upsert"`
+}
+
+// CreateRequest returns the update as a create request for an upsert of a
+// missing product, so the new product passes the same validation a normal
+// create does
+func (r *UpdateProductRequest) CreateRequest() (*CreateProductRequest, error) {
+	var missing []string
+	if r.Name == nil {
+		missing = append(missing, "name")
+	}
+	if r.Price == nil {
+		missing = append(missing, "price")
+	}
+	if r.Category == nil {
+		missing = append(missing, "category")
+	}
+	if r.SKU == nil {
+		missing = append(missing, "sku")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing %s", ErrIncompleteUpsert, strings.Join(missing, ", "))
+	}
+
+	req := &CreateProductRequest{
+		Name:     *r.Name,
+		Price:    *r.Price,
+		Cost:     r.Cost,
+		Category: *r.Category,
+		SKU:      *r.SKU,
+		Barcode:  r.Barcode,
+		IsActive: r.IsActive,
+		Metadata: r.Metadata,
+	}
+	if r.Description != nil {
+		req.Description = *r.Description
+	}
+	if r.Stock != nil {
+		req.Stock = *r.Stock
+	}
+	return req, nil
+}
@@ -0,0 +1,220 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/jobs"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// Outbox row statuses
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// maxBackoff caps the delay between retries of a failing event
+const maxBackoff = time.Hour
+
+// ErrNotDead is returned when retrying an event that is not dead-lettered
+var ErrNotDead = errors.New("outbox event not found or not dead")
+
+// Entry represents an outbox row
+type Entry struct {
+	ID        uuid.UUID    `json:"id"`
+	Event     events.Event `json:"event"`
+	Status    string       `json:"status"`
+	Attempts  int          `json:"attempts"`
+	LastError *string      `json:"last_error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Execer is implemented by *sql.DB and *sql.Tx
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+const insertEvent = `INSERT INTO outbox_events (event_type, product_id, payload, created_at) VALUES ($1, $2, $3, $4)`
+
+// Enqueue stores e for delivery; pass the transaction of the change the
+// event describes so both commit or roll back together
+func Enqueue(ctx context.Context, db Execer, e events.Event) error {
+	var payload interface{}
+	if len(e.Payload) > 0 {
+		payload = string(e.Payload)
+	}
+	_, err := db.ExecContext(ctx, insertEvent, e.Type, e.ProductID, payload, e.OccurredAt)
+	return err
+}
+
+// EnqueueProduct stores a lifecycle event such as models.EventProductCreated
+// carrying p as its payload; call it with the transaction that wrote p
+func EnqueueProduct(ctx context.Context, tx Execer, eventType string, p *models.Product) error {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return Enqueue(ctx, tx, events.Event{
+		Type:       eventType,
+		ProductID:  p.ID,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	})
+}
+
+// claimPending locks due events; SKIP LOCKED lets several instances relay
+// concurrently without delivering the same row twice at once
+const claimPending = `SELECT id, event_type, product_id, payload, attempts, created_at
+FROM outbox_events
+WHERE status = 'pending' AND available_at <= NOW()
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED`
+
+const markDelivered = `UPDATE outbox_events SET status = 'delivered', attempts = attempts + 1, last_error = NULL, delivered_at = NOW() WHERE id = $1`
+
+const markFailed = `UPDATE outbox_events
+SET status = $2, attempts = attempts + 1, last_error = $3, available_at = NOW() + $4 * INTERVAL '1 millisecond'
+WHERE id = $1`
+
+// Relay delivers pending outbox events to a sink with at-least-once
+// semantics. Each event is delivered on its own, so a poison event only
+// delays itself; after maxAttempts failures it is dead-lettered.
+type Relay struct {
+	db          *sql.DB
+	sink        events.Sink
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewRelay creates a relay; failed events are retried after backoff,
+// doubling per attempt up to an hour
+func NewRelay(db *sql.DB, sink events.Sink, batchSize, maxAttempts int, backoff time.Duration) *Relay {
+	return &Relay{db: db, sink: sink, batchSize: batchSize, maxAttempts: maxAttempts, backoff: backoff}
+}
+
+// RunOnce delivers one batch of due events and reports how many were
+// delivered, failed and dead-lettered
+func (r *Relay) RunOnce(ctx context.Context) (delivered, failed, dead int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, claimPending, r.batchSize)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var claimed []Entry
+	for rows.Next() {
+		var e Entry
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Event.Type, &e.Event.ProductID, &payload, &e.Attempts, &e.CreatedAt); err != nil {
+			rows.Close()
+			return 0, 0, 0, err
+		}
+		e.Event.Payload = payload
+		e.Event.OccurredAt = e.CreatedAt
+		claimed = append(claimed, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	for _, e := range claimed {
+		if deliverErr := r.sink.Deliver(ctx, []events.Event{e.Event}); deliverErr == nil {
+			if _, err := tx.ExecContext(ctx, markDelivered, e.ID); err != nil {
+				return 0, 0, 0, err
+			}
+			delivered++
+		} else {
+			status := StatusPending
+			if e.Attempts+1 >= r.maxAttempts {
+				status = StatusDead
+				dead++
+			} else {
+				failed++
+			}
+			delay := r.backoff << e.Attempts
+			if delay <= 0 || delay > maxBackoff {
+				delay = maxBackoff
+			}
+			if _, err := tx.ExecContext(ctx, markFailed, e.ID, status, deliverErr.Error(), delay.Milliseconds()); err != nil {
+				return 0, 0, 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, 0, err
+	}
+	return delivered, failed, dead, nil
+}
+
+// Job schedules the relay on a jobs.Runner
+func (r *Relay) Job(interval time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "outbox-relay",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, _, _, err := r.RunOnce(ctx)
+			return err
+		},
+	}
+}
+
+const listDead = `SELECT id, event_type, product_id, payload, status, attempts, last_error, created_at
+FROM outbox_events
+WHERE status = 'dead'
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2`
+
+// ListDead returns dead-lettered events, newest first
+func ListDead(ctx context.Context, db *sql.DB, page *models.PageFilter) ([]Entry, error) {
+	rows, err := db.QueryContext(ctx, listDead, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Event.Type, &e.Event.ProductID, &payload, &e.Status, &e.Attempts, &e.LastError, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Event.Payload = payload
+		e.Event.OccurredAt = e.CreatedAt
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+const requeueDead = `UPDATE outbox_events SET status = 'pending', attempts = 0, available_at = NOW() WHERE id = $1 AND status = 'dead'`
+
+// Retry requeues a dead-lettered event with a fresh attempt budget
+func Retry(ctx context.Context, db *sql.DB, id uuid.UUID) error {
+	res, err := db.ExecContext(ctx, requeueDead, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotDead
+	}
+	return nil
+}
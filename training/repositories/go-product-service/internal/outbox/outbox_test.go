@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/events"
+	"github.com/google/uuid"
+)
+
+// failingSink refuses every delivery
+type failingSink struct{ calls int }
+
+func (s *failingSink) Deliver(context.Context, []events.Event) error {
+	s.calls++
+	return errors.New("webhook unavailable")
+}
+
+func TestAlwaysFailingEventIsDeadLetteredAfterMaxAttempts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sink := &failingSink{}
+	const maxAttempts = 3
+	relay := NewRelay(db, sink, 10, maxAttempts, time.Second)
+	id, productID := uuid.New(), uuid.New()
+
+	for attempts := 0; attempts < maxAttempts; attempts++ {
+		status, backoff := StatusPending, (time.Second << attempts).Milliseconds()
+		if attempts == maxAttempts-1 {
+			status = StatusDead
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id, event_type, product_id, payload, attempts, created_at FROM outbox_events").WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "product_id", "payload", "attempts", "created_at"}).
+				AddRow(id, "product.updated", productID, []byte(`{}`), attempts, time.Now()))
+		mock.ExpectExec("UPDATE outbox_events SET status = \\$2").
+			WithArgs(id, status, "webhook unavailable", backoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		delivered, failed, dead, err := relay.RunOnce(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantFailed, wantDead := 1, 0
+		if status == StatusDead {
+			wantFailed, wantDead = 0, 1
+		}
+		if delivered != 0 || failed != wantFailed || dead != wantDead {
+			t.Fatalf("attempt %d: expected %d failed and %d dead, got %d delivered, %d failed, %d dead",
+				attempts+1, wantFailed, wantDead, delivered, failed, dead)
+		}
+	}
+	if sink.calls != maxAttempts {
+		t.Fatalf("expected %d deliveries, got %d", maxAttempts, sink.calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryRequeuesOnlyDeadEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	id := uuid.New()
+	mock.ExpectExec("UPDATE outbox_events SET status = 'pending', attempts = 0").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE outbox_events SET status = 'pending', attempts = 0").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := Retry(context.Background(), db, id); err != nil {
+		t.Fatalf("expected the dead event to be requeued, got %v", err)
+	}
+	if err := Retry(context.Background(), db, id); !errors.Is(err, ErrNotDead) {
+		t.Fatalf("expected ErrNotDead, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
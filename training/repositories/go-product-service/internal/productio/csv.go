@@ -0,0 +1,232 @@
+package productio
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+)
+
+// RowError describes a CSV row that could not be decoded
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader decodes product create requests from CSV using a column mapping
+type Reader struct {
+	// Decimal selects the decimal separator for prices: DecimalDot (the
+	// default when empty), DecimalComma or DecimalAuto
+	Decimal string
+
+	csv     *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+// NewReader reads the header row and resolves the mapping against it
+func NewReader(r io.Reader, mapping Mapping) (*Reader, error) {
+	if err := mapping.ValidateForImport(); err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		positions[strings.TrimSpace(h)] = i
+	}
+
+	columns := make(map[string]int, len(mapping))
+	for _, col := range mapping {
+		pos, ok := positions[col.Header]
+		if !ok {
+			return nil, fmt.Errorf("%w: header %q not found in file", ErrInvalidMapping, col.Header)
+		}
+		columns[col.Field] = pos
+	}
+
+	// Rows may omit trailing optional columns
+	cr.FieldsPerRecord = -1
+
+	return &Reader{csv: cr, columns: columns}, nil
+}
+
+// Read decodes the next row; it returns io.EOF when the input is exhausted
+// and a *RowError when a single row is malformed
+func (r *Reader) Read() (*models.CreateProductRequest, error) {
+	record, err := r.csv.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{Line: parseErr.Line, Err: parseErr.Err}
+		}
+		return nil, err
+	}
+	line, _ := r.csv.FieldPos(0)
+	r.line = line
+
+	value := func(field string) string {
+		pos, ok := r.columns[field]
+		if !ok || pos >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[pos])
+	}
+
+	req := &models.CreateProductRequest{
+		Name:        value(FieldName),
+		Description: value(FieldDescription),
+		Category:    value(FieldCategory),
+		SKU:         value(FieldSKU),
+	}
+
+	if raw := value(FieldPrice); raw != "" {
+		separator := r.Decimal
+		if separator == "" {
+			separator = DecimalDot
+		}
+		price, err := ParseDecimal(raw, separator)
+		if err != nil {
+			return nil, &RowError{Line: line, Err: fmt.Errorf("invalid price %q", raw)}
+		}
+		req.Price = price
+	}
+
+	if raw := value(FieldStock); raw != "" {
+		stock, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, &RowError{Line: line, Err: fmt.Errorf("invalid stock %q", raw)}
+		}
+		req.Stock = stock
+	}
+
+	return req, nil
+}
+
+// Line returns the line of the row last read
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Writer encodes products as CSV using a column mapping
+type Writer struct {
+	csv     *csv.Writer
+	mapping Mapping
+}
+
+// NewWriter creates a writer and emits the header row
+func NewWriter(w io.Writer, mapping Mapping) (*Writer, error) {
+	if err := mapping.ValidateForExport(); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	header := make([]string, len(mapping))
+	for i, col := range mapping {
+		header[i] = col.Header
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &Writer{csv: cw, mapping: mapping}, nil
+}
+
+// Write encodes a single product in the mapped column order
+func (w *Writer) Write(p *models.Product) error {
+	record := make([]string, len(w.mapping))
+	for i, col := range w.mapping {
+		record[i] = formatField(p, col.Field)
+	}
+	return w.csv.Write(record)
+}
+
+// Flush writes any buffered data and reports the first write error
+func (w *Writer) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// formatField renders a single product field for export
+func formatField(p *models.Product, field string) string {
+	switch field {
+	case FieldID:
+		return p.ID.String()
+	case FieldName:
+		return p.Name
+	case FieldDescription:
+		return p.Description
+	case FieldPrice:
+		return strconv.FormatFloat(p.Price, 'f', -1, 64)
+	case FieldCategory:
+		return p.Category
+	case FieldSKU:
+		return p.SKU
+	case FieldStock:
+		return strconv.Itoa(p.Stock)
+	case FieldIsActive:
+		return strconv.FormatBool(p.IsActive)
+	case FieldCreatedAt:
+		return p.CreatedAt.Format(time.RFC3339)
+	case FieldUpdatedAt:
+		return p.UpdatedAt.Format(time.RFC3339)
+	}
+	return ""
+}
+
+// StreamCSV runs a query selecting ProductColumns and writes each product as
+// one CSV row in the mapping's layout, row by row like StreamJSONL
+func StreamCSV(ctx context.Context, db *sql.DB, w io.Writer, mapping Mapping, q string, args ...interface{}) (int64, error) {
+	cw, err := NewWriter(w, mapping)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		p, err := query.ScanProduct(rows)
+		if err != nil {
+			return n, err
+		}
+		if err := cw.Write(p); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+	return n, cw.Flush()
+}
@@ -0,0 +1,141 @@
+package productio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// supplierMapping maps a supplier file whose columns are in a different
+// order and carry different names than the defaults
+var supplierMapping = Mapping{
+	{Field: FieldSKU, Header: "Article No"},
+	{Field: FieldStock, Header: "Qty"},
+	{Field: FieldPrice, Header: "Unit Price"},
+	{Field: FieldName, Header: "Title"},
+	{Field: FieldCategory, Header: "Group"},
+}
+
+func TestReaderMapsNonDefaultColumnOrder(t *testing.T) {
+	input := "Qty,Group,Ignored,Title,Article No,Unit Price\n" +
+		"4,furniture,x,Oak desk,DESK-1,120.5\n" +
+		"0,lighting,y,Lamp,LAMP-1,20\n"
+	r, err := NewReader(strings.NewReader(input), supplierMapping)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	want := []models.CreateProductRequest{
+		{Name: "Oak desk", Price: 120.5, Category: "furniture", SKU: "DESK-1", Stock: 4},
+		{Name: "Lamp", Price: 20, Category: "lighting", SKU: "LAMP-1"},
+	}
+	for i, w := range want {
+		got, err := r.Read()
+		if err != nil {
+			t.Fatalf("row %d: %v", i, err)
+		}
+		if got.Name != w.Name || got.Price != w.Price || got.Category != w.Category || got.SKU != w.SKU || got.Stock != w.Stock {
+			t.Fatalf("row %d: got %+v, want %+v", i, got, w)
+		}
+		if r.Line() != i+2 {
+			t.Fatalf("row %d: expected line %d, got %d", i, i+2, r.Line())
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestNewReaderRequiresMappedFields(t *testing.T) {
+	incomplete := Mapping{{Field: FieldName, Header: "Title"}, {Field: FieldPrice, Header: "Unit Price"}}
+	_, err := NewReader(strings.NewReader("Title,Unit Price\n"), incomplete)
+	if !errors.Is(err, ErrInvalidMapping) || !strings.Contains(err.Error(), "category, sku") {
+		t.Fatalf("expected the unmapped required fields to be reported, got %v", err)
+	}
+
+	_, err = NewReader(strings.NewReader("Title,Unit Price,Group\n"), supplierMapping)
+	if !errors.Is(err, ErrInvalidMapping) {
+		t.Fatalf("expected a header missing from the file to be rejected, got %v", err)
+	}
+}
+
+func TestWriterFollowsMappingOrder(t *testing.T) {
+	var sb strings.Builder
+	w, err := NewWriter(&sb, Mapping{{Field: FieldSKU, Header: "Article No"}, {Field: FieldName, Header: "Title"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(&models.Product{Name: "Oak desk", SKU: "DESK-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sb.String(), "Article No,Title\nDESK-1,Oak desk\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// requiredMapping maps only the fields an import requires
+var requiredMapping = Mapping{
+	{Field: FieldName, Header: FieldName},
+	{Field: FieldPrice, Header: FieldPrice},
+	{Field: FieldCategory, Header: FieldCategory},
+	{Field: FieldSKU, Header: FieldSKU},
+}
+
+func TestReaderParsesDecimalSeparators(t *testing.T) {
+	cases := []struct {
+		decimal string
+		prices  []string
+		want    []float64
+	}{
+		{DecimalDot, []string{"19.99", `"1,234.56"`}, []float64{19.99, 1234.56}},
+		{DecimalComma, []string{`"19,99"`, `"1.234,56"`}, []float64{19.99, 1234.56}},
+		{DecimalAuto, []string{`"19,99"`, `"1,234.56"`}, []float64{19.99, 1234.56}},
+	}
+	for _, tc := range cases {
+		input := "name,price,category,sku\n"
+		for i, price := range tc.prices {
+			input += "Desk," + price + ",furniture,DESK-" + string(rune('1'+i)) + "\n"
+		}
+		r, err := NewReader(strings.NewReader(input), requiredMapping)
+		if err != nil {
+			t.Fatalf("NewReader: %v", err)
+		}
+		r.Decimal = tc.decimal
+		for i, want := range tc.want {
+			got, err := r.Read()
+			if err != nil {
+				t.Fatalf("%s separator, row %d: %v", tc.decimal, i, err)
+			}
+			if got.Price != want {
+				t.Fatalf("%s separator, row %d: expected %v, got %v", tc.decimal, i, want, got.Price)
+			}
+		}
+	}
+}
+
+func TestReaderReportsUnparseablePrices(t *testing.T) {
+	input := "name,price,category,sku\n" +
+		`Desk,"19,99",furniture,DESK-1` + "\n" +
+		"Lamp,cheap,lighting,LAMP-1\n" +
+		"Chair,45.5,furniture,CHAIR-1\n"
+	r, err := NewReader(strings.NewReader(input), requiredMapping)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	for _, line := range []int{2, 3} {
+		_, err := r.Read()
+		var rowErr *RowError
+		if !errors.As(err, &rowErr) || rowErr.Line != line {
+			t.Fatalf("expected a row error on line %d, got %v", line, err)
+		}
+	}
+	if got, err := r.Read(); err != nil || got.Price != 45.5 {
+		t.Fatalf("expected reading to continue past bad rows, got %+v, %v", got, err)
+	}
+}
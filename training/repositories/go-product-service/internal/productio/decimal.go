@@ -0,0 +1,93 @@
+package productio
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Decimal separators accepted by CSV import
+const (
+	DecimalDot   = "."
+	DecimalComma = ","
+	DecimalAuto  = "auto"
+)
+
+// errInvalidNumber is wrapped into the row error for an unparseable number
+var errInvalidNumber = errors.New("not a number")
+
+// ParseDecimal parses a number written with the given decimal separator; the
+// other separator is accepted only as a thousands separator in groups of
+// three, so "1.234,56" parses with DecimalComma but "19,99" is rejected with
+// DecimalDot rather than read as 1999. DecimalAuto takes the last separator
+// as the decimal one when both appear, and a single separator as decimal.
+func ParseDecimal(raw, separator string) (float64, error) {
+	s := strings.TrimSpace(raw)
+	if separator == DecimalAuto {
+		separator = detectSeparator(s)
+	}
+
+	group := DecimalComma
+	if separator == DecimalComma {
+		group = DecimalDot
+	}
+
+	intPart, frac, hasFrac := strings.Cut(s, separator)
+	if hasFrac && strings.Contains(frac, separator) {
+		return 0, errInvalidNumber
+	}
+	if strings.Contains(intPart, group) {
+		grouped, ok := ungroup(intPart, group)
+		if !ok {
+			return 0, errInvalidNumber
+		}
+		intPart = grouped
+	}
+	if hasFrac && strings.Contains(frac, group) {
+		return 0, errInvalidNumber
+	}
+
+	normalized := intPart
+	if hasFrac {
+		normalized += "." + frac
+	}
+	v, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, errInvalidNumber
+	}
+	return v, nil
+}
+
+// detectSeparator guesses the decimal separator of s
+func detectSeparator(s string) string {
+	dot, comma := strings.LastIndex(s, DecimalDot), strings.LastIndex(s, DecimalComma)
+	switch {
+	case dot >= 0 && comma >= 0:
+		if comma > dot {
+			return DecimalComma
+		}
+		return DecimalDot
+	case comma >= 0 && strings.Count(s, DecimalComma) == 1:
+		return DecimalComma
+	case dot >= 0 && strings.Count(s, DecimalDot) > 1:
+		return DecimalComma
+	default:
+		return DecimalDot
+	}
+}
+
+// ungroup removes thousands separators, checking that every group after
+// the first has exactly three digits
+func ungroup(s, group string) (string, bool) {
+	parts := strings.Split(s, group)
+	first := strings.TrimLeft(parts[0], "+-")
+	if len(first) == 0 || len(first) > 3 {
+		return "", false
+	}
+	for _, p := range parts[1:] {
+		if len(p) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(parts, ""), true
+}
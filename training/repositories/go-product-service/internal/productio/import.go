@@ -0,0 +1,214 @@
+package productio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Import batch limits; PostgreSQL accepts at most 65535 bind parameters per statement
+const (
+	DefaultImportBatchSize = 500
+	MaxImportBatchSize     = 5000
+	DefaultMaxImportErrors = 1000
+)
+
+// RowReader yields create requests one row at a time; Reader and JSONLReader
+// implement it. Read returns io.EOF at the end and a *RowError for a row that
+// cannot be decoded, after which reading continues.
+type RowReader interface {
+	Read() (*models.CreateProductRequest, error)
+	Line() int
+}
+
+// ImportOptions controls a streamed import. With Upsert, rows whose SKU
+// already exists update that product instead of failing, and rows whose
+// content hash matches the stored one are skipped without a write.
+type ImportOptions struct {
+	Upsert        bool
+	BatchSize     int
+	MaxErrors     int
+	DefaultActive bool
+	Privileged    bool
+	// Normalize trims each row's text fields before validation, and with
+	// TitleCase title-cases its name
+	Normalize bool
+	TitleCase bool
+	// PricePlaces bounds the decimal places of each row's price; zero skips the check
+	PricePlaces int
+	// PriceGuard is the lowest price a row may carry
+	PriceGuard models.PriceGuard
+}
+
+// pendingRow is a validated row waiting for its batch insert
+type pendingRow struct {
+	id   uuid.UUID
+	line int
+	req  *models.CreateProductRequest
+}
+
+// Import streams rows from r, validates each with the CreateProductRequest
+// rules and inserts the valid ones with one multi-row INSERT per batch, each
+// batch in its own transaction together with its opening stock movements.
+// Row problems, including SKUs that already exist or repeat within the
+// import, go into the report; a database failure stops the import and
+// returns the report so far with the error.
+func Import(ctx context.Context, db *sql.DB, validate *validator.Validate, r RowReader, opts ImportOptions) (*models.ImportReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	if batchSize > MaxImportBatchSize {
+		batchSize = MaxImportBatchSize
+	}
+	maxErrors := opts.MaxErrors
+	if maxErrors <= 0 {
+		maxErrors = DefaultMaxImportErrors
+	}
+
+	report := &models.ImportReport{Errors: []models.ImportRowError{}}
+	seen := make(map[string]int)
+	batch := make([]pendingRow, 0, batchSize)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		req, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var rowErr *RowError
+			if !errors.As(err, &rowErr) {
+				return report, err
+			}
+			report.AddError(models.ImportRowError{Line: rowErr.Line, Error: rowErr.Err.Error()}, maxErrors)
+			continue
+		}
+		line := r.Line()
+
+		if opts.Normalize {
+			req.Normalize(opts.TitleCase)
+		}
+		if err := validate.Struct(req); err != nil {
+			report.AddError(models.ImportRowError{Line: line, SKU: req.SKU, Error: err.Error()}, maxErrors)
+			continue
+		}
+		if err := req.Metadata.Validate(); err != nil {
+			report.AddError(models.ImportRowError{Line: line, SKU: req.SKU, Error: err.Error()}, maxErrors)
+			continue
+		}
+		if err := opts.PriceGuard.Check("list", req.Price); err != nil {
+			report.AddError(models.ImportRowError{Line: line, SKU: req.SKU, Error: err.Error()}, maxErrors)
+			continue
+		}
+		if opts.PricePlaces > 0 {
+			if err := currency.CheckPrecision(req.Price, opts.PricePlaces); err != nil {
+				report.AddError(models.ImportRowError{Line: line, SKU: req.SKU, Error: err.Error()}, maxErrors)
+				continue
+			}
+		}
+		if first, ok := seen[req.SKU]; ok {
+			report.AddError(models.ImportRowError{Line: line, SKU: req.SKU, Error: fmt.Sprintf("sku %q duplicates line %d", req.SKU, first)}, maxErrors)
+			continue
+		}
+		seen[req.SKU] = line
+
+		batch = append(batch, pendingRow{id: uuid.New(), line: line, req: req})
+		if len(batch) == batchSize {
+			if err := writeBatch(ctx, db, batch, opts, report, maxErrors); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := writeBatch(ctx, db, batch, opts, report, maxErrors); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// writeBatch inserts or upserts one batch as opts selects
+func writeBatch(ctx context.Context, db *sql.DB, batch []pendingRow, opts ImportOptions, report *models.ImportReport, maxErrors int) error {
+	if opts.Upsert {
+		return upsertBatch(ctx, db, batch, opts, report)
+	}
+	return insertBatch(ctx, db, batch, opts, report, maxErrors)
+}
+
+// insertBatch inserts one batch and its opening stock movements, reporting
+// rows skipped because their SKU already exists
+func insertBatch(ctx context.Context, db *sql.DB, batch []pendingRow, opts ImportOptions, report *models.ImportReport, maxErrors int) error {
+	caller := auth.Caller(ctx)
+	args := make([]interface{}, 0, len(batch)*13)
+	for _, row := range batch {
+		req := row.req
+		args = append(args, row.id, req.Name, req.Description, req.Price, req.Cost, req.Category, req.SKU, req.Barcode,
+			req.Stock, req.InitialActive(opts.DefaultActive, opts.Privileged), req.Metadata, req.ContentHash(), caller)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query.InsertProductsBatch(len(batch)), args...)
+	if err != nil {
+		return err
+	}
+	inserted := make(map[string]bool, len(batch))
+	for rows.Next() {
+		var sku string
+		if err := rows.Scan(&sku); err != nil {
+			rows.Close()
+			return err
+		}
+		inserted[sku] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var movements []interface{}
+	for _, row := range batch {
+		if !inserted[row.req.SKU] {
+			continue
+		}
+		if m := row.req.OpeningMovement(row.id); m != nil {
+			movements = append(movements, m.ID, m.ProductID, m.Delta, m.Reason)
+		}
+	}
+	if len(movements) > 0 {
+		if _, err := tx.ExecContext(ctx, query.InsertStockMovementsBatch(len(movements)/4), movements...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, row := range batch {
+		if inserted[row.req.SKU] {
+			report.Created++
+		} else {
+			report.AddError(models.ImportRowError{Line: row.line, SKU: row.req.SKU, Error: fmt.Sprintf("sku %q already exists", row.req.SKU)}, maxErrors)
+		}
+	}
+	return nil
+}
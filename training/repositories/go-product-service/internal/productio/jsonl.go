@@ -0,0 +1,90 @@
+package productio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+
+	"github.com/company/go-product-service/internal/dto"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+)
+
+// MaxJSONLLineBytes bounds a single imported JSON line
+const MaxJSONLLineBytes = 1 << 20
+
+// JSONLReader decodes product create requests from newline-delimited JSON,
+// one object per line; blank lines are skipped
+type JSONLReader struct {
+	// Strict rejects unknown fields, as STRICT_JSON does for single creates
+	Strict bool
+
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewJSONLReader creates a reader over r
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxJSONLLineBytes)
+	return &JSONLReader{scanner: scanner}
+}
+
+// Read decodes the next line; it returns io.EOF when the input is exhausted
+// and a *RowError when a single line is malformed
+func (r *JSONLReader) Read() (*models.CreateProductRequest, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if r.Strict {
+			dec.DisallowUnknownFields()
+		}
+		var req models.CreateProductRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, &RowError{Line: r.line, Err: err}
+		}
+		return &req, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Line returns the line of the row last read
+func (r *JSONLReader) Line() int {
+	return r.line
+}
+
+// StreamJSONL runs a query selecting ProductColumns and writes each product as
+// one JSON line, row by row, so large exports are never held in memory. Lines
+// use the API representation, masked for the caller in ctx.
+func StreamJSONL(ctx context.Context, db *sql.DB, w io.Writer, q string, args ...interface{}) (int64, error) {
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	var n int64
+	for rows.Next() {
+		p, err := query.ScanProduct(rows)
+		if err != nil {
+			return n, err
+		}
+		if err := enc.Encode(dto.NewProduct(ctx, p)); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
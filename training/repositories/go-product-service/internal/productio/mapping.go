@@ -0,0 +1,143 @@
+package productio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// Product fields that can be mapped to a CSV column
+const (
+	FieldID          = "id"
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldPrice       = "price"
+	FieldCategory    = "category"
+	FieldSKU         = "sku"
+	FieldStock       = "stock"
+	FieldIsActive    = "is_active"
+	FieldCreatedAt   = "created_at"
+	FieldUpdatedAt   = "updated_at"
+)
+
+// importFields are the fields accepted when importing products
+var importFields = map[string]bool{
+	FieldName:        true,
+	FieldDescription: true,
+	FieldPrice:       true,
+	FieldCategory:    true,
+	FieldSKU:         true,
+	FieldStock:       true,
+}
+
+// requiredImportFields mirrors the required fields of CreateProductRequest
+var requiredImportFields = []string{FieldName, FieldPrice, FieldCategory, FieldSKU}
+
+// exportFields are the fields available when exporting products
+var exportFields = map[string]bool{
+	FieldID:          true,
+	FieldName:        true,
+	FieldDescription: true,
+	FieldPrice:       true,
+	FieldCategory:    true,
+	FieldSKU:         true,
+	FieldStock:       true,
+	FieldIsActive:    true,
+	FieldCreatedAt:   true,
+	FieldUpdatedAt:   true,
+}
+
+// ErrInvalidMapping is returned when a column mapping cannot be used
+var ErrInvalidMapping = errors.New("invalid column mapping")
+
+// Mapping is an ordered list of columns; the order defines the export layout
+type Mapping []models.MappedColumn
+
+// DefaultImportMapping uses the field names as headers
+func DefaultImportMapping() Mapping {
+	return Mapping{
+		{Field: FieldName, Header: FieldName},
+		{Field: FieldDescription, Header: FieldDescription},
+		{Field: FieldPrice, Header: FieldPrice},
+		{Field: FieldCategory, Header: FieldCategory},
+		{Field: FieldSKU, Header: FieldSKU},
+		{Field: FieldStock, Header: FieldStock},
+	}
+}
+
+// DefaultExportMapping uses the field names as headers
+func DefaultExportMapping() Mapping {
+	return Mapping{
+		{Field: FieldID, Header: FieldID},
+		{Field: FieldName, Header: FieldName},
+		{Field: FieldDescription, Header: FieldDescription},
+		{Field: FieldPrice, Header: FieldPrice},
+		{Field: FieldCategory, Header: FieldCategory},
+		{Field: FieldSKU, Header: FieldSKU},
+		{Field: FieldStock, Header: FieldStock},
+		{Field: FieldIsActive, Header: FieldIsActive},
+		{Field: FieldCreatedAt, Header: FieldCreatedAt},
+		{Field: FieldUpdatedAt, Header: FieldUpdatedAt},
+	}
+}
+
+// ValidateForImport checks that every required field is mapped exactly once
+func (m Mapping) ValidateForImport() error {
+	if err := m.validate(importFields); err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, field := range requiredImportFields {
+		if m.headerFor(field) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: required fields not mapped: %s", ErrInvalidMapping, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ValidateForExport checks that the mapping only references exportable fields
+func (m Mapping) ValidateForExport() error {
+	if len(m) == 0 {
+		return fmt.Errorf("%w: no columns", ErrInvalidMapping)
+	}
+	return m.validate(exportFields)
+}
+
+// validate rejects unknown fields and duplicate fields or headers
+func (m Mapping) validate(allowed map[string]bool) error {
+	fields := make(map[string]bool, len(m))
+	headers := make(map[string]bool, len(m))
+	for _, col := range m {
+		if !allowed[col.Field] {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, col.Field)
+		}
+		if col.Header == "" {
+			return fmt.Errorf("%w: empty header for field %q", ErrInvalidMapping, col.Field)
+		}
+		if fields[col.Field] {
+			return fmt.Errorf("%w: field %q mapped more than once", ErrInvalidMapping, col.Field)
+		}
+		if headers[col.Header] {
+			return fmt.Errorf("%w: header %q used more than once", ErrInvalidMapping, col.Header)
+		}
+		fields[col.Field] = true
+		headers[col.Header] = true
+	}
+	return nil
+}
+
+// headerFor returns the header mapped to field, or an empty string
+func (m Mapping) headerFor(field string) string {
+	for _, col := range m {
+		if col.Field == field {
+			return col.Header
+		}
+	}
+	return ""
+}
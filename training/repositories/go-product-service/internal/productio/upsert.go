@@ -0,0 +1,62 @@
+package productio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/google/uuid"
+)
+
+// upsertBatch upserts one batch by SKU in a transaction. A row whose content
+// hash matches the stored product is skipped by the statement itself, so an
+// unchanged nightly sync costs no writes; new products get their opening
+// stock movement.
+func upsertBatch(ctx context.Context, db *sql.DB, batch []pendingRow, opts ImportOptions, report *models.ImportReport) error {
+	caller := auth.Caller(ctx)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	outcomes := make([]string, len(batch))
+	for i, row := range batch {
+		req := row.req
+		var id uuid.UUID
+		var inserted bool
+		err := tx.QueryRowContext(ctx, query.UpsertProductBySKU,
+			row.id, req.Name, req.Description, req.Price, req.Cost, req.Category, req.SKU, req.Barcode,
+			req.Stock, req.InitialActive(opts.DefaultActive, opts.Privileged), req.Metadata, req.ContentHash(), caller,
+		).Scan(&id, &inserted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			outcomes[i] = models.UpsertSkipped
+			continue
+		case err != nil:
+			return err
+		case !inserted:
+			outcomes[i] = models.UpsertUpdated
+			continue
+		}
+
+		outcomes[i] = models.UpsertCreated
+		if m := req.OpeningMovement(id); m != nil {
+			if _, err := tx.ExecContext(ctx, query.InsertStockMovementsBatch(1), m.ID, m.ProductID, m.Delta, m.Reason); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, outcome := range outcomes {
+		report.Add(outcome)
+	}
+	return nil
+}
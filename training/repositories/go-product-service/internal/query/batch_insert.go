@@ -0,0 +1,48 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// productInsertColumns are the columns InsertProductsBatch writes per row
+const productInsertColumns = 13
+
+// InsertProductsBatch builds a multi-row INSERT of rows products, each bound
+// as id, name, description, price, cost, category, sku, barcode, stock,
+// is_active, metadata, content_hash and created_by. Rows whose SKU is already taken are skipped;
+// the skus that were inserted are returned.
+func InsertProductsBatch(rows int) string {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO products (id, name, description, price, cost, category, sku, barcode, stock, is_active, metadata, content_hash, created_by, created_at, updated_at) VALUES ")
+	writeValues(&sb, rows, productInsertColumns, "NOW(), NOW()")
+	sb.WriteString(" ON CONFLICT (sku) DO NOTHING RETURNING sku")
+	return sb.String()
+}
+
+// InsertStockMovementsBatch builds a multi-row INSERT of rows stock
+// movements, each bound as id, product_id, delta and reason
+func InsertStockMovementsBatch(rows int) string {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO stock_movements (id, product_id, delta, reason, created_at) VALUES ")
+	writeValues(&sb, rows, 4, "NOW()")
+	return sb.String()
+}
+
+// writeValues writes rows placeholder tuples of the given width, each
+// followed by the literal trailing columns
+func writeValues(sb *strings.Builder, rows, width int, trailing string) {
+	n := 1
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for j := 0; j < width; j++ {
+			sb.WriteString("$" + strconv.Itoa(n) + ", ")
+			n++
+		}
+		sb.WriteString(trailing)
+		sb.WriteByte(')')
+	}
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Builder accumulates WHERE conditions and their positional arguments
+type Builder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// Where adds a condition; each "?" in cond is bound to the next arg as $n
+func (b *Builder) Where(cond string, args ...interface{}) {
+	var sb strings.Builder
+	next := 0
+	for _, r := range cond {
+		if r == '?' && next < len(args) {
+			b.args = append(b.args, args[next])
+			next++
+			sb.WriteString("$" + strconv.Itoa(len(b.args)))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	b.conditions = append(b.conditions, sb.String())
+}
+
+// Arg binds a value and returns its placeholder
+func (b *Builder) Arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// WhereClause returns the combined conditions, or an empty string if there are none
+func (b *Builder) WhereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// Args returns the bound arguments in placeholder order
+func (b *Builder) Args() []interface{} {
+	return b.args
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"github.com/company/go-product-service/internal/models"
+	"github.com/lib/pq"
+)
+
+// BulkPriceTargets builds the SELECT for the live products a bulk price
+// change applies to: those in its category and, when given, with its IDs.
+// Rows come in ID order so concurrent applies lock them in the same order;
+// forUpdate locks them for the apply.
+func BulkPriceTargets(req *models.BulkPriceUpdateRequest, forUpdate bool) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	if req.Category != "" {
+		b.Where("category = ?", req.Category)
+	}
+	if len(req.ProductIDs) > 0 {
+		b.Where("id = ANY(?)", pq.Array(req.ProductIDs))
+	}
+
+	sql := "SELECT " + ProductColumns + " FROM products" + b.WhereClause() + " ORDER BY id ASC"
+	if forUpdate {
+		sql += " FOR UPDATE"
+	}
+	return sql, b.Args()
+}
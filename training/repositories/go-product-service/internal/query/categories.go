@@ -0,0 +1,17 @@
+package query
+
+// CategoryInUse reports whether any product uses the category
+const CategoryInUse = `SELECT EXISTS (SELECT 1 FROM products WHERE category = $1 AND deleted_at IS NULL)`
+
+// RenameCategory moves every product from one category name to another,
+// returning the IDs of the products moved
+const RenameCategory = `UPDATE products SET category = $2, updated_at = NOW() WHERE category = $1 RETURNING id`
+
+// ReassignCategory moves the live products of a deleted category to another
+// category, returning the IDs of the products moved
+const ReassignCategory = `UPDATE products SET category = $2, updated_at = NOW() WHERE category = $1 AND deleted_at IS NULL RETURNING id`
+
+// CategoryUsage counts the active and total products referencing a category
+const CategoryUsage = `SELECT COUNT(*) FILTER (WHERE is_active) AS active, COUNT(*) AS total
+FROM products
+WHERE category = $1 AND deleted_at IS NULL`
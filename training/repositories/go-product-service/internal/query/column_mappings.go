@@ -0,0 +1,17 @@
+package query
+
+// ColumnMappingColumns is the column list selected for column mappings
+const ColumnMappingColumns = "id, name, columns, created_at, updated_at"
+
+// GetColumnMapping selects a column mapping by name
+const GetColumnMapping = "SELECT " + ColumnMappingColumns + " FROM column_mappings WHERE name = $1"
+
+// ListColumnMappings selects every column mapping by name
+const ListColumnMappings = "SELECT " + ColumnMappingColumns + " FROM column_mappings ORDER BY name"
+
+// UpsertColumnMapping stores a column mapping, replacing the columns of an
+// existing mapping with the same name
+const UpsertColumnMapping = `INSERT INTO column_mappings (name, columns, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (name) DO UPDATE SET columns = EXCLUDED.columns, updated_at = NOW()
+RETURNING ` + ColumnMappingColumns
@@ -0,0 +1,33 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// salesVelocity returns a SELECT of each product's average daily units sold
+// over the last window days; window is an already bound placeholder
+func salesVelocity(window string) string {
+	return "SELECT product_id, -SUM(delta)::float8 / " + window + "::int AS daily_velocity FROM stock_movements" +
+		" WHERE reason = '" + models.MovementSale + "' AND delta < 0" +
+		" AND created_at >= NOW() - make_interval(days => " + window + "::int)" +
+		" GROUP BY product_id"
+}
+
+// applyMaxCoverage keeps products whose available stock lasts at most
+// MaxCoverageDays of sales. Products without recent sales have infinite
+// coverage: the velocity subquery yields NULL and they never match.
+func applyMaxCoverage(b *Builder, f *models.ProductFilter) {
+	windowDays := f.CoverageWindowDays
+	if windowDays < 1 {
+		windowDays = models.DefaultCoverageWindowDays
+	}
+	window := b.Arg(windowDays)
+	b.Where("stock - reserved <= ? * (SELECT -SUM(m.delta)::float8 / "+window+"::int FROM stock_movements m"+
+		" WHERE m.product_id = products.id AND m.reason = '"+models.MovementSale+"' AND m.delta < 0"+
+		" AND m.created_at >= NOW() - make_interval(days => "+window+"::int))", *f.MaxCoverageDays)
+}
+
+// SalesVelocities selects the average daily sales over the last $2 days for
+// the products in $1; products without sales in the window are omitted
+const SalesVelocities = "SELECT product_id, -SUM(delta)::float8 / $2::int AS daily_velocity FROM stock_movements" +
+	" WHERE product_id = ANY($1) AND reason = '" + models.MovementSale + "' AND delta < 0" +
+	" AND created_at >= NOW() - make_interval(days => $2::int)" +
+	" GROUP BY product_id"
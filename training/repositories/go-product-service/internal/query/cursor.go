@@ -0,0 +1,149 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for a cursor that cannot be decoded or was
+// issued for a different sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorToken is the decoded form of an opaque pagination cursor: the sort
+// it was issued for, the last row's sort value and its id
+type cursorToken struct {
+	SortBy    string          `json:"s"`
+	SortOrder string          `json:"o"`
+	Value     json.RawMessage `json:"v"`
+	ID        uuid.UUID       `json:"id"`
+}
+
+// normalizeSort applies the same defaults as OrderBy
+func normalizeSort(sortBy, sortOrder string) (string, string) {
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if sortOrder == "" {
+		sortOrder = "desc"
+	}
+	return sortBy, strings.ToLower(sortOrder)
+}
+
+// sortValue returns the value of the sort column for p
+func sortValue(sortBy string, p *models.Product) interface{} {
+	switch sortBy {
+	case "name":
+		return p.Name
+	case "price":
+		return p.Price
+	case "category":
+		return p.Category
+	case "sku":
+		return p.SKU
+	case "stock":
+		return p.Stock
+	case "updated_at":
+		return p.UpdatedAt
+	default:
+		return p.CreatedAt
+	}
+}
+
+// EncodeCursor returns the cursor pointing just after p in the filter's sort
+func EncodeCursor(f *models.ProductFilter, p *models.Product) (string, error) {
+	sortBy, sortOrder := normalizeSort(f.SortBy, f.SortOrder)
+	value, err := json.Marshal(sortValue(sortBy, p))
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(cursorToken{SortBy: sortBy, SortOrder: sortOrder, Value: value, ID: p.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor parses a cursor and returns the sort value as the type of
+// the sort column
+func decodeCursor(f *models.ProductFilter) (interface{}, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: not base64", ErrInvalidCursor)
+	}
+	var tok cursorToken
+	if err := json.Unmarshal(raw, &tok); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	sortBy, sortOrder := normalizeSort(f.SortBy, f.SortOrder)
+	if tok.SortBy != sortBy || tok.SortOrder != sortOrder {
+		return nil, uuid.Nil, fmt.Errorf("%w: issued for a different sort", ErrInvalidCursor)
+	}
+	if tok.ID == uuid.Nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: missing id", ErrInvalidCursor)
+	}
+
+	var value interface{}
+	switch sortBy {
+	case "name", "category", "sku":
+		var s string
+		err = json.Unmarshal(tok.Value, &s)
+		value = s
+	case "price":
+		var n float64
+		err = json.Unmarshal(tok.Value, &n)
+		value = n
+	case "stock":
+		var n int
+		err = json.Unmarshal(tok.Value, &n)
+		value = n
+	default:
+		var t time.Time
+		err = json.Unmarshal(tok.Value, &t)
+		value = t
+	}
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: bad sort value", ErrInvalidCursor)
+	}
+	return value, tok.ID, nil
+}
+
+// keysetCondition restricts b to the rows after the filter's cursor; the row
+// comparison on (column, id) matches the ORDER BY built by OrderBy
+func keysetCondition(b *Builder, f *models.ProductFilter) error {
+	value, id, err := decodeCursor(f)
+	if err != nil {
+		return err
+	}
+	sortBy, sortOrder := normalizeSort(f.SortBy, f.SortOrder)
+	op := "<"
+	if sortOrder == "asc" {
+		op = ">"
+	}
+	b.Where("("+sortColumns[sortBy]+", id) "+op+" (?, ?)", value, id)
+	return nil
+}
+
+// Page trims the extra row ListProducts fetches and returns the cursor for
+// the following page, or an empty cursor on the final page and for
+// relevance ordering, which pages by offset
+func Page(f *models.ProductFilter, products []models.Product) ([]models.Product, string, error) {
+	if f.Limit <= 0 || len(products) <= f.Limit {
+		return products, "", nil
+	}
+	products = products[:f.Limit]
+	if f.SortBy == models.SortRelevance {
+		return products, "", nil
+	}
+	next, err := EncodeCursor(f, &products[len(products)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return products, next, nil
+}
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	f := &models.ProductFilter{SortBy: "price", SortOrder: "ASC", Limit: 2}
+	p := &models.Product{ID: uuid.New(), Price: 12.5}
+
+	cursor, err := EncodeCursor(f, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Cursor = cursor
+
+	value, id, err := decodeCursor(f)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if value != 12.5 || id != p.ID {
+		t.Fatalf("expected (12.5, %s), got (%v, %s)", p.ID, value, id)
+	}
+}
+
+func TestCursorRejectsDifferentSort(t *testing.T) {
+	cursor, err := EncodeCursor(&models.ProductFilter{SortBy: "name"}, &models.Product{ID: uuid.New(), Name: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = ListProducts(&models.ProductFilter{SortBy: "price", Cursor: cursor, Limit: 10})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	_, _, err = ListProducts(&models.ProductFilter{Cursor: "!!!", Limit: 10})
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for garbage, got %v", err)
+	}
+}
+
+func TestListProductsWithCursorUsesKeyset(t *testing.T) {
+	p := &models.Product{ID: uuid.New(), CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	f := &models.ProductFilter{Limit: 20, Offset: 40}
+	cursor, err := EncodeCursor(f, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Cursor = cursor
+
+	sql, args, err := ListProducts(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sql, "(created_at, id) < ($1, $2)") {
+		t.Fatalf("expected keyset condition, got %s", sql)
+	}
+	if strings.Contains(sql, "OFFSET") {
+		t.Fatalf("cursor pages must not use OFFSET: %s", sql)
+	}
+	if !strings.HasSuffix(sql, "ORDER BY created_at DESC, id DESC LIMIT $3") || args[2] != 21 {
+		t.Fatalf("expected limit+1 after the order, got %s %v", sql, args)
+	}
+}
+
+func TestPageReturnsNextCursorOnlyWhenMoreRows(t *testing.T) {
+	f := &models.ProductFilter{Limit: 2}
+	rows := []models.Product{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+
+	page, next, err := Page(f, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || next == "" {
+		t.Fatalf("expected two rows and a cursor, got %d rows cursor %q", len(page), next)
+	}
+
+	page, next, err = Page(f, rows[:2])
+	if err != nil || len(page) != 2 || next != "" {
+		t.Fatalf("final page should carry no cursor, got %d rows cursor %q err %v", len(page), next, err)
+	}
+}
+
+func TestSearchModes(t *testing.T) {
+	cases := map[string]string{
+		models.MatchModePrefix: "(name ILIKE $1 OR name ILIKE $2)",
+		models.MatchModeWord:   "search_vector @@ plainto_tsquery('simple', $1)",
+		models.MatchModePhrase: "search_vector @@ phraseto_tsquery('simple', $1)",
+		"":                     "search_vector @@ plainto_tsquery('simple', $3)",
+	}
+	for mode, want := range cases {
+		b := &Builder{}
+		applySearch(b, "wire_less", mode)
+		if got := b.WhereClause(); !strings.Contains(got, want) {
+			t.Errorf("mode %q: expected %q in %q", mode, want, got)
+		}
+		if mode != models.MatchModeWord && mode != models.MatchModePhrase && b.Args()[0] != `wire\_less%` {
+			t.Errorf("mode %q: LIKE wildcards not escaped: %v", mode, b.Args()[0])
+		}
+	}
+}
+
+func TestRelevanceSortOrdersByRank(t *testing.T) {
+	sql, _, err := ListProducts(&models.ProductFilter{Search: "chair", SortBy: models.SortRelevance, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sql, "ORDER BY ts_rank(search_vector, plainto_tsquery('simple', $4)) DESC, id DESC") {
+		t.Fatalf("expected relevance order, got %s", sql)
+	}
+
+	if _, _, err := ListProducts(&models.ProductFilter{SortBy: models.SortRelevance}); !errors.Is(err, ErrInvalidSort) {
+		t.Fatalf("relevance without a search should fail, got %v", err)
+	}
+}
@@ -0,0 +1,7 @@
+package query
+
+// PublishDraft makes a validated draft live; it matches no row when the
+// product is missing or no longer a draft
+const PublishDraft = `UPDATE products SET status = 'active', is_active = TRUE, updated_at = NOW()
+WHERE id = $1 AND status = 'draft' AND deleted_at IS NULL
+RETURNING ` + ProductColumns
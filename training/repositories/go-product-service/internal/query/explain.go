@@ -0,0 +1,28 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// FilterExplanation is the SQL a product filter compiles to, with its bind
+// arguments in placeholder order
+type FilterExplanation struct {
+	SQL       string        `json:"sql"`
+	Args      []interface{} `json:"args"`
+	CountSQL  string        `json:"count_sql"`
+	CountArgs []interface{} `json:"count_args"`
+}
+
+// Explain compiles f exactly as a list request would, without running it.
+// It is a development aid and must not be reachable in production, where
+// it would reveal the schema.
+func Explain(f *models.ProductFilter) (*FilterExplanation, error) {
+	f.ApplyDefaults()
+	sql, args, err := ListProducts(f)
+	if err != nil {
+		return nil, err
+	}
+	countSQL, countArgs, err := CountProducts(f)
+	if err != nil {
+		return nil, err
+	}
+	return &FilterExplanation{SQL: sql, Args: args, CountSQL: countSQL, CountArgs: countArgs}, nil
+}
@@ -0,0 +1,30 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// UpdateFrequency builds the SELECT for live products whose number of
+// audited updates since f.Since falls within [Min, Max], most changed first.
+// Counts come from the audit log, so a window reaching past the audit
+// retention period undercounts.
+func UpdateFrequency(f *models.UpdateFrequencyFilter) (string, []interface{}) {
+	b := &Builder{}
+	since := b.Arg(f.Since)
+	b.Where("deleted_at IS NULL")
+	b.Where("update_count >= ?", f.Min)
+	if f.Max != nil {
+		b.Where("update_count <= ?", *f.Max)
+	}
+
+	sql := "WITH changes AS (" +
+		"SELECT entity_id, COUNT(*) AS n FROM audit_log" +
+		" WHERE entity_type = '" + models.AuditEntityProduct + "' AND action = '" + models.AuditActionUpdate + "'" +
+		" AND created_at >= " + since +
+		" GROUP BY entity_id)" +
+		" SELECT " + ProductColumns + ", update_count FROM (" +
+		"SELECT p.*, COALESCE(c.n, 0) AS update_count FROM products p LEFT JOIN changes c ON c.entity_id = p.id::text" +
+		") AS products" +
+		b.WhereClause() +
+		" ORDER BY update_count DESC, id ASC" +
+		" LIMIT " + b.Arg(f.Limit) + " OFFSET " + b.Arg(f.Offset)
+	return sql, b.Args()
+}
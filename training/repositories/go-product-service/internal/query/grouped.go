@@ -0,0 +1,57 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/lib/pq"
+)
+
+// groupColumns maps the allowed group_by values to the grouping expression
+var groupColumns = map[string]string{
+	"category":  "category",
+	"is_active": "is_active::text",
+}
+
+// GroupedProducts builds a query returning up to ItemsPerGroup products per
+// group, newest first, each row carrying its group key and the group's total
+// size. Rows are ordered by group so they can be folded into buckets in one pass.
+func GroupedProducts(f *models.GroupedProductsFilter) (string, []interface{}, error) {
+	group, ok := groupColumns[f.GroupBy]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid group_by %q", f.GroupBy)
+	}
+
+	b := &Builder{}
+	b.Where("group_rank <= ?", f.ItemsPerGroup)
+
+	sql := "SELECT " + ProductColumns + ", group_key, group_count FROM (" +
+		"SELECT *, " + group + " AS group_key," +
+		" ROW_NUMBER() OVER (PARTITION BY " + group + " ORDER BY created_at DESC, id DESC) AS group_rank," +
+		" COUNT(*) OVER (PARTITION BY " + group + ") AS group_count" +
+		" FROM products WHERE deleted_at IS NULL) AS products" +
+		b.WhereClause() +
+		" ORDER BY group_key ASC, group_rank ASC"
+	return sql, b.Args(), nil
+}
+
+// CategorySample builds a single query returning up to Limit products from
+// each requested category, newest first, ordered by category
+func CategorySample(req *models.CategorySampleRequest) (string, []interface{}) {
+	inner := &Builder{}
+	inner.Where("deleted_at IS NULL")
+	inner.Where("category = ANY(?)", pq.Array(req.Categories))
+	if req.IsActive != nil {
+		inner.Where(ActivePredicate(*req.IsActive))
+	}
+	if req.InStockOnly {
+		inner.Where("stock - reserved > 0")
+	}
+
+	sql := "SELECT " + ProductColumns + " FROM (" +
+		"SELECT *, ROW_NUMBER() OVER (PARTITION BY category ORDER BY created_at DESC, id DESC) AS category_rank" +
+		" FROM products" + inner.WhereClause() + ") AS products" +
+		" WHERE category_rank <= " + inner.Arg(req.Limit) +
+		" ORDER BY category ASC, category_rank ASC"
+	return sql, inner.Args()
+}
@@ -0,0 +1,9 @@
+package query
+
+// ProductIDsBySKU selects the IDs of the live products with any of the SKUs in $1
+const ProductIDsBySKU = "SELECT id, sku FROM products WHERE sku = ANY($1) AND deleted_at IS NULL"
+
+// InsertProductImage attaches an image to a product
+const InsertProductImage = `INSERT INTO product_images (product_id, url, position, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING id`
@@ -0,0 +1,16 @@
+package query
+
+import "time"
+
+// IncrementalExport selects every product, soft-deleted tombstones included,
+// whose updated_at falls in [since, until), oldest change first
+func IncrementalExport(since, until time.Time) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("updated_at >= ?", since)
+	b.Where("updated_at < ?", until)
+	return "SELECT " + ProductColumns + " FROM products" + b.WhereClause() + " ORDER BY updated_at ASC, id ASC", b.Args()
+}
+
+// CatalogSnapshot selects every live product in a stable order for a full
+// catalog dump
+const CatalogSnapshot = "SELECT " + ProductColumns + " FROM products WHERE deleted_at IS NULL ORDER BY id ASC"
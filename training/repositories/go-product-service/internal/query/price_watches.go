@@ -0,0 +1,20 @@
+package query
+
+// PriceWatchColumns is the column list selected for price watches
+const PriceWatchColumns = "id, product_id, email, created_at"
+
+// InsertPriceWatch watches a live product's price for an email; watching it
+// again returns the existing watch, and no row is returned for a missing product
+const InsertPriceWatch = `INSERT INTO price_watches (product_id, email, created_at)
+SELECT $1, $2, NOW() WHERE EXISTS (SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)
+ON CONFLICT (product_id, email) DO UPDATE SET email = EXCLUDED.email
+RETURNING ` + PriceWatchColumns
+
+// DeletePriceWatch removes a product's watch by ID
+const DeletePriceWatch = "DELETE FROM price_watches WHERE id = $1 AND product_id = $2"
+
+// PriceWatchesByProduct selects every watch on a product
+const PriceWatchesByProduct = "SELECT " + PriceWatchColumns + " FROM price_watches WHERE product_id = $1 ORDER BY created_at"
+
+// ClearPriceWatches removes every watch on a product once its watchers were notified
+const ClearPriceWatches = "DELETE FROM price_watches WHERE product_id = $1"
@@ -0,0 +1,248 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/lib/pq"
+)
+
+// ProductColumns is the column list selected for products
+const ProductColumns = "id, name, description, price, currency, sale_price, sale_starts_at, sale_ends_at, cost, category, sku, barcode, stock, reserved, reorder_level, is_active, status, metadata, content_hash, created_at, updated_at, deleted_at, created_by"
+
+// ErrInvalidSort is returned for a sort column or order outside the allowlist
+var ErrInvalidSort = errors.New("invalid sort")
+
+// sortColumns maps the API sort keys to product columns
+var sortColumns = map[string]string{
+	"name":       "name",
+	"price":      "price",
+	"category":   "category",
+	"sku":        "sku",
+	"stock":      "stock",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// OrderBy returns the ORDER BY clause for a sort; id is always appended as a
+// tie-breaker so rows sharing the sort value keep a stable order across pages
+func OrderBy(sortBy, sortOrder string) (string, error) {
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	column, ok := sortColumns[sortBy]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown sort column %q", ErrInvalidSort, sortBy)
+	}
+
+	direction := strings.ToUpper(sortOrder)
+	if direction == "" {
+		direction = "DESC"
+	}
+	if direction != "ASC" && direction != "DESC" {
+		return "", fmt.Errorf("%w: unknown sort order %q", ErrInvalidSort, sortOrder)
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction), nil
+}
+
+// ActivePredicate returns the is_active condition as a literal rather than a
+// bound parameter, so the planner can match the partial indexes on
+// "is_active AND deleted_at IS NULL" even with generic prepared plans
+func ActivePredicate(active bool) string {
+	if active {
+		return "is_active"
+	}
+	return "NOT is_active"
+}
+
+// metadataOperators maps the metadata filter operators to SQL
+var metadataOperators = map[string]string{
+	"eq":  "=",
+	"ne":  "<>",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
+// ApplyProductFilter adds the filter's conditions to b
+func ApplyProductFilter(b *Builder, f *models.ProductFilter) error {
+	b.Where("deleted_at IS NULL")
+	if f.Category != "" {
+		b.Where("category = ?", f.Category)
+	}
+	if len(f.Categories) > 0 {
+		b.Where("category = ANY(?)", pq.Array(f.Categories))
+	}
+	if len(f.Tags) > 0 {
+		b.Where("EXISTS (SELECT 1 FROM product_tags t WHERE t.product_id = products.id AND t.tag = ANY(?))", pq.Array(f.Tags))
+	}
+	if f.MinPrice > 0 {
+		b.Where("price >= ?", f.MinPrice)
+	}
+	if f.MaxPrice > 0 {
+		b.Where("price <= ?", f.MaxPrice)
+	}
+	if f.MinMargin != nil {
+		b.Where("cost IS NOT NULL AND (price - cost) / NULLIF(price, 0) >= ?", *f.MinMargin)
+	}
+	if f.MaxMargin != nil {
+		b.Where("cost IS NOT NULL AND (price - cost) / NULLIF(price, 0) <= ?", *f.MaxMargin)
+	}
+	if f.IsActive != nil {
+		b.Where(ActivePredicate(*f.IsActive))
+	}
+	if f.ContentHash != "" {
+		b.Where("content_hash = ?", f.ContentHash)
+	}
+	if f.MetadataKey != "" {
+		// Values that parse as JSON (numbers, booleans) match as typed; anything else as a string
+		var value interface{} = f.MetadataValue
+		var literal interface{}
+		if err := json.Unmarshal([]byte(f.MetadataValue), &literal); err == nil {
+			value = literal
+		}
+
+		// Containment lets Postgres use the GIN index on metadata
+		containment, _ := json.Marshal(map[string]interface{}{f.MetadataKey: value})
+		b.Where("metadata @> ?::jsonb", string(containment))
+	}
+	if len(f.MetadataFilters) > 0 {
+		conditions, err := models.ParseMetadataConditions(f.MetadataFilters)
+		if err != nil {
+			return err
+		}
+		for _, c := range conditions {
+			applyMetadataCondition(b, c)
+		}
+	}
+	if f.PriceVsCategoryAvg != "" {
+		ratio, err := models.ParseRatioCondition(f.PriceVsCategoryAvg)
+		if err != nil {
+			return err
+		}
+		// Single-product categories are excluded since a product is always at its own average
+		b.Where("category_size > 1 AND price "+ratio.Op+" ? * category_avg_price", ratio.Value)
+	}
+	if f.MaxCoverageDays != nil {
+		applyMaxCoverage(b, f)
+	}
+	if f.Search != "" {
+		applySearch(b, f.Search, f.MatchMode)
+	}
+	return nil
+}
+
+// applyMetadataCondition compares a metadata key as a number or as text. The
+// jsonb_typeof guard keeps a non-numeric stored value from failing the cast;
+// such rows simply don't match.
+func applyMetadataCondition(b *Builder, c models.MetadataCondition) {
+	op := metadataOperators[c.Op]
+	if c.Numeric {
+		b.Where("(CASE WHEN jsonb_typeof(metadata -> ?::text) = 'number' THEN (metadata ->> ?::text)::numeric END) "+op+" ?::numeric",
+			c.Key, c.Key, c.Value)
+		return
+	}
+	b.Where("(CASE WHEN jsonb_typeof(metadata -> ?::text) = 'string' THEN metadata ->> ?::text END) "+op+" ?",
+		c.Key, c.Key, c.Value)
+}
+
+// categoryWindow exposes each product's category average price and size
+const categoryWindow = `(SELECT *,
+	AVG(price) OVER (PARTITION BY category) AS category_avg_price,
+	COUNT(*) OVER (PARTITION BY category) AS category_size
+FROM products WHERE deleted_at IS NULL) AS products`
+
+// productSource returns the relation to select from; the window over categories
+// is only computed when a filter needs it
+func productSource(f *models.ProductFilter) string {
+	if f.PriceVsCategoryAvg != "" {
+		return categoryWindow
+	}
+	return "products"
+}
+
+// ListProducts builds the paginated SELECT for a product filter. With a
+// cursor it pages by keyset instead of OFFSET; relevance ordering has no
+// stable keyset and only pages by OFFSET. One row beyond Limit is
+// fetched so Page can tell whether another page follows.
+func ListProducts(f *models.ProductFilter) (string, []interface{}, error) {
+	relevance := f.SortBy == models.SortRelevance
+	var orderBy string
+	if relevance {
+		if f.Search == "" {
+			return "", nil, fmt.Errorf("%w: relevance sort requires a search", ErrInvalidSort)
+		}
+		if f.Cursor != "" {
+			return "", nil, fmt.Errorf("%w: relevance sort pages by offset", ErrInvalidCursor)
+		}
+	} else {
+		var err error
+		if orderBy, err = OrderBy(f.SortBy, f.SortOrder); err != nil {
+			return "", nil, err
+		}
+	}
+
+	b := &Builder{}
+	if err := ApplyProductFilter(b, f); err != nil {
+		return "", nil, err
+	}
+	if f.Cursor != "" {
+		if err := keysetCondition(b, f); err != nil {
+			return "", nil, err
+		}
+	}
+	if relevance {
+		orderBy = relevanceOrder(b, f)
+	}
+
+	sql := "SELECT " + ProductColumns + " FROM " + productSource(f) + b.WhereClause() + orderBy
+	if f.Limit > 0 {
+		sql += " LIMIT " + b.Arg(f.Limit+1)
+	}
+	if f.Offset > 0 && f.Cursor == "" {
+		sql += " OFFSET " + b.Arg(f.Offset)
+	}
+	return sql, b.Args(), nil
+}
+
+// CountProducts builds the COUNT query matching a product filter
+func CountProducts(f *models.ProductFilter) (string, []interface{}, error) {
+	b := &Builder{}
+	if err := ApplyProductFilter(b, f); err != nil {
+		return "", nil, err
+	}
+	return "SELECT COUNT(*) FROM " + productSource(f) + b.WhereClause(), b.Args(), nil
+}
+
+// ListLossMaking builds the SELECT for active products whose cost is at or
+// above their price, largest loss first
+func ListLossMaking(page *models.PageFilter) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	b.Where(ActivePredicate(true))
+	b.Where("cost IS NOT NULL AND cost >= price")
+
+	sql := "SELECT " + ProductColumns + " FROM products" + b.WhereClause() +
+		" ORDER BY (cost - price) DESC, id ASC" +
+		" LIMIT " + b.Arg(page.Limit) + " OFFSET " + b.Arg(page.Offset)
+	return sql, b.Args()
+}
+
+// ProductsByBarcodes builds the SELECT for products carrying any of the given
+// barcodes, which must already be normalized. Stored barcodes keep their
+// leading zeros, so they are compared with the zeros stripped, matching the
+// expression index on products.
+func ProductsByBarcodes(barcodes []string) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	b.Where("ltrim(barcode, '0') = ANY(?)", pq.Array(barcodes))
+	return "SELECT " + ProductColumns + " FROM products" + b.WhereClause(), b.Args()
+}
+
+// ProductsByIDs selects the live products with any of the given IDs in one query
+const ProductsByIDs = "SELECT " + ProductColumns + " FROM products WHERE id = ANY($1) AND deleted_at IS NULL"
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestOrderByAlwaysBreaksTiesOnID(t *testing.T) {
+	for column := range sortColumns {
+		for _, order := range []string{"ASC", "DESC"} {
+			clause, err := OrderBy(column, order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(clause, ", id "+order) {
+				t.Errorf("%s %s: expected an id tie-breaker, got %q", column, order, clause)
+			}
+		}
+	}
+}
+
+// keyset returns the rows of sorted, ordered by (price, id) ascending, that
+// follow the cursor in args the way the keyset condition selects them
+func keyset(sorted []models.Product, args []interface{}, limit int) []models.Product {
+	start := 0
+	if len(args) > 1 {
+		price, id := args[0].(float64), args[1].(uuid.UUID)
+		start = sort.Search(len(sorted), func(i int) bool {
+			p := sorted[i]
+			return p.Price > price || p.Price == price && p.ID.String() > id.String()
+		})
+	}
+	end := start + limit
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+	return sorted[start:end]
+}
+
+func TestEqualPricedPagesNeitherOverlapNorSkip(t *testing.T) {
+	products := make([]models.Product, 7)
+	for i := range products {
+		products[i] = models.Product{ID: uuid.New(), Price: 19.99}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID.String() < products[j].ID.String() })
+
+	seen := map[uuid.UUID]bool{}
+	f := &models.ProductFilter{SortBy: "price", SortOrder: "ASC", Limit: 3}
+	for pages := 0; ; pages++ {
+		if pages > len(products) {
+			t.Fatal("paging did not terminate")
+		}
+		sql, args, err := ListProducts(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Cursor != "" && !strings.Contains(sql, "(price, id) > ($1, $2)") {
+			t.Fatalf("expected a keyset condition on price and id, got %s", sql)
+		}
+		page, next, err := Page(f, keyset(products, args, f.Limit+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range page {
+			if seen[p.ID] {
+				t.Fatalf("product %s appeared on two pages", p.ID)
+			}
+			seen[p.ID] = true
+		}
+		if next == "" {
+			break
+		}
+		f.Cursor = next
+	}
+	if len(seen) != len(products) {
+		t.Fatalf("expected every product once, saw %d of %d", len(seen), len(products))
+	}
+}
+
+func TestActiveFilterIsLiteralForPartialIndexes(t *testing.T) {
+	for _, active := range []bool{true, false} {
+		f := &models.ProductFilter{Category: "furniture", IsActive: &active, Limit: 10}
+		sqlText, args, err := ListProducts(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(sqlText, " "+ActivePredicate(active)) {
+			t.Errorf("active=%v: expected the literal predicate %q in %s", active, ActivePredicate(active), sqlText)
+		}
+		for _, arg := range args {
+			if _, ok := arg.(bool); ok {
+				t.Errorf("active=%v: expected no boolean bind argument, got %v", active, args)
+			}
+		}
+	}
+}
+
+func TestSearchMatchModeConditions(t *testing.T) {
+	for _, tc := range []struct {
+		mode       string
+		contains   []string
+		absent     []string
+		searchArgs []interface{}
+	}{
+		{models.MatchModePrefix, []string{"name ILIKE $1 OR name ILIKE $2"}, []string{"search_vector"}, []interface{}{`50\%%`, `% 50\%%`}},
+		{models.MatchModeWord, []string{"search_vector @@ plainto_tsquery('simple', $1)"}, []string{"ILIKE"}, []interface{}{"50%"}},
+		{models.MatchModePhrase, []string{"search_vector @@ phraseto_tsquery('simple', $1)"}, []string{"ILIKE"}, []interface{}{"50%"}},
+		{"", []string{"name ILIKE $1 OR name ILIKE $2", "search_vector @@ plainto_tsquery('simple', $3)"}, nil, []interface{}{`50\%%`, `% 50\%%`, "50%"}},
+	} {
+		sqlText, args, err := ListProducts(&models.ProductFilter{Search: "50%", MatchMode: tc.mode, Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tc.contains {
+			if !strings.Contains(sqlText, want) {
+				t.Errorf("mode %q: expected %q in %s", tc.mode, want, sqlText)
+			}
+		}
+		for _, unwanted := range tc.absent {
+			if strings.Contains(sqlText, unwanted) {
+				t.Errorf("mode %q: expected no %q in %s", tc.mode, unwanted, sqlText)
+			}
+		}
+		for i, want := range tc.searchArgs {
+			if args[i] != want {
+				t.Errorf("mode %q: expected argument %d to be %q, got %v", tc.mode, i+1, want, args[i])
+			}
+		}
+	}
+}
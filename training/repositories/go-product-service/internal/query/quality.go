@@ -0,0 +1,24 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// LowQualityProducts builds the SELECT for products scoring at most MaxScore,
+// worst first. The score starts at 100 and loses the configured weight for a
+// missing description, no images, zero stock and no tags; it may go below zero
+// when the weights add up to more than 100.
+func LowQualityProducts(f *models.LowQualityFilter, w models.QualityWeights) (string, []interface{}) {
+	b := &Builder{}
+	score := "100" +
+		" - CASE WHEN COALESCE(TRIM(description), '') = '' THEN " + b.Arg(w.MissingDescription) + "::int ELSE 0 END" +
+		" - CASE WHEN NOT EXISTS (SELECT 1 FROM product_images i WHERE i.product_id = p.id) THEN " + b.Arg(w.NoImage) + "::int ELSE 0 END" +
+		" - CASE WHEN stock = 0 THEN " + b.Arg(w.ZeroStock) + "::int ELSE 0 END" +
+		" - CASE WHEN NOT EXISTS (SELECT 1 FROM product_tags t WHERE t.product_id = p.id) THEN " + b.Arg(w.NoTags) + "::int ELSE 0 END"
+
+	b.Where("data_quality_score <= ?", f.MaxScore)
+	sql := "SELECT " + ProductColumns + ", data_quality_score FROM (" +
+		"SELECT p.*, " + score + " AS data_quality_score FROM products p WHERE deleted_at IS NULL) AS products" +
+		b.WhereClause() +
+		" ORDER BY data_quality_score ASC, id ASC" +
+		" LIMIT " + b.Arg(f.Limit) + " OFFSET " + b.Arg(f.Offset)
+	return sql, b.Args()
+}
@@ -0,0 +1,44 @@
+// Package querytest builds sqlmock rows in the shapes the query package selects
+package querytest
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+)
+
+// ProductColumns returns the column names of query.ProductColumns
+func ProductColumns() []string {
+	return strings.Split(query.ProductColumns, ", ")
+}
+
+// ProductRows returns rows of query.ProductColumns holding products
+func ProductRows(products ...models.Product) *sqlmock.Rows {
+	rows := sqlmock.NewRows(ProductColumns())
+	for i := range products {
+		rows.AddRow(ProductValues(&products[i])...)
+	}
+	return rows
+}
+
+// ProductValues returns p's values in the order of query.ProductColumns, as
+// the driver values Postgres would return
+func ProductValues(p *models.Product) []driver.Value {
+	fields := []interface{}{
+		p.ID, p.Name, p.Description, p.Price, p.Currency, p.SalePrice, p.SaleStartsAt, p.SaleEndsAt, p.Cost, p.Category, p.SKU, p.Barcode,
+		p.Stock, p.Reserved, p.ReorderLevel, p.IsActive, p.Status, p.Metadata, p.ContentHash, p.CreatedAt, p.UpdatedAt, p.DeletedAt,
+		p.CreatedBy,
+	}
+	values := make([]driver.Value, len(fields))
+	for i, f := range fields {
+		v, err := driver.DefaultParameterConverter.ConvertValue(f)
+		if err != nil {
+			panic(err)
+		}
+		values[i] = v
+	}
+	return values
+}
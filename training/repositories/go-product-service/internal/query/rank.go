@@ -0,0 +1,56 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidScope is returned for a rank scope outside the allowlist
+var ErrInvalidScope = errors.New("invalid rank scope")
+
+// rankScopes maps the API scope keys to the column products are grouped by
+var rankScopes = map[string]string{
+	"category": "category",
+	"status":   "status",
+}
+
+// ProductRank builds the SELECT of a product's rank and the size of its
+// scope under the given ordering. Products sharing a sort value are ordered
+// by id, matching list pagination, so ranks are unique. No row is returned
+// when the product is missing or deleted.
+func ProductRank(id uuid.UUID, q *models.RankQuery) (string, []interface{}, error) {
+	column, ok := sortColumns[q.SortBy]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unknown sort column %q", ErrInvalidSort, q.SortBy)
+	}
+	direction := strings.ToUpper(q.SortOrder)
+	if direction == "" {
+		direction = "ASC"
+	}
+	if direction != "ASC" && direction != "DESC" {
+		return "", nil, fmt.Errorf("%w: unknown sort order %q", ErrInvalidSort, q.SortOrder)
+	}
+
+	b := &Builder{}
+	target := b.Arg(id)
+	b.Where("deleted_at IS NULL")
+	if q.Within != "" {
+		scope, ok := rankScopes[q.Within]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %q", ErrInvalidScope, q.Within)
+		}
+		// Only the target's own scope is ranked rather than every partition
+		b.Where(scope + " = (SELECT " + scope + " FROM products WHERE id = " + target + ")")
+	}
+
+	sql := "SELECT rank, total FROM (" +
+		"SELECT id, ROW_NUMBER() OVER (ORDER BY " + column + " " + direction + ", id " + direction + ") AS rank," +
+		" COUNT(*) OVER () AS total" +
+		" FROM products" + b.WhereClause() +
+		") ranked WHERE id = " + target
+	return sql, b.Args(), nil
+}
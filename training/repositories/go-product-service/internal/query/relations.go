@@ -0,0 +1,83 @@
+package query
+
+// Relation queries take an array of product IDs so that any number of
+// products is loaded with one query per relation rather than one per product
+
+// ImagesByProductIDs selects the images of the given products
+const ImagesByProductIDs = `SELECT id, product_id, url, position, created_at
+FROM product_images
+WHERE product_id = ANY($1)
+ORDER BY product_id, position, id`
+
+// VariantsByProductIDs selects the variants of the given products
+const VariantsByProductIDs = `SELECT id, product_id, sku, name, price, stock, attributes, created_at, updated_at
+FROM product_variants
+WHERE product_id = ANY($1)
+ORDER BY product_id, name, id`
+
+// InsertVariant stores a new variant, returning its generated ID and timestamps
+const InsertVariant = `INSERT INTO product_variants (product_id, sku, name, price, stock, attributes)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at, updated_at`
+
+// DeleteVariant removes one of a product's variants
+const DeleteVariant = `DELETE FROM product_variants WHERE id = $1 AND product_id = $2`
+
+// TagsByProductIDs selects the tags of the given products
+const TagsByProductIDs = `SELECT product_id, tag
+FROM product_tags
+WHERE product_id = ANY($1)
+ORDER BY product_id, tag`
+
+// TagCounts counts the live products carrying each tag, hiding tags used by
+// fewer than $1 products, most used first
+const TagCounts = `SELECT t.tag, COUNT(*) AS count
+FROM product_tags t
+JOIN products p ON p.id = t.product_id
+WHERE p.deleted_at IS NULL
+GROUP BY t.tag
+HAVING COUNT(*) >= $1
+ORDER BY count DESC, t.tag`
+
+// DeleteTag removes a tag from every product, returning the products it was
+// removed from so they can be touched
+const DeleteTag = `DELETE FROM product_tags WHERE tag = $1 RETURNING product_id`
+
+// TranslationsByProductIDs selects the translations of the given products
+const TranslationsByProductIDs = `SELECT product_id, locale, name, description
+FROM product_translations
+WHERE product_id = ANY($1)
+ORDER BY product_id, locale`
+
+// UpsertTranslation stores a product's translation for a locale, replacing any existing one
+const UpsertTranslation = `INSERT INTO product_translations (product_id, locale, name, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (product_id, locale) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description`
+
+// DeleteTranslation removes a product's translation for a locale
+const DeleteTranslation = `DELETE FROM product_translations WHERE product_id = $1 AND locale = $2`
+
+// TouchProduct advances a product's updated_at after one of its images,
+// variants, tags or translations changed; run it in the child's transaction
+// so caches and change feeds keyed on updated_at see the change atomically.
+// clock_timestamp() is used so the touch lands after the row's previous
+// updated_at even when both were written in the same transaction.
+const TouchProduct = `UPDATE products SET updated_at = GREATEST(clock_timestamp(), updated_at + INTERVAL '1 microsecond')
+WHERE id = $1 AND deleted_at IS NULL`
+
+// TouchProducts is TouchProduct for every product in $1, for changes such as
+// deleting a tag that affect many products at once
+const TouchProducts = `UPDATE products SET updated_at = GREATEST(clock_timestamp(), updated_at + INTERVAL '1 microsecond')
+WHERE id = ANY($1) AND deleted_at IS NULL`
+
+// PriceTiersByProductIDs selects the price tiers of the given products
+const PriceTiersByProductIDs = `SELECT product_id, min_qty, price
+FROM product_price_tiers
+WHERE product_id = ANY($1)
+ORDER BY product_id, min_qty`
+
+// DeletePriceTiers removes a product's tiers before they are replaced
+const DeletePriceTiers = `DELETE FROM product_price_tiers WHERE product_id = $1`
+
+// InsertPriceTier stores one price tier
+const InsertPriceTier = `INSERT INTO product_price_tiers (product_id, min_qty, price) VALUES ($1, $2, $3)`
@@ -0,0 +1,22 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// ReorderCandidates builds the SELECT for active products whose available
+// stock is at or below their reorder level, each with its average daily
+// sales over the last windowDays, fastest sellers first
+func ReorderCandidates(f *models.ReorderFilter, windowDays int) (string, []interface{}) {
+	b := &Builder{}
+	window := b.Arg(windowDays)
+	b.Where("deleted_at IS NULL")
+	b.Where("is_active")
+	b.Where("reorder_level IS NOT NULL AND stock - reserved <= reorder_level")
+
+	sql := "WITH velocity AS (" + salesVelocity(window) + ")" +
+		" SELECT " + ProductColumns + ", COALESCE(v.daily_velocity, 0) AS daily_velocity" +
+		" FROM products LEFT JOIN velocity v ON v.product_id = products.id" +
+		b.WhereClause() +
+		" ORDER BY daily_velocity DESC, id ASC" +
+		" LIMIT " + b.Arg(f.Limit) + " OFFSET " + b.Arg(f.Offset)
+	return sql, b.Args()
+}
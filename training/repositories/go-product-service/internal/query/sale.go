@@ -0,0 +1,23 @@
+package query
+
+import (
+	"time"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// UpcomingSales builds the SELECT for products whose sale starts after now
+// and no later than now+within, soonest first; now is passed in rather than
+// read from the database clock so callers can control it
+func UpcomingSales(f *models.UpcomingSalesFilter, now time.Time, within time.Duration) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	b.Where("sale_price IS NOT NULL")
+	b.Where("sale_starts_at > ?", now)
+	b.Where("sale_starts_at <= ?", now.Add(within))
+
+	sql := "SELECT " + ProductColumns + " FROM products" + b.WhereClause() +
+		" ORDER BY sale_starts_at ASC, id ASC" +
+		" LIMIT " + b.Arg(f.Limit) + " OFFSET " + b.Arg(f.Offset)
+	return sql, b.Args()
+}
@@ -0,0 +1,24 @@
+package query
+
+// SavedQueryColumns is the column list selected for saved queries
+const SavedQueryColumns = "name, description, filter, created_at, updated_at"
+
+// GetSavedQuery selects a saved query by name
+const GetSavedQuery = "SELECT " + SavedQueryColumns + " FROM saved_queries WHERE name = $1"
+
+// ListSavedQueries selects every saved query by name
+const ListSavedQueries = "SELECT " + SavedQueryColumns + " FROM saved_queries ORDER BY name LIMIT $1 OFFSET $2"
+
+// InsertSavedQuery stores a new saved query; a taken name fails with a unique violation
+const InsertSavedQuery = `INSERT INTO saved_queries (name, description, filter, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+RETURNING ` + SavedQueryColumns
+
+// UpdateSavedQuery replaces the description and filter of a saved query; no
+// row is returned when the name does not exist
+const UpdateSavedQuery = `UPDATE saved_queries SET description = $2, filter = $3, updated_at = NOW()
+WHERE name = $1
+RETURNING ` + SavedQueryColumns
+
+// DeleteSavedQuery removes a saved query
+const DeleteSavedQuery = "DELETE FROM saved_queries WHERE name = $1"
@@ -0,0 +1,22 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// Scanner is implemented by *sql.Row and *sql.Rows
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// ScanProduct scans a row selected with ProductColumns
+func ScanProduct(s Scanner, extra ...interface{}) (*models.Product, error) {
+	var p models.Product
+	dest := []interface{}{
+		&p.ID, &p.Name, &p.Description, &p.Price, &p.Currency, &p.SalePrice, &p.SaleStartsAt, &p.SaleEndsAt, &p.Cost, &p.Category, &p.SKU, &p.Barcode,
+		&p.Stock, &p.Reserved, &p.ReorderLevel, &p.IsActive, &p.Status, &p.Metadata, &p.ContentHash, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt,
+		&p.CreatedBy,
+	}
+	if err := s.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
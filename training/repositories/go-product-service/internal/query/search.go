@@ -0,0 +1,67 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// suggestionThreshold is the minimum trigram similarity for a name to be suggested
+const suggestionThreshold = 0.3
+
+// SuggestProductNames builds the query for active product names that are
+// trigram-similar to a search term, most similar first
+func SuggestProductNames(term string, limit int) (string, []interface{}) {
+	b := &Builder{}
+	termArg := b.Arg(term)
+	b.Where("deleted_at IS NULL")
+	b.Where(ActivePredicate(true))
+	b.Where("similarity(name, "+termArg+") > ?", suggestionThreshold)
+
+	sql := "SELECT name FROM products" + b.WhereClause() +
+		" GROUP BY name ORDER BY MAX(similarity(name, " + termArg + ")) DESC, name ASC" +
+		" LIMIT " + b.Arg(limit)
+	return sql, b.Args()
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// searchDocument is the text matched by the full-text modes: the generated
+// search_vector column over name, category and description, weighted in
+// that order so ts_rank prefers name matches
+const searchDocument = "search_vector"
+
+// tsQuery returns the tsquery constructor for a match mode
+func tsQuery(mode string) string {
+	if mode == models.MatchModePhrase {
+		return "phraseto_tsquery"
+	}
+	return "plainto_tsquery"
+}
+
+// applySearch adds the search condition for a match mode. Performance differs:
+//   - prefix matches the start of any word in the name with ILIKE, which the
+//     trigram index on name serves
+//   - word and phrase use full-text search over name, category and
+//     description, served by the GIN index on search_vector
+//   - the default is prefix OR word, trading a little speed for matching
+//     both "wir" and whole words in descriptions
+func applySearch(b *Builder, term, mode string) {
+	prefix := likeEscaper.Replace(term) + "%"
+	switch mode {
+	case models.MatchModePrefix:
+		b.Where("(name ILIKE ? OR name ILIKE ?)", prefix, "% "+prefix)
+	case models.MatchModeWord, models.MatchModePhrase:
+		b.Where(searchDocument+" @@ "+tsQuery(mode)+"('simple', ?)", term)
+	default:
+		b.Where("(name ILIKE ? OR name ILIKE ? OR "+searchDocument+" @@ plainto_tsquery('simple', ?))", prefix, "% "+prefix, term)
+	}
+}
+
+// relevanceOrder returns the ORDER BY for SortRelevance, best match first;
+// prefix-only matches rank zero and fall back to id order
+func relevanceOrder(b *Builder, f *models.ProductFilter) string {
+	rank := "ts_rank(" + searchDocument + ", " + tsQuery(f.MatchMode) + "('simple', " + b.Arg(f.Search) + "))"
+	return " ORDER BY " + rank + " DESC, id DESC"
+}
@@ -0,0 +1,23 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// SimilarProducts builds the SELECT for active products in p's category whose
+// price lies within the filter's band around p's price, excluding p itself.
+// Products closest in price come first unless the filter asks for a random order.
+func SimilarProducts(p *models.Product, f *models.SimilarProductsFilter) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	b.Where(ActivePredicate(true))
+	b.Where("category = ?", p.Category)
+	b.Where("id <> ?", p.ID)
+	b.Where("price BETWEEN ? AND ?", p.Price*(1-f.PriceBand), p.Price*(1+f.PriceBand))
+
+	orderBy := " ORDER BY random()"
+	if f.Order != models.SimilarOrderRandom {
+		orderBy = " ORDER BY ABS(price - " + b.Arg(p.Price) + "), id ASC"
+	}
+	sql := "SELECT " + ProductColumns + " FROM products" + b.WhereClause() + orderBy +
+		" LIMIT " + b.Arg(f.Limit)
+	return sql, b.Args()
+}
@@ -0,0 +1,17 @@
+package query
+
+// ProductStatsSummary computes the dashboard totals in a single scan
+const ProductStatsSummary = `SELECT
+	COUNT(*) AS total_products,
+	COUNT(*) FILTER (WHERE is_active) AS active_products,
+	COUNT(*) FILTER (WHERE stock = 0) AS out_of_stock,
+	COALESCE(SUM(price * stock), 0) AS inventory_value
+FROM products
+WHERE deleted_at IS NULL`
+
+// ProductStatsByCategory computes the per-category product counts
+const ProductStatsByCategory = `SELECT category, COUNT(*) AS count
+FROM products
+WHERE deleted_at IS NULL
+GROUP BY category
+ORDER BY count DESC, category ASC`
@@ -0,0 +1,8 @@
+package query
+
+// TransitionStatus moves a product from $3 to $2, keeping is_active in step
+// with the status; it matches no row when the product is missing or its
+// status changed concurrently
+const TransitionStatus = `UPDATE products SET status = $2::varchar, is_active = ($2::varchar = 'active'), updated_at = NOW()
+WHERE id = $1 AND status = $3 AND deleted_at IS NULL
+RETURNING ` + ProductColumns
@@ -0,0 +1,43 @@
+package query
+
+import "github.com/company/go-product-service/internal/models"
+
+// InsertStockMovement records a stock movement; run it in the same
+// transaction as the stock change it describes
+const InsertStockMovement = `INSERT INTO stock_movements (id, product_id, delta, reason, created_at)
+VALUES ($1, $2, $3, $4, NOW())`
+
+// AdjustStock applies a stock delta and, in the same statement, flips is_active
+// when auto-deactivation ($3) or auto-reactivation ($4) is enabled. The update
+// is refused (no row returned) if it would make stock negative.
+// It returns the new stock, the new is_active and the previous is_active.
+const AdjustStock = `WITH current AS (
+	SELECT id, stock, is_active FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+)
+UPDATE products p SET
+	stock = current.stock + $2,
+	is_active = CASE
+		WHEN $3 AND current.stock + $2 = 0 THEN FALSE
+		WHEN $4 AND current.stock = 0 AND current.stock + $2 > 0 THEN TRUE
+		ELSE current.is_active
+	END,
+	updated_at = NOW()
+FROM current
+WHERE p.id = current.id AND current.stock + $2 >= 0
+RETURNING p.stock, p.is_active, current.is_active`
+
+// NeverSold builds the SELECT for active products created before the cutoff
+// without a single sale movement, oldest first as the likeliest clearance
+// candidates
+func NeverSold(f *models.NeverSoldFilter) (string, []interface{}) {
+	b := &Builder{}
+	b.Where("deleted_at IS NULL")
+	b.Where(ActivePredicate(true))
+	b.Where("created_at < ?", f.CreatedBefore)
+	b.Where("NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = products.id AND m.reason = '" + models.MovementSale + "')")
+
+	sql := "SELECT " + ProductColumns + " FROM products" + b.WhereClause() +
+		" ORDER BY created_at ASC, id ASC" +
+		" LIMIT " + b.Arg(f.Limit) + " OFFSET " + b.Arg(f.Offset)
+	return sql, b.Args()
+}
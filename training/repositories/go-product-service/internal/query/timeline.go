@@ -0,0 +1,35 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// timelineIntervals allowlists the date_trunc units; the unit is inlined so
+// the bucket expression in SELECT and GROUP BY is identical
+var timelineIntervals = map[string]string{
+	"hour":  "'hour'",
+	"day":   "'day'",
+	"week":  "'week'",
+	"month": "'month'",
+}
+
+// CreationTimeline builds a query counting products created per interval in
+// [From, Until), oldest bucket first; empty buckets are not returned and
+// soft-deleted products still count towards the bucket they were created in
+func CreationTimeline(f *models.CreationTimelineFilter) (string, []interface{}, error) {
+	unit, ok := timelineIntervals[f.Interval]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: invalid interval %q", models.ErrInvalidFilter, f.Interval)
+	}
+
+	b := &Builder{}
+	b.Where("created_at >= ?", f.From)
+	b.Where("created_at < ?", f.Until)
+
+	bucket := "date_trunc(" + unit + ", created_at)"
+	sql := "SELECT " + bucket + " AS bucket, COUNT(*) AS count FROM products" + b.WhereClause() +
+		" GROUP BY " + bucket + " ORDER BY bucket ASC"
+	return sql, b.Args(), nil
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+)
+
+// TooManyRowsError is returned when an unpaginated list would exceed the configured cap
+type TooManyRowsError struct {
+	Max int
+}
+
+func (e *TooManyRowsError) Error() string {
+	return fmt.Sprintf("unpaginated list exceeds %d rows; page with limit/offset or use the streaming export", e.Max)
+}
+
+// CapUnpaginated sets the limit of an unpaginated filter to maxRows; since
+// ListProducts loads one row past the limit, the caller can detect an
+// overflow without a separate COUNT. Paginated
+// filters and a non-positive maxRows are left unchanged. It reports whether the cap applied.
+func CapUnpaginated(f *models.ProductFilter, maxRows int) bool {
+	if f.Limit > 0 || maxRows <= 0 {
+		return false
+	}
+	f.Limit = maxRows
+	return true
+}
+
+// CheckUnpaginated returns a *TooManyRowsError when a capped query loaded more than maxRows rows
+func CheckUnpaginated(rows, maxRows int) error {
+	if rows > maxRows {
+		return &TooManyRowsError{Max: maxRows}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package query
+
+// UpsertProductBySKU inserts a product or updates the one with the same SKU.
+// The update is skipped when the stored content hash already matches, in
+// which case no row is returned; otherwise "inserted" tells a create from an update.
+const UpsertProductBySKU = `INSERT INTO products (id, name, description, price, cost, category, sku, barcode, stock, is_active, metadata, content_hash, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
+ON CONFLICT (sku) DO UPDATE SET
+	name = EXCLUDED.name,
+	description = EXCLUDED.description,
+	price = EXCLUDED.price,
+	cost = EXCLUDED.cost,
+	category = EXCLUDED.category,
+	barcode = EXCLUDED.barcode,
+	stock = EXCLUDED.stock,
+	metadata = EXCLUDED.metadata,
+	content_hash = EXCLUDED.content_hash,
+	updated_at = NOW()
+WHERE products.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+RETURNING id, (xmax = 0) AS inserted`
+
+// InsertProduct inserts a new product, bound like InsertProductsBatch; a
+// taken SKU fails with a unique violation
+const InsertProduct = `INSERT INTO products (id, name, description, price, cost, category, sku, barcode, stock, is_active, metadata, content_hash, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())`
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// BulkUpdatePrices locks the products req targets and stores the new price
+// of every one whose price changes and satisfies guard, recording each write
+// like Update. The returned summary is computed by models.PreviewBulkPriceUpdate
+// over the locked rows, so it is what a preview of the same rows reports.
+func (r *ProductRepository) BulkUpdatePrices(ctx context.Context, req *models.BulkPriceUpdateRequest, guard models.PriceGuard, sampleSize int) (*models.BulkPricePreview, []models.PriceChange, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.BulkUpdatePrices")
+	defer span.End()
+
+	var preview *models.BulkPricePreview
+	var changes []models.PriceChange
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		sqlText, args := query.BulkPriceTargets(req, true)
+		products, err := queryProducts(ctx, tx, sqlText, args...)
+		if err != nil {
+			return fmt.Errorf("lock products: %w", err)
+		}
+		preview = models.PreviewBulkPriceUpdate(products, req, guard, sampleSize)
+
+		changes = nil
+		for i := range products {
+			before := products[i]
+			next := req.NewPrice(before.Price)
+			if next == before.Price || !guard.Allows(next) {
+				continue
+			}
+			p := before
+			p.Price = next
+			p.ContentHash = p.ComputeContentHash()
+			if err := tx.QueryRowContext(ctx, updateProduct, productArgs(&p)...).Scan(&p.UpdatedAt); err != nil {
+				return fmt.Errorf("update product %s: %w", p.ID, err)
+			}
+			if err := r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, &before, &p); err != nil {
+				return err
+			}
+			changes = append(changes, models.PriceChange{Product: p, OldPrice: before.Price})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return preview, changes, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// RenameCategory moves every product in category old to category new in one
+// transaction and audits the rename with the number of products affected.
+// It returns the IDs of the products moved along with the result. A new name
+// that live products already use fails with models.ErrCategoryTaken.
+func (r *ProductRepository) RenameCategory(ctx context.Context, old, new string) (*models.RenameCategoryResult, []uuid.UUID, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.RenameCategory")
+	defer span.End()
+
+	result := &models.RenameCategoryResult{Old: old, New: new}
+	var moved []uuid.UUID
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var taken bool
+		if err := tx.QueryRowContext(ctx, query.CategoryInUse, new).Scan(&taken); err != nil {
+			return fmt.Errorf("check category %q: %w", new, err)
+		}
+		if taken {
+			return fmt.Errorf("%w: %q", models.ErrCategoryTaken, new)
+		}
+
+		rows, err := tx.QueryContext(ctx, query.RenameCategory, old, new)
+		if err != nil {
+			return fmt.Errorf("rename category %q: %w", old, err)
+		}
+		moved = nil
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			moved = append(moved, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		result.Affected = int64(len(moved))
+
+		before, err := json.Marshal(map[string]string{"category": old})
+		if err != nil {
+			return err
+		}
+		after, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		err = audit.Record(ctx, tx, &models.AuditEntry{
+			Actor:      auth.Caller(ctx),
+			Action:     models.AuditActionRenameCategory,
+			EntityType: models.AuditEntityCategory,
+			EntityID:   old,
+			Before:     before,
+			After:      after,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, moved, nil
+}
+
+// DeleteCategory retires category in one audited transaction. Live products
+// still using it are moved to reassignTo, whose IDs are returned along with
+// the result; with no reassignTo a category still in use fails with a
+// *models.CategoryInUseError carrying its usage.
+func (r *ProductRepository) DeleteCategory(ctx context.Context, category, reassignTo string) (*models.DeleteCategoryResult, []uuid.UUID, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeleteCategory")
+	defer span.End()
+
+	result := &models.DeleteCategoryResult{Category: category, ReassignTo: reassignTo}
+	var moved []uuid.UUID
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		usage := models.CategoryUsage{Category: category}
+		if err := tx.QueryRowContext(ctx, query.CategoryUsage, category).Scan(&usage.Active, &usage.Total); err != nil {
+			return fmt.Errorf("category usage: %w", err)
+		}
+		if usage.Total > 0 && reassignTo == "" {
+			return &models.CategoryInUseError{Usage: usage}
+		}
+
+		moved = nil
+		if reassignTo != "" {
+			rows, err := tx.QueryContext(ctx, query.ReassignCategory, category, reassignTo)
+			if err != nil {
+				return fmt.Errorf("reassign category %q: %w", category, err)
+			}
+			for rows.Next() {
+				var id uuid.UUID
+				if err := rows.Scan(&id); err != nil {
+					rows.Close()
+					return err
+				}
+				moved = append(moved, id)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+		}
+		result.Affected = int64(len(moved))
+
+		before, err := json.Marshal(usage)
+		if err != nil {
+			return err
+		}
+		after, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		err = audit.Record(ctx, tx, &models.AuditEntry{
+			Actor:      auth.Caller(ctx),
+			Action:     models.AuditActionDeleteCategory,
+			EntityType: models.AuditEntityCategory,
+			EntityID:   category,
+			Before:     before,
+			After:      after,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, moved, nil
+}
+
+// CategoryUsage counts the live products, and the active ones among them,
+// that use a category
+func (r *ProductRepository) CategoryUsage(ctx context.Context, category string) (*models.CategoryUsage, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CategoryUsage")
+	defer span.End()
+
+	usage := &models.CategoryUsage{Category: category}
+	if err := r.db.QueryRowContext(ctx, query.CategoryUsage, category).Scan(&usage.Active, &usage.Total); err != nil {
+		return nil, fmt.Errorf("category usage: %w", err)
+	}
+	return usage, nil
+}
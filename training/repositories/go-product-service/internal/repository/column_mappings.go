@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// SaveColumnMapping stores m under its name, replacing any mapping with that name
+func (r *ProductRepository) SaveColumnMapping(ctx context.Context, m *models.ColumnMapping) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SaveColumnMapping")
+	defer span.End()
+
+	row := r.db.QueryRowContext(ctx, query.UpsertColumnMapping, m.Name, m.Columns)
+	if err := scanColumnMapping(row, m); err != nil {
+		return fmt.Errorf("save column mapping %q: %w", m.Name, err)
+	}
+	return nil
+}
+
+// GetColumnMapping returns the named mapping, or models.ErrColumnMappingNotFound
+func (r *ProductRepository) GetColumnMapping(ctx context.Context, name string) (*models.ColumnMapping, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.GetColumnMapping")
+	defer span.End()
+
+	var m models.ColumnMapping
+	err := scanColumnMapping(r.db.QueryRowContext(ctx, query.GetColumnMapping, name), &m)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", models.ErrColumnMappingNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get column mapping %q: %w", name, err)
+	}
+	return &m, nil
+}
+
+// ListColumnMappings returns every stored mapping by name
+func (r *ProductRepository) ListColumnMappings(ctx context.Context) ([]models.ColumnMapping, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ListColumnMappings")
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query.ListColumnMappings)
+	if err != nil {
+		return nil, fmt.Errorf("list column mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []models.ColumnMapping{}
+	for rows.Next() {
+		var m models.ColumnMapping
+		if err := scanColumnMapping(rows, &m); err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, rows.Err()
+}
+
+// scanColumnMapping scans a row of query.ColumnMappingColumns into m
+func scanColumnMapping(s query.Scanner, m *models.ColumnMapping) error {
+	return s.Scan(&m.ID, &m.Name, &m.Columns, &m.CreatedAt, &m.UpdatedAt)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SalesVelocities returns the average daily units sold over the last
+// windowDays for each of ids; products without sales in the window are omitted
+func (r *ProductRepository) SalesVelocities(ctx context.Context, ids []uuid.UUID, windowDays int) (map[uuid.UUID]float64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SalesVelocities")
+	defer span.End()
+
+	velocities := make(map[uuid.UUID]float64, len(ids))
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var id uuid.UUID
+		var velocity float64
+		if err := rows.Scan(&id, &velocity); err != nil {
+			return err
+		}
+		velocities[id] = velocity
+		return nil
+	}, query.SalesVelocities, pq.Array(ids), windowDays)
+	if err != nil {
+		return nil, fmt.Errorf("sales velocities: %w", err)
+	}
+	return velocities, nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// UpdateFrequency returns a page of live products whose audited update
+// count since f.Since lies within f's range, most changed first
+func (r *ProductRepository) UpdateFrequency(ctx context.Context, f *models.UpdateFrequencyFilter) ([]models.ProductUpdateFrequency, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.UpdateFrequency")
+	defer span.End()
+
+	q, args := query.UpdateFrequency(f)
+	counted := []models.ProductUpdateFrequency{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var count int64
+		p, err := query.ScanProduct(rows, &count)
+		if err != nil {
+			return err
+		}
+		counted = append(counted, models.ProductUpdateFrequency{Product: *p, UpdateCount: count})
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("update frequency: %w", err)
+	}
+	return counted, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+)
+
+func TestUpdateFrequencyFiltersByChangeCount(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Minute)
+
+	seed := func(sku string, updates int) *models.Product {
+		t.Helper()
+		p := &models.Product{Name: sku, Price: 10, Category: "furniture", SKU: sku, Stock: 1, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < updates; i++ {
+			updated, err := repo.Update(ctx, p.ID, func(p *models.Product) error {
+				p.Price++
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p = updated
+		}
+		return p
+	}
+	seed("STALE", 0)
+	once := seed("ONCE", 1)
+	busy := seed("BUSY", 5)
+
+	two := 2
+	stale, err := repo.UpdateFrequency(ctx, &models.UpdateFrequencyFilter{Since: since, Min: 0, Max: new(int), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 || stale[0].SKU != "STALE" || stale[0].UpdateCount != 0 {
+		t.Fatalf("expected only STALE unchanged, got %+v", stale)
+	}
+
+	changed, err := repo.UpdateFrequency(ctx, &models.UpdateFrequencyFilter{Since: since, Min: 1, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 || changed[0].ID != busy.ID || changed[0].UpdateCount != 5 || changed[1].ID != once.ID {
+		t.Fatalf("expected BUSY then ONCE, got %+v", changed)
+	}
+
+	low, err := repo.UpdateFrequency(ctx, &models.UpdateFrequencyFilter{Since: since, Min: 1, Max: &two, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(low) != 1 || low[0].ID != once.ID || low[0].UpdateCount != 1 {
+		t.Fatalf("expected only ONCE within 1..2, got %+v", low)
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// GroupedProducts returns the live products bucketed by f.GroupBy, each
+// group holding its full count and a sample of its newest products
+func (r *ProductRepository) GroupedProducts(ctx context.Context, f *models.GroupedProductsFilter) ([]models.ProductGroup, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.GroupedProducts")
+	defer span.End()
+
+	q, args, err := query.GroupedProducts(f)
+	if err != nil {
+		return nil, err
+	}
+	groups := []models.ProductGroup{}
+	err = r.eachRow(ctx, func(rows *sql.Rows) error {
+		var key string
+		var count int64
+		p, err := query.ScanProduct(rows, &key, &count)
+		if err != nil {
+			return err
+		}
+		// Rows arrive ordered by group, so a new key starts a new group
+		if n := len(groups); n == 0 || groups[n-1].Group != key {
+			groups = append(groups, models.ProductGroup{Group: key, Count: count})
+		}
+		last := &groups[len(groups)-1]
+		last.Items = append(last.Items, *p)
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("group products: %w", err)
+	}
+	return groups, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AttachImagesBySKU attaches every item's image to the product with its SKU
+// in one transaction, with touch also advancing the updated_at of each
+// product that gained an image. Items whose SKU matches no live product are
+// reported as failed and the rest are still attached.
+func (r *ProductRepository) AttachImagesBySKU(ctx context.Context, items []models.BulkImageItem, touch bool) ([]models.BulkImageResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.AttachImagesBySKU")
+	defer span.End()
+
+	skus := make([]string, len(items))
+	for i, item := range items {
+		skus[i] = item.SKU
+	}
+
+	results := make([]models.BulkImageResult, len(items))
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		ids, err := productIDsBySKU(ctx, tx, skus)
+		if err != nil {
+			return err
+		}
+		var touched []uuid.UUID
+		for i, item := range items {
+			results[i] = models.BulkImageResult{Index: i, SKU: item.SKU}
+			productID, ok := ids[item.SKU]
+			if !ok {
+				results[i].Error = "product not found"
+				continue
+			}
+			var imageID uuid.UUID
+			if err := tx.QueryRowContext(ctx, query.InsertProductImage, productID, item.URL, item.Position).Scan(&imageID); err != nil {
+				return fmt.Errorf("attach image to %q: %w", item.SKU, err)
+			}
+			results[i].Success = true
+			results[i].ProductID = &productID
+			results[i].ImageID = &imageID
+			touched = append(touched, productID)
+		}
+		if touch && len(touched) > 0 {
+			if _, err := tx.ExecContext(ctx, query.TouchProducts, pq.Array(touched)); err != nil {
+				return fmt.Errorf("touch products: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// productIDsBySKU maps each of skus that names a live product to its ID
+func productIDsBySKU(ctx context.Context, tx *sql.Tx, skus []string) (map[string]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx, query.ProductIDsBySKU, pq.Array(skus))
+	if err != nil {
+		return nil, fmt.Errorf("resolve skus: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]uuid.UUID, len(skus))
+	for rows.Next() {
+		var id uuid.UUID
+		var sku string
+		if err := rows.Scan(&id, &sku); err != nil {
+			return nil, err
+		}
+		ids[sku] = id
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+)
+
+func TestAttachingImageAdvancesParentUpdatedAt(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	for _, touch := range []bool{false, true} {
+		sku := "DESK-UNTOUCHED"
+		if touch {
+			sku = "DESK-TOUCHED"
+		}
+		p := &models.Product{Name: "Desk", Price: 120, Category: "furniture", SKU: sku, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		item := models.BulkImageItem{SKU: sku, URL: "https://cdn.example.com/desk.jpg"}
+		if _, err := repo.AttachImagesBySKU(ctx, []models.BulkImageItem{item}, touch); err != nil {
+			t.Fatal(err)
+		}
+		after, err := repo.GetByID(ctx, p.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if advanced := after.UpdatedAt.After(p.UpdatedAt); advanced != touch {
+			t.Errorf("touch=%v: updated_at went from %s to %s", touch, p.UpdatedAt, after.UpdatedAt)
+		}
+	}
+}
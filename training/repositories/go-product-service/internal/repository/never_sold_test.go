@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/google/uuid"
+)
+
+func TestNeverSoldSkipsProductsWithASale(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	seed := func(sku string) *models.Product {
+		t.Helper()
+		p := &models.Product{Name: sku, Price: 10, Category: "furniture", SKU: sku, Stock: 5, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	sold := seed("SOLD")
+	seed("UNSOLD")
+	restocked := seed("RESTOCKED")
+	if _, err := repo.AdjustStock(ctx, &models.StockMovement{ID: uuid.New(), ProductID: sold.ID, Delta: -1, Reason: models.MovementSale}, models.StockActivation{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.AdjustStock(ctx, &models.StockMovement{ID: uuid.New(), ProductID: restocked.ID, Delta: 2, Reason: models.MovementRestock}, models.StockActivation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	q, args := query.NeverSold(&models.NeverSoldFilter{CreatedBefore: time.Now().Add(time.Minute), Limit: 10})
+	products, err := repo.QueryProducts(ctx, q, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 2 || products[0].SKU != "UNSOLD" || products[1].SKU != "RESTOCKED" {
+		t.Fatalf("expected UNSOLD then RESTOCKED, got %+v", products)
+	}
+}
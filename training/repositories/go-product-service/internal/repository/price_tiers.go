@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PriceTiers returns a product's price tiers by increasing quantity
+func (r *ProductRepository) PriceTiers(ctx context.Context, productID uuid.UUID) ([]models.PriceTier, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.PriceTiers")
+	defer span.End()
+
+	tiers := []models.PriceTier{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var t models.PriceTier
+		if err := rows.Scan(&t.ProductID, &t.MinQty, &t.Price); err != nil {
+			return err
+		}
+		tiers = append(tiers, t)
+		return nil
+	}, query.PriceTiersByProductIDs, pq.Array([]uuid.UUID{productID}))
+	if err != nil {
+		return nil, fmt.Errorf("price tiers of %s: %w", productID, err)
+	}
+	return tiers, nil
+}
+
+// SetPriceTiers replaces a live product's price tiers in one transaction,
+// with touch also advancing its updated_at; it returns sql.ErrNoRows if the
+// product is not live
+func (r *ProductRepository) SetPriceTiers(ctx context.Context, productID uuid.UUID, tiers []models.PriceTier, touch bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SetPriceTiers")
+	defer span.End()
+
+	return r.writeChild(ctx, productID, touch, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query.DeletePriceTiers, productID); err != nil {
+			return fmt.Errorf("clear price tiers: %w", err)
+		}
+		for _, t := range tiers {
+			if _, err := tx.ExecContext(ctx, query.InsertPriceTier, productID, t.MinQty, t.Price); err != nil {
+				return fmt.Errorf("insert price tier %d: %w", t.MinQty, err)
+			}
+		}
+		return nil
+	})
+}
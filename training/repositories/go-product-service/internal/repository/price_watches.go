@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// CreatePriceWatch watches the price of a live product for email, or returns
+// sql.ErrNoRows when the product does not exist
+func (r *ProductRepository) CreatePriceWatch(ctx context.Context, productID uuid.UUID, email string) (*models.PriceWatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CreatePriceWatch")
+	defer span.End()
+
+	var w models.PriceWatch
+	err := r.db.QueryRowContext(ctx, query.InsertPriceWatch, productID, email).
+		Scan(&w.ID, &w.ProductID, &w.Email, &w.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("watch price of %s: %w", productID, err)
+	}
+	return &w, nil
+}
+
+// DeletePriceWatch removes a product's watch, or returns models.ErrPriceWatchNotFound
+func (r *ProductRepository) DeletePriceWatch(ctx context.Context, productID, watchID uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeletePriceWatch")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx, query.DeletePriceWatch, watchID, productID)
+	if err != nil {
+		return fmt.Errorf("delete price watch %s: %w", watchID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return models.ErrPriceWatchNotFound
+	}
+	return nil
+}
+
+// PriceWatches returns every watch on a product
+func (r *ProductRepository) PriceWatches(ctx context.Context, productID uuid.UUID) ([]models.PriceWatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.PriceWatches")
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query.PriceWatchesByProduct, productID)
+	if err != nil {
+		return nil, fmt.Errorf("list price watches of %s: %w", productID, err)
+	}
+	defer rows.Close()
+
+	var watches []models.PriceWatch
+	for rows.Next() {
+		var w models.PriceWatch
+		if err := rows.Scan(&w.ID, &w.ProductID, &w.Email, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		watches = append(watches, w)
+	}
+	return watches, rows.Err()
+}
+
+// ClearPriceWatches removes every watch on a product
+func (r *ProductRepository) ClearPriceWatches(ctx context.Context, productID uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ClearPriceWatches")
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, query.ClearPriceWatches, productID); err != nil {
+		return fmt.Errorf("clear price watches of %s: %w", productID, err)
+	}
+	return nil
+}
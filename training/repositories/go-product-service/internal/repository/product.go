@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/outbox"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// skuConstraint is the unique constraint on products.sku
+const skuConstraint = "products_sku_key"
+
+// insertProduct inserts every stored column of a new product
+const insertProduct = `INSERT INTO products (id, name, description, price, currency, sale_price, sale_starts_at, sale_ends_at, cost, category, sku, barcode, stock, reorder_level, is_active, status, metadata, content_hash, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW(), NOW())
+RETURNING created_at, updated_at`
+
+// insertProductIfMissing inserts a product like insertProduct unless its ID
+// is already taken, live or deleted, in which case no row is returned
+const insertProductIfMissing = `INSERT INTO products (id, name, description, price, currency, sale_price, sale_starts_at, sale_ends_at, cost, category, sku, barcode, stock, reorder_level, is_active, status, metadata, content_hash, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW(), NOW())
+ON CONFLICT (id) DO NOTHING
+RETURNING created_at, updated_at`
+
+// updateProduct overwrites the editable columns of a live product; reserved
+// stock is owned by reservations and never written here
+const updateProduct = `UPDATE products SET name = $2, description = $3, price = $4, currency = $5, sale_price = $6, sale_starts_at = $7, sale_ends_at = $8,
+	cost = $9, category = $10, sku = $11, barcode = $12, stock = $13, reorder_level = $14, is_active = $15, status = $16, metadata = $17,
+	content_hash = $18, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING updated_at`
+
+// softDeleteProduct marks a live product deleted and returns it as it was
+const softDeleteProduct = `UPDATE products SET deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING ` + query.ProductColumns
+
+// Live product lookups; the FOR UPDATE variant locks the row for a write
+const (
+	selectProductByID          = "SELECT " + query.ProductColumns + " FROM products WHERE id = $1 AND deleted_at IS NULL"
+	selectProductByIDForUpdate = selectProductByID + " FOR UPDATE"
+	selectProductBySKU         = "SELECT " + query.ProductColumns + " FROM products WHERE sku = $1 AND deleted_at IS NULL"
+)
+
+// ProductRepository stores products in Postgres. Every write records its
+// audit entry and outbox event in the same transaction as the row itself,
+// attributed to the caller in the context.
+type ProductRepository struct {
+	db *sql.DB
+
+	// reads picks the database for reads outside a transaction
+	reads *dbroute.Router
+
+	// maxUnpaginatedRows caps List calls without a limit; zero disables the cap
+	maxUnpaginatedRows int
+}
+
+// NewProductRepository creates a repository on db
+func NewProductRepository(db *sql.DB) *ProductRepository {
+	return &ProductRepository{db: db, reads: dbroute.NewRouter(db, nil)}
+}
+
+// SetReplica sends reads outside a transaction to replica unless their
+// context asks for strong consistency; writes and transactions always use
+// the primary. Call it before the repository is shared.
+func (r *ProductRepository) SetReplica(replica *sql.DB) {
+	r.reads = dbroute.NewRouter(r.db, replica)
+}
+
+// reader returns the database reads for ctx run against
+func (r *ProductRepository) reader(ctx context.Context) *sql.DB {
+	return r.reads.Reader(ctx)
+}
+
+// SetMaxUnpaginatedRows makes List fail with a *query.TooManyRowsError,
+// rather than load the whole table, when a filter without a limit matches
+// more than n rows; call it before the repository is shared
+func (r *ProductRepository) SetMaxUnpaginatedRows(n int) {
+	r.maxUnpaginatedRows = n
+}
+
+// DB returns the primary database, for operations that run their own transactions
+func (r *ProductRepository) DB() *sql.DB {
+	return r.db
+}
+
+// Create inserts p, assigning its ID and content hash and recording the
+// caller in ctx as its creator. A non-nil opening movement, which must name
+// p's ID, is recorded in the same transaction so the stock ledger starts
+// with the product.
+func (r *ProductRepository) Create(ctx context.Context, p *models.Product, opening *models.StockMovement) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.Create")
+	defer span.End()
+
+	_, err := r.insert(ctx, insertProduct, p, opening)
+	return err
+}
+
+// CreateIfMissing inserts p under its preset ID like Create, reporting false
+// without writing anything when a product already has that ID
+func (r *ProductRepository) CreateIfMissing(ctx context.Context, p *models.Product, opening *models.StockMovement) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CreateIfMissing")
+	defer span.End()
+
+	return r.insert(ctx, insertProductIfMissing, p, opening)
+}
+
+// insert runs the insert q for p and its opening movement, reporting
+// whether q returned the new row
+func (r *ProductRepository) insert(ctx context.Context, q string, p *models.Product, opening *models.StockMovement) (bool, error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	if p.Status == "" {
+		p.Status = models.StatusActive
+	}
+	p.ContentHash = p.ComputeContentHash()
+	caller := auth.Caller(ctx)
+	p.CreatedBy = &caller
+
+	inserted := true
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		args := append(productArgs(p), p.CreatedBy)
+		err := tx.QueryRowContext(ctx, q, args...).Scan(&p.CreatedAt, &p.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			inserted = false
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("insert product: %w", mapWriteError(err))
+		}
+		if opening != nil {
+			if _, err := tx.ExecContext(ctx, query.InsertStockMovement, opening.ID, opening.ProductID, opening.Delta, opening.Reason); err != nil {
+				return fmt.Errorf("record opening stock: %w", err)
+			}
+		}
+		return r.recordChange(ctx, tx, models.AuditActionCreate, models.EventProductCreated, nil, p)
+	})
+	return inserted && err == nil, err
+}
+
+// GetByID returns the live product with the given ID, or sql.ErrNoRows
+func (r *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.GetByID")
+	defer span.End()
+
+	p, err := query.ScanProduct(r.reader(ctx).QueryRowContext(ctx, selectProductByID, id))
+	if err != nil {
+		return nil, fmt.Errorf("get product %s: %w", id, err)
+	}
+	return p, nil
+}
+
+// GetBySKU returns the live product with the given SKU, or sql.ErrNoRows
+func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.GetBySKU")
+	defer span.End()
+
+	p, err := query.ScanProduct(r.reader(ctx).QueryRowContext(ctx, selectProductBySKU, sku))
+	if err != nil {
+		return nil, fmt.Errorf("get product by sku: %w", err)
+	}
+	return p, nil
+}
+
+// List returns the products matching f and their total count. With a limit
+// the page holds one row beyond it, which query.Page trims into a cursor.
+// Without one at most the unpaginated cap is loaded; streaming exports do not
+// use List and are not capped.
+func (r *ProductRepository) List(ctx context.Context, f *models.ProductFilter) ([]models.Product, int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.List")
+	defer span.End()
+
+	bounded := *f
+	capped := query.CapUnpaginated(&bounded, r.maxUnpaginatedRows)
+	sqlText, args, err := query.ListProducts(&bounded)
+	if err != nil {
+		return nil, 0, err
+	}
+	products, err := r.QueryProducts(ctx, sqlText, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list products: %w", err)
+	}
+	if capped {
+		if err := query.CheckUnpaginated(len(products), r.maxUnpaginatedRows); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	countText, countArgs, err := query.CountProducts(f)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	if err := r.reader(ctx).QueryRowContext(ctx, countText, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count products: %w", err)
+	}
+	return products, total, nil
+}
+
+// Update locks the live product id, applies change to the locked row and
+// stores every editable field, refreshing the content hash. change starts
+// from the committed row, so stock moved since the caller last read the
+// product is kept rather than overwritten; an error from change aborts the
+// update.
+func (r *ProductRepository) Update(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.Update")
+	defer span.End()
+
+	_, p, err := r.update(ctx, id, change, true)
+	return p, err
+}
+
+// UpdateUnrecorded stores a change like Update but writes no audit entry or
+// event, returning the state it replaced along with the new one so the
+// caller can record the change later with RecordUpdate
+func (r *ProductRepository) UpdateUnrecorded(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (before, after *models.Product, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.UpdateUnrecorded")
+	defer span.End()
+
+	return r.update(ctx, id, change, false)
+}
+
+// RecordUpdate writes the audit entry and outbox event of an update from
+// before to after that was stored with UpdateUnrecorded
+func (r *ProductRepository) RecordUpdate(ctx context.Context, before, after *models.Product) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.RecordUpdate")
+	defer span.End()
+
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, before, after)
+	})
+}
+
+// update applies change to product id under its row lock, recording the
+// change when record is set
+func (r *ProductRepository) update(ctx context.Context, id uuid.UUID, change func(p *models.Product) error, record bool) (before, after *models.Product, err error) {
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		locked, err := query.ScanProduct(tx.QueryRowContext(ctx, selectProductByIDForUpdate, id))
+		if err != nil {
+			return fmt.Errorf("lock product %s: %w", id, err)
+		}
+		p := *locked
+		if err := change(&p); err != nil {
+			return err
+		}
+		p.ContentHash = p.ComputeContentHash()
+		if err := tx.QueryRowContext(ctx, updateProduct, productArgs(&p)...).Scan(&p.UpdatedAt); err != nil {
+			return fmt.Errorf("update product %s: %w", id, mapWriteError(err))
+		}
+		before, after = locked, &p
+		if !record {
+			return nil
+		}
+		return r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, locked, &p)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
+
+// Delete soft-deletes the product, or returns sql.ErrNoRows if it is not live
+func (r *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.Delete")
+	defer span.End()
+
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := query.ScanProduct(tx.QueryRowContext(ctx, softDeleteProduct, id))
+		if err != nil {
+			return fmt.Errorf("delete product %s: %w", id, err)
+		}
+		return r.recordChange(ctx, tx, models.AuditActionDelete, models.EventProductDeleted, before, nil)
+	})
+}
+
+// recordChange writes the audit entry and outbox event for a product write;
+// after is nil for a deletion, whose event carries the deleted product
+func (r *ProductRepository) recordChange(ctx context.Context, tx *sql.Tx, action, eventType string, before, after *models.Product) error {
+	entry := &models.AuditEntry{
+		Actor:      auth.Caller(ctx),
+		Action:     action,
+		EntityType: models.AuditEntityProduct,
+	}
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+	entry.EntityID = subject.ID.String()
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+	if err := audit.Record(ctx, tx, entry); err != nil {
+		return fmt.Errorf("record audit entry: %w", err)
+	}
+	if err := outbox.EnqueueProduct(ctx, tx, eventType, subject); err != nil {
+		return fmt.Errorf("enqueue %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// QueryProducts runs a SELECT of query.ProductColumns built by the query
+// package and scans every row
+func (r *ProductRepository) QueryProducts(ctx context.Context, q string, args ...interface{}) ([]models.Product, error) {
+	return queryProducts(ctx, r.reader(ctx), q, args...)
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryProducts runs a SELECT of query.ProductColumns on db or a transaction
+// and scans every row
+func queryProducts(ctx context.Context, db querier, q string, args ...interface{}) ([]models.Product, error) {
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+	for rows.Next() {
+		p, err := query.ScanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *p)
+	}
+	return products, rows.Err()
+}
+
+// inTx runs fn in a transaction, committing only when it succeeds
+func (r *ProductRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// productArgs returns the arguments of insertProduct and updateProduct
+func productArgs(p *models.Product) []interface{} {
+	return []interface{}{
+		p.ID, p.Name, p.Description, p.Price, p.Currency, p.SalePrice, p.SaleStartsAt, p.SaleEndsAt, p.Cost, p.Category, p.SKU, p.Barcode,
+		p.Stock, p.ReorderLevel, p.IsActive, p.Status, p.Metadata, p.ContentHash,
+	}
+}
+
+// mapWriteError turns a violation of the SKU constraint into models.ErrDuplicateSKU
+func mapWriteError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == skuConstraint {
+		return models.ErrDuplicateSKU
+	}
+	return err
+}
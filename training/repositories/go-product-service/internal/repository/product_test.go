@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/query/querytest"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func newMock(t *testing.T) (*ProductRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+	return NewProductRepository(db), mock
+}
+
+func testProduct() models.Product {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return models.Product{
+		ID: uuid.New(), Name: "Desk", Price: 120, Category: "furniture", SKU: "DESK-1",
+		Stock: 3, IsActive: true, Status: models.StatusActive, CreatedAt: now, UpdatedAt: now,
+	}
+}
+
+func TestCreateRecordsAuditAndOutboxInTransaction(t *testing.T) {
+	repo, mock := newMock(t)
+	ctx := auth.WithCaller(context.Background(), "client-1")
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), "client-1", models.AuditActionCreate, models.AuditEntityProduct, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(models.EventProductCreated, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	p := &models.Product{Name: "Desk", Price: 120, Category: "furniture", SKU: "DESK-1"}
+	if err := repo.Create(ctx, p, nil); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if p.ID == uuid.Nil || p.ContentHash != p.ComputeContentHash() || p.Status != models.StatusActive {
+		t.Fatalf("expected id, content hash and status to be set: %+v", p)
+	}
+}
+
+func TestCreateMapsDuplicateSKU(t *testing.T) {
+	repo, mock := newMock(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO products").
+		WillReturnError(&pq.Error{Code: uniqueViolation, Constraint: skuConstraint})
+	mock.ExpectRollback()
+
+	err := repo.Create(context.Background(), &models.Product{Name: "Desk", SKU: "DESK-1"}, nil)
+	if !errors.Is(err, models.ErrDuplicateSKU) {
+		t.Fatalf("expected ErrDuplicateSKU, got %v", err)
+	}
+}
+
+func TestUpdateAuditsBeforeAndAfter(t *testing.T) {
+	repo, mock := newMock(t)
+	before := testProduct()
+	before.Reserved = 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FROM products WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+		WithArgs(before.ID).WillReturnRows(querytest.ProductRows(before))
+	mock.ExpectQuery("UPDATE products SET name").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), auth.AnonymousCaller, models.AuditActionUpdate, models.AuditEntityProduct, before.ID.String(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(models.EventProductUpdated, before.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	p, err := repo.Update(context.Background(), before.ID, func(p *models.Product) error {
+		p.Price = 99
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if p.Reserved != 2 || p.Price != 99 {
+		t.Fatalf("expected the change applied to the stored row, got %+v", p)
+	}
+}
+
+func TestUpdateKeepsStockMovedSinceTheCallerRead(t *testing.T) {
+	repo, mock := newMock(t)
+	read := testProduct()
+	locked := read
+	locked.Stock = 7
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .+ FOR UPDATE").WithArgs(read.ID).WillReturnRows(querytest.ProductRows(locked))
+	args := make([]driver.Value, 18)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	args[3], args[12] = 99.0, 7
+	mock.ExpectQuery("UPDATE products SET name").WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	p, err := repo.Update(context.Background(), read.ID, func(p *models.Product) error {
+		p.Price = 99
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if p.Stock != 7 {
+		t.Fatalf("expected the stock committed since the read kept, got %d", p.Stock)
+	}
+}
+
+func TestDeleteMissingProductReturnsNoRows(t *testing.T) {
+	repo, mock := newMock(t)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE products SET deleted_at").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(querytest.ProductColumns()))
+	mock.ExpectRollback()
+
+	if err := repo.Delete(context.Background(), id); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestListReturnsPageAndTotal(t *testing.T) {
+	repo, mock := newMock(t)
+	a, b := testProduct(), testProduct()
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL AND category = \\$1 ORDER BY created_at DESC, id DESC LIMIT \\$2").
+		WithArgs("furniture", 3).WillReturnRows(querytest.ProductRows(a, b))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM products").WithArgs("furniture").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	products, total, err := repo.List(context.Background(), &models.ProductFilter{Category: "furniture", Limit: 2})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(products) != 2 || total != 7 || products[1].ID != b.ID {
+		t.Fatalf("unexpected result: %d products, total %d", len(products), total)
+	}
+}
+
+func TestListRejectsUnpaginatedOverflow(t *testing.T) {
+	repo, mock := newMock(t)
+	repo.SetMaxUnpaginatedRows(2)
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\$1").
+		WithArgs(3).WillReturnRows(querytest.ProductRows(testProduct(), testProduct(), testProduct()))
+
+	_, _, err := repo.List(context.Background(), &models.ProductFilter{})
+	var tooMany *query.TooManyRowsError
+	if !errors.As(err, &tooMany) || tooMany.Max != 2 {
+		t.Fatalf("expected TooManyRowsError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "streaming export") {
+		t.Fatalf("error should point at the streaming export: %v", err)
+	}
+}
+
+func TestListUnpaginatedWithinCap(t *testing.T) {
+	repo, mock := newMock(t)
+	repo.SetMaxUnpaginatedRows(2)
+
+	mock.ExpectQuery("SELECT .+ FROM products WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \\$1").
+		WithArgs(3).WillReturnRows(querytest.ProductRows(testProduct(), testProduct()))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM products").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	products, total, err := repo.List(context.Background(), &models.ProductFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(products) != 2 || total != 2 {
+		t.Fatalf("unexpected result: %d products, total %d", len(products), total)
+	}
+}
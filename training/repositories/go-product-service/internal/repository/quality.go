@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// LowQualityProducts returns a page of live products scoring at most
+// f.MaxScore under weights w, worst first
+func (r *ProductRepository) LowQualityProducts(ctx context.Context, f *models.LowQualityFilter, w models.QualityWeights) ([]models.ScoredProduct, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.LowQualityProducts")
+	defer span.End()
+
+	q, args := query.LowQualityProducts(f, w)
+	scored := []models.ScoredProduct{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var score int
+		p, err := query.ScanProduct(rows, &score)
+		if err != nil {
+			return err
+		}
+		scored = append(scored, models.ScoredProduct{Product: *p, DataQualityScore: score})
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("low quality products: %w", err)
+	}
+	return scored, nil
+}
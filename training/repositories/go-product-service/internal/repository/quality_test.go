@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestLowQualityProductsScoreSeededGaps(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	seed := func(sku, description string, stock int, image, tag bool) uuid.UUID {
+		t.Helper()
+		p := &models.Product{Name: sku, Description: description, Price: 10, Category: "furniture", SKU: sku, Stock: stock, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		if image {
+			if _, err := db.Exec("INSERT INTO product_images (product_id, url) VALUES ($1, 'https://img.example/1.jpg')", p.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tag {
+			if _, err := db.Exec("INSERT INTO product_tags (product_id, tag) VALUES ($1, 'office')", p.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return p.ID
+	}
+	seed("COMPLETE", "A desk", 5, true, true)
+	noTags := seed("NO-TAGS", "A desk", 5, true, false)
+	noImage := seed("NO-IMAGE", "A desk", 5, false, false)
+	bare := seed("BARE", "", 0, false, false)
+
+	weights := models.QualityWeights{MissingDescription: 40, NoImage: 30, ZeroStock: 20, NoTags: 10}
+	scored, err := repo.LowQualityProducts(ctx, &models.LowQualityFilter{MaxScore: 95, Limit: 10}, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id    uuid.UUID
+		score int
+	}{{bare, 0}, {noImage, 60}, {noTags, 90}}
+	if len(scored) != len(want) {
+		t.Fatalf("expected %d products at or below 95, got %d", len(want), len(scored))
+	}
+	for i, w := range want {
+		if scored[i].ID != w.id || scored[i].DataQualityScore != w.score {
+			t.Fatalf("position %d: expected %s scoring %d, got %s scoring %d", i, w.id, w.score, scored[i].ID, scored[i].DataQualityScore)
+		}
+	}
+}
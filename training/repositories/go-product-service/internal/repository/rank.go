@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// ProductRank returns the position of a product under q, or sql.ErrNoRows
+// when the product is missing or deleted
+func (r *ProductRepository) ProductRank(ctx context.Context, id uuid.UUID, q *models.RankQuery) (*models.ProductRank, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ProductRank")
+	defer span.End()
+
+	stmt, args, err := query.ProductRank(id, q)
+	if err != nil {
+		return nil, err
+	}
+	rank := &models.ProductRank{ProductID: id, SortBy: q.SortBy, SortOrder: q.SortOrder, Within: q.Within}
+	if err := r.reader(ctx).QueryRowContext(ctx, stmt, args...).Scan(&rank.Rank, &rank.Total); err != nil {
+		return nil, fmt.Errorf("rank product %s: %w", id, err)
+	}
+	return rank, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+)
+
+func TestProductRankByPriceWithinCategory(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	seed := func(sku, category string, price float64) *models.Product {
+		t.Helper()
+		p := &models.Product{Name: sku, Price: price, Category: category, SKU: sku, Stock: 1, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	seed("CHEAP", "furniture", 10)
+	target := seed("MID", "furniture", 50)
+	seed("DEAR", "furniture", 90)
+	seed("LAMP", "lighting", 20)
+
+	rank, err := repo.ProductRank(ctx, target.ID, &models.RankQuery{SortBy: "price", SortOrder: "desc", Within: "category"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank.Rank != 2 || rank.Total != 3 {
+		t.Fatalf("expected #2 of 3 in furniture, got #%d of %d", rank.Rank, rank.Total)
+	}
+
+	rank, err = repo.ProductRank(ctx, target.ID, &models.RankQuery{SortBy: "price", SortOrder: "asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank.Rank != 3 || rank.Total != 4 {
+		t.Fatalf("expected #3 of 4 overall, got #%d of %d", rank.Rank, rank.Total)
+	}
+}
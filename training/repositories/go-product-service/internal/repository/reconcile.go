@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ReconcileStock sets each counted product's stock to its counted quantity
+// in one transaction, recording every variance as a stock movement
+func (r *ProductRepository) ReconcileStock(ctx context.Context, req *models.ReconcileStockRequest) (*models.ReconcileResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ReconcileStock")
+	defer span.End()
+
+	return inventory.Reconcile(ctx, r.db, req)
+}
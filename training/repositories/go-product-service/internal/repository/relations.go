@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// LoadRelations loads the images, variants, tags, translations and price
+// tiers of the given products with one query per relation, however many
+// products there are
+func (r *ProductRepository) LoadRelations(ctx context.Context, ids []uuid.UUID) (*models.ProductRelations, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.LoadRelations")
+	defer span.End()
+
+	rel := &models.ProductRelations{}
+	arg := pq.Array(ids)
+	loads := []struct {
+		name string
+		q    string
+		scan func(rows *sql.Rows) error
+	}{
+		{"images", query.ImagesByProductIDs, func(rows *sql.Rows) error {
+			var img models.ProductImage
+			if err := rows.Scan(&img.ID, &img.ProductID, &img.URL, &img.Position, &img.CreatedAt); err != nil {
+				return err
+			}
+			rel.Images = append(rel.Images, img)
+			return nil
+		}},
+		{"variants", query.VariantsByProductIDs, func(rows *sql.Rows) error {
+			var v models.ProductVariant
+			if err := rows.Scan(&v.ID, &v.ProductID, &v.SKU, &v.Name, &v.Price, &v.Stock, &v.Attributes, &v.CreatedAt, &v.UpdatedAt); err != nil {
+				return err
+			}
+			rel.Variants = append(rel.Variants, v)
+			return nil
+		}},
+		{"tags", query.TagsByProductIDs, func(rows *sql.Rows) error {
+			var t models.ProductTag
+			if err := rows.Scan(&t.ProductID, &t.Tag); err != nil {
+				return err
+			}
+			rel.Tags = append(rel.Tags, t)
+			return nil
+		}},
+		{"translations", query.TranslationsByProductIDs, func(rows *sql.Rows) error {
+			var t models.ProductTranslation
+			if err := rows.Scan(&t.ProductID, &t.Locale, &t.Name, &t.Description); err != nil {
+				return err
+			}
+			rel.Translations = append(rel.Translations, t)
+			return nil
+		}},
+		{"price tiers", query.PriceTiersByProductIDs, func(rows *sql.Rows) error {
+			var t models.PriceTier
+			if err := rows.Scan(&t.ProductID, &t.MinQty, &t.Price); err != nil {
+				return err
+			}
+			rel.PriceTiers = append(rel.PriceTiers, t)
+			return nil
+		}},
+	}
+	for _, load := range loads {
+		if err := r.eachRow(ctx, load.scan, load.q, arg); err != nil {
+			return nil, fmt.Errorf("load %s: %w", load.name, err)
+		}
+	}
+	return rel, nil
+}
+
+// eachRow runs the read q and calls scan for every row
+func (r *ProductRepository) eachRow(ctx context.Context, scan func(rows *sql.Rows) error, q string, args ...interface{}) error {
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// writeChild runs write in a transaction holding the lock on a live parent
+// product, with touch also advancing the parent's updated_at as part of the
+// same write; it returns sql.ErrNoRows if the product is not live
+func (r *ProductRepository) writeChild(ctx context.Context, productID uuid.UUID, touch bool, write func(tx *sql.Tx) error) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := query.ScanProduct(tx.QueryRowContext(ctx, selectProductByIDForUpdate, productID)); err != nil {
+			return fmt.Errorf("lock product %s: %w", productID, err)
+		}
+		if err := write(tx); err != nil {
+			return err
+		}
+		if touch {
+			if _, err := tx.ExecContext(ctx, query.TouchProduct, productID); err != nil {
+				return fmt.Errorf("touch product: %w", err)
+			}
+		}
+		return nil
+	})
+}
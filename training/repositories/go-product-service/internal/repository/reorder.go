@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ReorderSuggestions returns a page of suggestions for active products at or
+// below their reorder level, sized from their sales over the last
+// windowDays to cover coverDays, fastest sellers first
+func (r *ProductRepository) ReorderSuggestions(ctx context.Context, f *models.ReorderFilter, windowDays, coverDays int) ([]models.ReorderSuggestion, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ReorderSuggestions")
+	defer span.End()
+
+	q, args := query.ReorderCandidates(f, windowDays)
+	suggestions := []models.ReorderSuggestion{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var velocity float64
+		p, err := query.ScanProduct(rows, &velocity)
+		if err != nil {
+			return err
+		}
+		suggestions = append(suggestions, models.SuggestReorder(p, velocity, coverDays))
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reorder suggestions: %w", err)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ReserveBatch reserves stock for every line item in one transaction like
+// inventory.ReserveBatch, recording each reserved product's write like Update
+func (r *ProductRepository) ReserveBatch(ctx context.Context, req *models.ReserveBatchRequest) ([]models.Reservation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ReserveBatch")
+	defer span.End()
+
+	return inventory.ReserveBatch(ctx, r.db, req, func(tx *sql.Tx, before, after *models.Product) error {
+		return r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, before, after)
+	})
+}
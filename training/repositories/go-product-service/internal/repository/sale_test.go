@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+)
+
+func TestUpcomingSalesKeepOnlySalesStartingInWindow(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	seed := func(sku string, startsIn time.Duration) {
+		t.Helper()
+		sale, starts := 9.0, now.Add(startsIn)
+		p := &models.Product{Name: sku, Price: 10, Category: "furniture", SKU: sku, IsActive: true, SalePrice: &sale, SaleStartsAt: &starts}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seed("STARTED", -time.Hour)
+	seed("IN-THREE-DAYS", 72*time.Hour)
+	seed("TOMORROW", 24*time.Hour)
+	seed("IN-TEN-DAYS", 240*time.Hour)
+
+	q, args := query.UpcomingSales(&models.UpcomingSalesFilter{Limit: 10}, now, 7*24*time.Hour)
+	products, err := repo.QueryProducts(ctx, q, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 2 || products[0].SKU != "TOMORROW" || products[1].SKU != "IN-THREE-DAYS" {
+		t.Fatalf("expected TOMORROW then IN-THREE-DAYS, got %+v", products)
+	}
+}
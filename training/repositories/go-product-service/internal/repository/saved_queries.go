@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/lib/pq"
+)
+
+// CreateSavedQuery stores q under its name, or fails with
+// models.ErrSavedQueryExists when the name is taken
+func (r *ProductRepository) CreateSavedQuery(ctx context.Context, q *models.SavedQuery) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CreateSavedQuery")
+	defer span.End()
+
+	err := scanSavedQuery(r.db.QueryRowContext(ctx, query.InsertSavedQuery, q.Name, q.Description, q.Filter), q)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: %q", models.ErrSavedQueryExists, q.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("create saved query %q: %w", q.Name, err)
+	}
+	return nil
+}
+
+// GetSavedQuery returns the named query, or models.ErrSavedQueryNotFound
+func (r *ProductRepository) GetSavedQuery(ctx context.Context, name string) (*models.SavedQuery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.GetSavedQuery")
+	defer span.End()
+
+	var q models.SavedQuery
+	err := scanSavedQuery(r.db.QueryRowContext(ctx, query.GetSavedQuery, name), &q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", models.ErrSavedQueryNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get saved query %q: %w", name, err)
+	}
+	return &q, nil
+}
+
+// ListSavedQueries returns a page of saved queries by name
+func (r *ProductRepository) ListSavedQueries(ctx context.Context, page *models.PageFilter) ([]models.SavedQuery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.ListSavedQueries")
+	defer span.End()
+
+	queries := []models.SavedQuery{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var q models.SavedQuery
+		if err := scanSavedQuery(rows, &q); err != nil {
+			return err
+		}
+		queries = append(queries, q)
+		return nil
+	}, query.ListSavedQueries, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("list saved queries: %w", err)
+	}
+	return queries, nil
+}
+
+// UpdateSavedQuery replaces the description and filter of the query named
+// q.Name, or fails with models.ErrSavedQueryNotFound
+func (r *ProductRepository) UpdateSavedQuery(ctx context.Context, q *models.SavedQuery) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.UpdateSavedQuery")
+	defer span.End()
+
+	err := scanSavedQuery(r.db.QueryRowContext(ctx, query.UpdateSavedQuery, q.Name, q.Description, q.Filter), q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %q", models.ErrSavedQueryNotFound, q.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("update saved query %q: %w", q.Name, err)
+	}
+	return nil
+}
+
+// DeleteSavedQuery removes the named query, or fails with models.ErrSavedQueryNotFound
+func (r *ProductRepository) DeleteSavedQuery(ctx context.Context, name string) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeleteSavedQuery")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx, query.DeleteSavedQuery, name)
+	if err != nil {
+		return fmt.Errorf("delete saved query %q: %w", name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %q", models.ErrSavedQueryNotFound, name)
+	}
+	return nil
+}
+
+// scanSavedQuery scans a row of query.SavedQueryColumns into q
+func scanSavedQuery(s query.Scanner, q *models.SavedQuery) error {
+	return s.Scan(&q.Name, &q.Description, &q.Filter, &q.CreatedAt, &q.UpdatedAt)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// SuggestNames returns up to limit active product names trigram-similar to
+// term, most similar first
+func (r *ProductRepository) SuggestNames(ctx context.Context, term string, limit int) ([]string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SuggestNames")
+	defer span.End()
+
+	q, args := query.SuggestProductNames(term, limit)
+	names := []string{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("suggest names: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+)
+
+func TestSearchMatchModes(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	for _, p := range []*models.Product{
+		{Name: "Wireless Mouse", Description: "Quiet clicks for the office", Price: 25, Category: "electronics", SKU: "MOUSE-1", IsActive: true},
+		{Name: "Desk Lamp", Description: "Warm light, wireless charging base", Price: 40, Category: "lighting", SKU: "LAMP-1", IsActive: true},
+	} {
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		mode, term string
+		want       []string
+	}{
+		{models.MatchModePrefix, "wir", []string{"MOUSE-1"}},
+		{models.MatchModePrefix, "mou", []string{"MOUSE-1"}},
+		{models.MatchModePrefix, "ire", nil},
+		{models.MatchModePrefix, "charging", nil},
+		{models.MatchModeWord, "wireless", []string{"LAMP-1", "MOUSE-1"}},
+		{models.MatchModeWord, "wir", nil},
+		{models.MatchModeWord, "charging base", []string{"LAMP-1"}},
+		{models.MatchModePhrase, "charging base", []string{"LAMP-1"}},
+		{models.MatchModePhrase, "base charging", nil},
+		{"", "wir", []string{"MOUSE-1"}},
+		{"", "charging", []string{"LAMP-1"}},
+		{"", "ire", nil},
+	} {
+		f := &models.ProductFilter{Search: tc.term, MatchMode: tc.mode, SortBy: "sku", SortOrder: "asc", Limit: 10}
+		products, _, err := repo.List(ctx, f)
+		if err != nil {
+			t.Fatalf("%q %q: %v", tc.mode, tc.term, err)
+		}
+		var got []string
+		for _, p := range products {
+			got = append(got, p.SKU)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("mode %q, term %q: expected %v, got %v", tc.mode, tc.term, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("mode %q, term %q: expected %v, got %v", tc.mode, tc.term, tc.want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/sku"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// SwapSKUs exchanges the SKUs of products a and b in one transaction,
+// auditing both changes as the caller in ctx
+func (r *ProductRepository) SwapSKUs(ctx context.Context, a, b uuid.UUID) ([]models.SKUSwapResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SwapSKUs")
+	defer span.End()
+
+	return sku.Swap(ctx, r.db, auth.Caller(ctx), a, b)
+}
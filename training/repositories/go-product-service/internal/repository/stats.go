@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// Stats returns the dashboard summary of the live catalog in two aggregate queries
+func (r *ProductRepository) Stats(ctx context.Context) (*models.ProductStats, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.Stats")
+	defer span.End()
+
+	var st models.ProductStats
+	err := r.db.QueryRowContext(ctx, query.ProductStatsSummary).
+		Scan(&st.TotalProducts, &st.ActiveProducts, &st.OutOfStock, &st.InventoryValue)
+	if err != nil {
+		return nil, fmt.Errorf("product stats: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query.ProductStatsByCategory)
+	if err != nil {
+		return nil, fmt.Errorf("product stats by category: %w", err)
+	}
+	defer rows.Close()
+
+	st.Categories = []models.CategoryCount{}
+	for rows.Next() {
+		var c models.CategoryCount
+		if err := rows.Scan(&c.Category, &c.Count); err != nil {
+			return nil, err
+		}
+		st.Categories = append(st.Categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// CreationTimeline returns the number of products created per interval of f,
+// oldest bucket first
+func (r *ProductRepository) CreationTimeline(ctx context.Context, f *models.CreationTimelineFilter) ([]models.TimelineBucket, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CreationTimeline")
+	defer span.End()
+
+	q, args, err := query.CreationTimeline(f)
+	if err != nil {
+		return nil, err
+	}
+	buckets := []models.TimelineBucket{}
+	err = r.eachRow(ctx, func(rows *sql.Rows) error {
+		var b models.TimelineBucket
+		if err := rows.Scan(&b.Bucket, &b.Count); err != nil {
+			return err
+		}
+		buckets = append(buckets, b)
+		return nil
+	}, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("creation timeline: %w", err)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// PublishDraft makes the draft live, recording the change like any update.
+// A product that is no longer a draft returns models.ErrNotDraft; a missing
+// one sql.ErrNoRows.
+func (r *ProductRepository) PublishDraft(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.PublishDraft")
+	defer span.End()
+
+	return r.changeStatus(ctx, id, models.ErrNotDraft, query.PublishDraft, id)
+}
+
+// TransitionStatus moves the product from status from to status to,
+// recording the change like any update. A product whose status is no longer
+// from returns a *models.TransitionError; a missing one sql.ErrNoRows.
+func (r *ProductRepository) TransitionStatus(ctx context.Context, id uuid.UUID, from, to string) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.TransitionStatus")
+	defer span.End()
+
+	return r.changeStatus(ctx, id, &models.TransitionError{From: from, To: to}, query.TransitionStatus, id, to, from)
+}
+
+// changeStatus locks the product, runs the status update q and records the
+// change; stale is returned when q matches no row because the status moved on
+func (r *ProductRepository) changeStatus(ctx context.Context, id uuid.UUID, stale error, q string, args ...interface{}) (*models.Product, error) {
+	var after *models.Product
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := query.ScanProduct(tx.QueryRowContext(ctx, selectProductByIDForUpdate, id))
+		if err != nil {
+			return fmt.Errorf("lock product %s: %w", id, err)
+		}
+		after, err = query.ScanProduct(tx.QueryRowContext(ctx, q, args...))
+		if errors.Is(err, sql.ErrNoRows) {
+			return stale
+		}
+		if err != nil {
+			return fmt.Errorf("change status of %s: %w", id, err)
+		}
+		return r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return after, nil
+}
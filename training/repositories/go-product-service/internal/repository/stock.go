@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/inventory"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/outbox"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// AdjustStock applies the movement's delta to the product and records the
+// movement, its audit entry and its product.updated event in one
+// transaction. When activation turns IsActive off at zero stock or back on
+// after a restock, the same transaction also enqueues product.deactivated or
+// product.reactivated. A delta that would make stock negative returns an
+// *inventory.InsufficientStockError; a missing product sql.ErrNoRows.
+func (r *ProductRepository) AdjustStock(ctx context.Context, m *models.StockMovement, activation models.StockActivation) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.AdjustStock")
+	defer span.End()
+
+	var after *models.Product
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := query.ScanProduct(tx.QueryRowContext(ctx, selectProductByIDForUpdate, m.ProductID))
+		if err != nil {
+			return fmt.Errorf("lock product %s: %w", m.ProductID, err)
+		}
+
+		adjusted := *before
+		var wasActive bool
+		err = tx.QueryRowContext(ctx, query.AdjustStock, m.ProductID, m.Delta, activation.DeactivateOnZero, activation.ReactivateOnRestock).
+			Scan(&adjusted.Stock, &adjusted.IsActive, &wasActive)
+		if errors.Is(err, sql.ErrNoRows) {
+			return &inventory.InsufficientStockError{ProductID: m.ProductID, Requested: -m.Delta, Available: before.Stock}
+		}
+		if err != nil {
+			return fmt.Errorf("adjust stock of %s: %w", m.ProductID, err)
+		}
+		if _, err := tx.ExecContext(ctx, query.InsertStockMovement, m.ID, m.ProductID, m.Delta, m.Reason); err != nil {
+			return fmt.Errorf("record stock movement: %w", err)
+		}
+		if err := r.recordChange(ctx, tx, models.AuditActionUpdate, models.EventProductUpdated, before, &adjusted); err != nil {
+			return err
+		}
+		if event := models.ActivationEvent(wasActive, adjusted.IsActive); event != "" {
+			if err := outbox.EnqueueProduct(ctx, tx, event, &adjusted); err != nil {
+				return fmt.Errorf("enqueue %s: %w", event, err)
+			}
+		}
+		after = &adjusted
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return after, nil
+}
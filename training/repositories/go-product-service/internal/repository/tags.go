@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// TagCounts counts the live products carrying each tag used by at least
+// f.MinCount of them, most used first
+func (r *ProductRepository) TagCounts(ctx context.Context, f *models.TagCountFilter) ([]models.TagCount, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.TagCounts")
+	defer span.End()
+
+	counts := []models.TagCount{}
+	err := r.eachRow(ctx, func(rows *sql.Rows) error {
+		var tc models.TagCount
+		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
+			return err
+		}
+		counts = append(counts, tc)
+		return nil
+	}, query.TagCounts, f.MinCount)
+	if err != nil {
+		return nil, fmt.Errorf("tag counts: %w", err)
+	}
+	return counts, nil
+}
+
+// DeleteTag removes tag from every product in one transaction and audits
+// the deletion with the products affected; with touch those products get a
+// new updated_at. A tag no product carries fails with models.ErrTagNotFound.
+func (r *ProductRepository) DeleteTag(ctx context.Context, tag string, touch bool) (*models.DeleteTagResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeleteTag")
+	defer span.End()
+
+	result := &models.DeleteTagResult{Tag: tag, Products: []uuid.UUID{}}
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query.DeleteTag, tag)
+		if err != nil {
+			return fmt.Errorf("delete tag %q: %w", tag, err)
+		}
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			result.Products = append(result.Products, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(result.Products) == 0 {
+			return fmt.Errorf("%w: %q", models.ErrTagNotFound, tag)
+		}
+
+		if touch {
+			if _, err := tx.ExecContext(ctx, query.TouchProducts, pq.Array(result.Products)); err != nil {
+				return fmt.Errorf("touch products: %w", err)
+			}
+		}
+		after, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		err = audit.Record(ctx, tx, &models.AuditEntry{
+			Actor:      auth.Caller(ctx),
+			Action:     models.AuditActionDeleteTag,
+			EntityType: models.AuditEntityTag,
+			EntityID:   tag,
+			After:      after,
+		})
+		if err != nil {
+			return fmt.Errorf("record audit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/go-product-service/internal/database/databasetest"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestDeleteTagRemovesItEverywhere(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	seed := func(sku string, tags ...string) uuid.UUID {
+		t.Helper()
+		p := &models.Product{Name: sku, Price: 10, Category: "furniture", SKU: sku, IsActive: true}
+		if err := repo.Create(ctx, p, nil); err != nil {
+			t.Fatal(err)
+		}
+		for _, tag := range tags {
+			if _, err := db.ExecContext(ctx, "INSERT INTO product_tags (product_id, tag) VALUES ($1, $2)", p.ID, tag); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return p.ID
+	}
+	seed("OAK-1", "oak", "sale")
+	seed("OAK-2", "oak", "sale")
+	seed("PINE-1", "oak")
+
+	counts, err := repo.TagCounts(ctx, &models.TagCountFilter{MinCount: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts[0] != (models.TagCount{Tag: "oak", Count: 3}) || counts[1] != (models.TagCount{Tag: "sale", Count: 2}) {
+		t.Fatalf("expected oak 3 then sale 2, got %+v", counts)
+	}
+
+	result, err := repo.DeleteTag(ctx, "sale", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Products) != 2 {
+		t.Fatalf("expected sale removed from two products, got %+v", result)
+	}
+	if counts, err = repo.TagCounts(ctx, &models.TagCountFilter{MinCount: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts[0].Tag != "oak" {
+		t.Fatalf("expected only oak left, got %+v", counts)
+	}
+}
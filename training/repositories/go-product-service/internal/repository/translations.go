@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// SetTranslation stores a live product's translation for t.Locale,
+// replacing any existing one, with touch also advancing the product's
+// updated_at; it returns sql.ErrNoRows if the product is not live
+func (r *ProductRepository) SetTranslation(ctx context.Context, t *models.ProductTranslation, touch bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.SetTranslation")
+	defer span.End()
+
+	return r.writeChild(ctx, t.ProductID, touch, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query.UpsertTranslation, t.ProductID, t.Locale, t.Name, t.Description); err != nil {
+			return fmt.Errorf("store %s translation: %w", t.Locale, err)
+		}
+		return nil
+	})
+}
+
+// DeleteTranslation removes a live product's translation for locale, with
+// touch also advancing the product's updated_at. It returns sql.ErrNoRows if
+// the product is not live and models.ErrTranslationNotFound if it has no
+// translation for locale.
+func (r *ProductRepository) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string, touch bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeleteTranslation")
+	defer span.End()
+
+	return r.writeChild(ctx, productID, touch, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query.DeleteTranslation, productID, locale)
+		if err != nil {
+			return fmt.Errorf("delete %s translation: %w", locale, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("%w: %q", models.ErrTranslationNotFound, locale)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// variantSKUConstraint is the unique constraint on product_variants.sku
+const variantSKUConstraint = "product_variants_sku_key"
+
+// CreateVariant adds v to its live product, filling in its ID and
+// timestamps, with touch also advancing the product's updated_at; it returns
+// sql.ErrNoRows if the product is not live
+func (r *ProductRepository) CreateVariant(ctx context.Context, v *models.ProductVariant, touch bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.CreateVariant")
+	defer span.End()
+
+	return r.writeChild(ctx, v.ProductID, touch, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, query.InsertVariant, v.ProductID, v.SKU, v.Name, v.Price, v.Stock, v.Attributes).
+			Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == variantSKUConstraint {
+			return models.ErrDuplicateVariantSKU
+		}
+		if err != nil {
+			return fmt.Errorf("insert variant %q: %w", v.SKU, err)
+		}
+		return nil
+	})
+}
+
+// DeleteVariant removes one of a live product's variants, with touch also
+// advancing the product's updated_at. It returns sql.ErrNoRows if the
+// product is not live and models.ErrVariantNotFound if it has no such variant.
+func (r *ProductRepository) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID, touch bool) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductRepository.DeleteVariant")
+	defer span.End()
+
+	return r.writeChild(ctx, productID, touch, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query.DeleteVariant, variantID, productID)
+		if err != nil {
+			return fmt.Errorf("delete variant %s: %w", variantID, err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("%w: %s", models.ErrVariantNotFound, variantID)
+		}
+		return nil
+	})
+}
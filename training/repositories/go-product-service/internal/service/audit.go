@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ExportAudit streams the audit entries created in [f.From, f.To) to w and
+// returns the number written; it requires the admin scope
+func (s *ProductService) ExportAudit(ctx context.Context, w io.Writer, f *models.AuditExportFilter) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ExportAudit")
+	defer span.End()
+
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return 0, err
+	}
+	return audit.Export(ctx, s.repo.DB(), f.From, f.To, f.Format, w)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// defaultCartCurrency is the currency of products without one when
+// Options.DefaultCurrency is empty
+const defaultCartCurrency = "USD"
+
+// CheckAvailability reports, for every line item, whether its product has
+// enough unreserved stock. Nothing is reserved; unknown products are unavailable.
+func (s *ProductService) CheckAvailability(ctx context.Context, req *models.CheckAvailabilityRequest) ([]models.AvailabilityResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CheckAvailability")
+	defer span.End()
+
+	byID, err := s.lineProducts(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	return models.CheckAvailability(byID, req.Items), nil
+}
+
+// CartSnapshot reports the price, active sale price and availability of every
+// cart line from one query, with the availability CheckAvailability reports
+func (s *ProductService) CartSnapshot(ctx context.Context, req *models.CartSnapshotRequest) ([]models.CartSnapshotItem, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CartSnapshot")
+	defer span.End()
+
+	byID, err := s.lineProducts(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	currency := s.opts.DefaultCurrency
+	if currency == "" {
+		currency = defaultCartCurrency
+	}
+	return models.BuildCartSnapshot(byID, models.ActiveSalePrices(byID, s.opts.Now()), req.Items, currency), nil
+}
+
+// lineProducts loads the live products named by items, keyed by ID
+func (s *ProductService) lineProducts(ctx context.Context, items []models.StockLineItem) (map[uuid.UUID]*models.Product, error) {
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	products, err := s.repo.QueryProducts(ctx, query.ProductsByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+	return byID, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// LookupBarcodes returns the live products carrying any of the requested
+// barcodes, compared without leading zeros, and the barcodes none carries
+func (s *ProductService) LookupBarcodes(ctx context.Context, req *models.BarcodeLookupRequest) (*models.BarcodeLookupResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.LookupBarcodes")
+	defer span.End()
+
+	sqlText, args := query.ProductsByBarcodes(models.NormalizeBarcodes(req.Barcodes))
+	products, err := s.repo.QueryProducts(ctx, sqlText, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BarcodeLookupResult{
+		Products:  products,
+		Unmatched: models.UnmatchedBarcodes(req.Barcodes, products),
+	}, nil
+}
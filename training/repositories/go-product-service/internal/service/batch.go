@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// checkDuplicates returns a *models.DuplicateIDError for the repeated IDs of
+// a batch when Options.RejectDuplicateBatchIDs is set
+func (s *ProductService) checkDuplicates(duplicates []uuid.UUID) error {
+	if s.opts.RejectDuplicateBatchIDs && len(duplicates) > 0 {
+		return &models.DuplicateIDError{IDs: duplicates}
+	}
+	return nil
+}
+
+// GetProducts returns the live products with the requested IDs, each once
+// however often it was requested, and the IDs that matched none
+func (s *ProductService) GetProducts(ctx context.Context, req *models.BulkIDsRequest) (*models.BulkGetResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.GetProducts")
+	defer span.End()
+
+	ids, duplicates := models.UniqueIDs(req.IDs)
+	if err := s.checkDuplicates(duplicates); err != nil {
+		return nil, err
+	}
+	products, err := s.repo.QueryProducts(ctx, query.ProductsByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	result := &models.BulkGetResult{Products: []models.Product{}, NotFound: []uuid.UUID{}}
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			result.Products = append(result.Products, p)
+		} else {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	return result, nil
+}
+
+// DeleteProducts soft-deletes each requested product once, reporting the IDs
+// that matched no live product rather than failing on them
+func (s *ProductService) DeleteProducts(ctx context.Context, req *models.BulkIDsRequest) (*models.BulkDeleteResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteProducts")
+	defer span.End()
+
+	ids, duplicates := models.UniqueIDs(req.IDs)
+	if err := s.checkDuplicates(duplicates); err != nil {
+		return nil, err
+	}
+
+	result := &models.BulkDeleteResult{Deleted: []uuid.UUID{}, NotFound: []uuid.UUID{}}
+	defer s.invalidateLists()
+	s.flushPending(ids...)
+	for _, id := range ids {
+		if err := s.products.Delete(ctx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				result.NotFound = append(result.NotFound, id)
+				continue
+			}
+			return nil, err
+		}
+		result.Deleted = append(result.Deleted, id)
+		s.mirror(models.EventProductDeleted, &models.Product{ID: id})
+	}
+	return result, nil
+}
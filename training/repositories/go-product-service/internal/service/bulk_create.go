@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/bulk"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// BulkCreateProducts creates every valid request in one transaction and
+// reports each item's new ID or error. In all_or_nothing mode any item error
+// rolls the batch back; in best_effort mode the valid items are kept. An
+// empty mode uses Options.BulkCreateMode.
+func (s *ProductService) BulkCreateProducts(ctx context.Context, reqs []models.CreateProductRequest, mode string) (*models.BulkCreateResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.BulkCreateProducts")
+	defer span.End()
+
+	if mode == "" {
+		mode = s.opts.BulkCreateMode
+	}
+	if mode == "" {
+		mode = models.BulkModeAllOrNothing
+	}
+	for i := range reqs {
+		if err := s.AssignSKU(ctx, &reqs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := bulk.CreateProducts(ctx, s.repo.DB(), s.validate, reqs, bulk.Options{
+		Mode:          mode,
+		DefaultActive: s.opts.DefaultProductActive,
+		Privileged:    auth.HasScope(ctx, auth.ScopeAdmin),
+		Normalize:     s.opts.NormalizeInput,
+		TitleCase:     s.opts.TitleCaseNames,
+		PricePlaces:   s.pricePlaces(),
+		PriceGuard:    s.opts.PriceGuard,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Committed && resp.Created > 0 {
+		s.invalidateLists()
+	}
+	return resp, nil
+}
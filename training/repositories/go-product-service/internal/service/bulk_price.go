@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// bulkPriceSampleSize bounds the affected products listed in a bulk price summary
+const bulkPriceSampleSize = 20
+
+// PreviewBulkPriceUpdate reports what UpdateBulkPrices would do with req,
+// without writing anything
+func (s *ProductService) PreviewBulkPriceUpdate(ctx context.Context, req *models.BulkPriceUpdateRequest) (*models.BulkPricePreview, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.PreviewBulkPriceUpdate")
+	defer span.End()
+
+	sqlText, args := query.BulkPriceTargets(req, false)
+	products, err := s.repo.QueryProducts(ctx, sqlText, args...)
+	if err != nil {
+		return nil, err
+	}
+	return models.PreviewBulkPriceUpdate(products, req, s.opts.PriceGuard, bulkPriceSampleSize), nil
+}
+
+// UpdateBulkPrices applies req to every product it targets, skipping prices
+// the guard refuses, and reports the outcome in the preview's form
+func (s *ProductService) UpdateBulkPrices(ctx context.Context, req *models.BulkPriceUpdateRequest) (*models.BulkPricePreview, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpdateBulkPrices")
+	defer span.End()
+
+	s.FlushUpdates()
+	summary, changes, err := s.products.BulkUpdatePrices(ctx, req, s.opts.PriceGuard, bulkPriceSampleSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		s.invalidateLists()
+	}
+	for i := range changes {
+		s.mirror(models.EventProductUpdated, &changes[i].Product)
+	}
+	s.notifyPriceDrops(ctx, changes)
+	return summary, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// RenameCategory renames a category on every product that uses it
+func (s *ProductService) RenameCategory(ctx context.Context, req *models.RenameCategoryRequest) (*models.RenameCategoryResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.RenameCategory")
+	defer span.End()
+
+	s.FlushUpdates()
+	result, _, err := s.products.RenameCategory(ctx, req.Old, req.New)
+	if err != nil {
+		return nil, err
+	}
+	s.similar.Purge()
+	s.invalidateLists()
+	return result, nil
+}
+
+// DeleteCategory retires a category, moving the products that still use it
+// to q.ReassignTo. Without a reassignment target a category in use is
+// refused with its usage, so no product is left in a retired category.
+func (s *ProductService) DeleteCategory(ctx context.Context, category string, q *models.DeleteCategoryQuery) (*models.DeleteCategoryResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteCategory")
+	defer span.End()
+
+	if q.ReassignTo == category {
+		return nil, models.ErrReassignToSelf
+	}
+	s.FlushUpdates()
+	result, moved, err := s.products.DeleteCategory(ctx, category, q.ReassignTo)
+	if err != nil {
+		return nil, err
+	}
+	if len(moved) > 0 {
+		s.similar.Purge()
+		s.invalidateLists()
+	}
+	return result, nil
+}
+
+// CategoryUsage reports how many products use a category, so a caller can
+// warn before the category is retired; an unused category counts zero
+func (s *ProductService) CategoryUsage(ctx context.Context, category string) (*models.CategoryUsage, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CategoryUsage")
+	defer span.End()
+
+	return s.repo.CategoryUsage(ctx, category)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// storeUpdate persists change at once; with coalescing on, its audit entry
+// and event wait in the coalescer to be merged with the caller's next updates
+func (s *ProductService) storeUpdate(ctx context.Context, id uuid.UUID, change func(p *models.Product) error) (*models.Product, error) {
+	if s.coalescer == nil {
+		return s.products.Update(ctx, id, change)
+	}
+	before, p, err := s.products.UpdateUnrecorded(ctx, id, change)
+	if err != nil {
+		return nil, err
+	}
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return nil, err
+	}
+	afterJSON, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	s.coalescer.Update(p.ID, auth.Caller(ctx), beforeJSON, afterJSON)
+	return p, nil
+}
+
+// recordCoalesced writes the single audit entry and event of a coalesced
+// change, attributed to the actor of its updates
+func (s *ProductService) recordCoalesced(change events.Change) {
+	ctx := auth.WithCaller(context.Background(), change.Actor)
+	log := s.logger.WithContext(ctx).With(zap.String("product_id", change.ProductID.String()), zap.Int("updates", change.Updates))
+
+	var before, after models.Product
+	if err := json.Unmarshal(change.Before, &before); err != nil {
+		log.Error("Decoding a coalesced update failed", err)
+		return
+	}
+	if err := json.Unmarshal(change.After, &after); err != nil {
+		log.Error("Decoding a coalesced update failed", err)
+		return
+	}
+	if err := s.repo.RecordUpdate(ctx, &before, &after); err != nil {
+		log.Error("Recording a coalesced update failed", err)
+	}
+}
+
+// flushPending records the coalesced updates still pending for ids before a
+// write to them, so history and events show those updates ahead of the write
+func (s *ProductService) flushPending(ids ...uuid.UUID) {
+	if s.coalescer == nil {
+		return
+	}
+	for _, id := range ids {
+		s.coalescer.FlushProduct(id)
+	}
+}
+
+// FlushUpdates records every change still inside its coalescing window;
+// call it on shutdown so no update goes unrecorded, and before a bulk write
+// whose products are only known once it runs
+func (s *ProductService) FlushUpdates() {
+	if s.coalescer != nil {
+		s.coalescer.Flush()
+	}
+}
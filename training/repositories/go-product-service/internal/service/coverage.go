@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// coverageWindow returns the sales velocity window for coverage days
+func (s *ProductService) coverageWindow() int {
+	if s.opts.CoverageVelocityDays <= 0 {
+		return models.DefaultCoverageWindowDays
+	}
+	return s.opts.CoverageVelocityDays
+}
+
+// SalesVelocities returns the average daily sales of products over
+// Options.CoverageVelocityDays, from which their coverage_days is computed.
+// Coverage is only shown to readers, so for anyone else it returns nil
+// without a query.
+func (s *ProductService) SalesVelocities(ctx context.Context, products []models.Product) (map[uuid.UUID]float64, error) {
+	if len(products) == 0 || !auth.HasScope(ctx, auth.ScopeRead) {
+		return nil, nil
+	}
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SalesVelocities")
+	defer span.End()
+
+	ids := make([]uuid.UUID, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+	return s.repo.SalesVelocities(ctx, ids, s.coverageWindow())
+}
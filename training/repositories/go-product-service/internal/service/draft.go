@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// CreateDraft stores an inactive draft from a validated draft request. Only
+// the fields it sets are checked now; a draft without a SKU gets a
+// placeholder that publishing refuses.
+func (s *ProductService) CreateDraft(ctx context.Context, req *models.CreateDraftRequest) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreateDraft")
+	defer span.End()
+
+	p := req.Product()
+	p.ID = uuid.New()
+	if p.SKU == "" {
+		p.SKU = models.DraftSKU(p.ID)
+	}
+	if req.Price != nil {
+		if err := s.checkPrices(p.Price, nil); err != nil {
+			return nil, err
+		}
+		if err := s.checkPrecision(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Metadata.Validate(); err != nil {
+		return nil, err
+	}
+	opening := (&models.CreateProductRequest{Stock: p.Stock}).OpeningMovement(p.ID)
+	if err := s.products.Create(ctx, p, opening); err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductCreated, p)
+	return p, nil
+}
+
+// PublishDraft validates a draft exactly as a create would be, prices
+// included, and makes it live; a product that is not a draft returns
+// models.ErrNotDraft
+func (s *ProductService) PublishDraft(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.PublishDraft")
+	defer span.End()
+
+	// Publish checks the draft as the primary has it
+	ctx = dbroute.WithStrongConsistency(ctx)
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != models.StatusDraft {
+		return nil, models.ErrNotDraft
+	}
+	if err := s.validate.Struct(p.PublishRequest()); err != nil {
+		return nil, err
+	}
+	if err := s.checkPrices(p.Price, p.SalePrice); err != nil {
+		return nil, err
+	}
+	if err := s.checkPrecision(p); err != nil {
+		return nil, err
+	}
+
+	s.flushPending(id)
+	published, err := s.products.PublishDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductUpdated, published)
+	return published, nil
+}
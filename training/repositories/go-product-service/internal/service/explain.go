@@ -0,0 +1,13 @@
+package service
+
+import (
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+)
+
+// ExplainFilter returns the list and count SQL f compiles to, with their
+// bind arguments, without running either
+func (s *ProductService) ExplainFilter(f *models.ProductFilter) (*query.FilterExplanation, error) {
+	f.CoverageWindowDays = s.coverageWindow()
+	return query.Explain(f)
+}
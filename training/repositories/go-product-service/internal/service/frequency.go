@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// UpdateFrequency returns a page of products by how often they were updated
+// since f.Since, surfacing both runaway integrations and stale data
+func (s *ProductService) UpdateFrequency(ctx context.Context, f *models.UpdateFrequencyFilter) ([]models.ProductUpdateFrequency, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpdateFrequency")
+	defer span.End()
+
+	return s.repo.UpdateFrequency(ctx, f)
+}
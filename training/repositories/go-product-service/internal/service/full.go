@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// GetFullProduct returns a live product with its images, variants, tags,
+// translations and price tiers, loaded with one query per relation.
+// Relations the product lacks are empty lists.
+func (s *ProductService) GetFullProduct(ctx context.Context, id uuid.UUID) (*models.FullProduct, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.GetFullProduct")
+	defer span.End()
+
+	p, err := s.products.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := s.repo.LoadRelations(ctx, []uuid.UUID{p.ID})
+	if err != nil {
+		return nil, err
+	}
+	full := models.AssembleFullProducts([]models.Product{*p}, rel)
+	return &full[0], nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// GroupedProducts returns products bucketed by category or active status,
+// with each group's count and a capped sample of its newest products
+func (s *ProductService) GroupedProducts(ctx context.Context, f *models.GroupedProductsFilter) ([]models.ProductGroup, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.GroupedProducts")
+	defer span.End()
+
+	return s.repo.GroupedProducts(ctx, f)
+}
+
+// CategorySample returns up to req.Limit of the newest products of each
+// requested category, fetched in one query and grouped in request order
+func (s *ProductService) CategorySample(ctx context.Context, req *models.CategorySampleRequest) ([]models.ProductGroup, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CategorySample")
+	defer span.End()
+
+	q, args := query.CategorySample(req)
+	products, err := s.repo.QueryProducts(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return models.GroupSamples(req.Categories, products), nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// FieldHistory returns every audited change to one field of a product,
+// oldest first. The field must be in audit.HistoryFields, and the cost
+// history requires the finance scope like the cost itself.
+func (s *ProductService) FieldHistory(ctx context.Context, id uuid.UUID, field string) ([]audit.FieldChange, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.FieldHistory")
+	defer span.End()
+
+	if field == "cost" {
+		if err := auth.Require(ctx, auth.ScopeFinance); err != nil {
+			return nil, err
+		}
+	}
+	return audit.FieldHistory(ctx, s.repo.DB(), id.String(), field)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// AttachImages attaches the images of a validated bulk request to the
+// products with their SKUs, reporting the outcome of every row. With
+// Options.TouchParentOnChildChange each product that gained an image is
+// touched and drops out of the caches.
+func (s *ProductService) AttachImages(ctx context.Context, req *models.BulkImageRequest) ([]models.BulkImageResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.AttachImages")
+	defer span.End()
+
+	s.FlushUpdates()
+	results, err := s.products.AttachImagesBySKU(ctx, req.Items, s.opts.TouchParentOnChildChange)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return results, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"time"
+
+	"github.com/company/go-product-service/internal/cache"
+	"github.com/company/go-product-service/internal/models"
+)
+
+// listPage is a cached ListProducts result
+type listPage struct {
+	products []models.Product
+	total    int64
+	next     string
+}
+
+// newListCache creates the list response cache, or nil when ttl disables it
+func newListCache(ttl time.Duration) *cache.ListCache[listPage] {
+	if ttl <= 0 {
+		return nil
+	}
+	return cache.NewListCache[listPage](ttl)
+}
+
+// invalidateLists drops the cached list responses after a product write.
+// Only this instance's cache is cleared; others serve their entries until
+// the TTL ends.
+func (s *ProductService) invalidateLists() {
+	if s.lists != nil {
+		s.lists.Invalidate()
+	}
+}
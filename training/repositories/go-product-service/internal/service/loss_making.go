@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// LossMakingProducts returns a page of active products whose cost is at or
+// above their price, largest loss first; it requires the finance scope
+func (s *ProductService) LossMakingProducts(ctx context.Context, page *models.PageFilter) ([]models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.LossMakingProducts")
+	defer span.End()
+
+	if err := auth.Require(ctx, auth.ScopeFinance); err != nil {
+		return nil, err
+	}
+	q, args := query.ListLossMaking(page)
+	return s.repo.QueryProducts(ctx, q, args...)
+}
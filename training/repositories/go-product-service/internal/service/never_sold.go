@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// NeverSoldProducts returns a page of active products created before the
+// filter's cutoff that have never recorded a sale, oldest first
+func (s *ProductService) NeverSoldProducts(ctx context.Context, f *models.NeverSoldFilter) ([]models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.NeverSoldProducts")
+	defer span.End()
+
+	q, args := query.NeverSold(f)
+	return s.repo.QueryProducts(ctx, q, args...)
+}
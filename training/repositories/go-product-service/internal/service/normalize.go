@@ -0,0 +1,12 @@
+package service
+
+import "github.com/company/go-product-service/internal/models"
+
+// Normalize trims a create or update request, and title-cases its name,
+// when Options.NormalizeInput is on. Call it before validating the request
+// so that length checks apply to the normalized values.
+func (s *ProductService) Normalize(req models.Normalizer) {
+	if s.opts.NormalizeInput {
+		req.Normalize(s.opts.TitleCaseNames)
+	}
+}
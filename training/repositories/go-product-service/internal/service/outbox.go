@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/outbox"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// DeadOutboxEvents returns a page of dead-lettered outbox events, newest
+// first; it requires the admin scope
+func (s *ProductService) DeadOutboxEvents(ctx context.Context, page *models.PageFilter) ([]outbox.Entry, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeadOutboxEvents")
+	defer span.End()
+
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return nil, err
+	}
+	return outbox.ListDead(ctx, s.repo.DB(), page)
+}
+
+// RetryOutboxEvent requeues a dead-lettered outbox event with a fresh
+// attempt budget, or returns outbox.ErrNotDead; it requires the admin scope
+func (s *ProductService) RetryOutboxEvent(ctx context.Context, id uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.RetryOutboxEvent")
+	defer span.End()
+
+	if err := auth.Require(ctx, auth.ScopeAdmin); err != nil {
+		return err
+	}
+	return outbox.Retry(ctx, s.repo.DB(), id)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/models"
+)
+
+// defaultPricePlaces applies when Options.PriceDecimalPlaces is zero
+const defaultPricePlaces = 2
+
+// pricePlaces returns the decimal places accepted on prices without a currency
+func (s *ProductService) pricePlaces() int {
+	if s.opts.PriceDecimalPlaces > 0 {
+		return s.opts.PriceDecimalPlaces
+	}
+	return defaultPricePlaces
+}
+
+// checkPrecision rejects a price with more decimal places than the product's
+// currency allows, or than the configured default when it has no currency
+func (s *ProductService) checkPrecision(p *models.Product) error {
+	var code string
+	if p.Currency != nil {
+		code = *p.Currency
+	}
+	return currency.CheckPrecision(p.Price, currency.MinorUnits(code, s.pricePlaces()))
+}
+
+// Sources named in price guard errors
+const (
+	priceSourceList = "list"
+	priceSourceSale = "sale"
+	priceSourceTier = "tier"
+)
+
+// checkPrices applies the price guard to the list price and, when set, the
+// sale price of a product being written
+func (s *ProductService) checkPrices(price float64, salePrice *float64) error {
+	if err := s.opts.PriceGuard.Check(priceSourceList, price); err != nil {
+		return err
+	}
+	if salePrice != nil {
+		return s.opts.PriceGuard.Check(priceSourceSale, *salePrice)
+	}
+	return nil
+}
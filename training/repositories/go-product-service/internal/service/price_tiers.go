@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/currency"
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// SetPriceTiers replaces a product's quantity price tiers with those of a
+// validated request. Tiers must rise in quantity and never in price, stay
+// below the list price and pass the price guard.
+func (s *ProductService) SetPriceTiers(ctx context.Context, productID uuid.UUID, req *models.SetPriceTiersRequest) ([]models.PriceTier, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SetPriceTiers")
+	defer span.End()
+
+	// Tiers are checked against the primary's current price
+	ctx = dbroute.WithStrongConsistency(ctx)
+	p, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := models.ValidateTiers(p.Price, req.Tiers); err != nil {
+		return nil, err
+	}
+	var code string
+	if p.Currency != nil {
+		code = *p.Currency
+	}
+	places := currency.MinorUnits(code, s.pricePlaces())
+	tiers := make([]models.PriceTier, len(req.Tiers))
+	for i, t := range req.Tiers {
+		if err := s.opts.PriceGuard.Check(priceSourceTier, t.Price); err != nil {
+			return nil, err
+		}
+		if err := currency.CheckPrecision(t.Price, places); err != nil {
+			return nil, err
+		}
+		tiers[i] = models.PriceTier{ProductID: productID, MinQty: t.MinQty, Price: t.Price}
+	}
+
+	s.flushPending(productID)
+	if err := s.products.SetPriceTiers(ctx, productID, tiers, s.opts.TouchParentOnChildChange); err != nil {
+		return nil, err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return tiers, nil
+}
+
+// EffectivePrice returns the unit price of qty units of a product now, by
+// Options.Now: the lower of its active sale or list price and the deepest
+// price tier qty reaches
+func (s *ProductService) EffectivePrice(ctx context.Context, productID uuid.UUID, qty int) (*models.EffectivePrice, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.EffectivePrice")
+	defer span.End()
+
+	p, err := s.repo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := s.repo.PriceTiers(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return models.ComputeEffectivePrice(p, tiers, qty, s.opts.Now()), nil
+}
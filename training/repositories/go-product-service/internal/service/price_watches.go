@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CreatePriceWatch subscribes email to price drops on a product
+func (s *ProductService) CreatePriceWatch(ctx context.Context, productID uuid.UUID, req *models.CreatePriceWatchRequest) (*models.PriceWatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreatePriceWatch")
+	defer span.End()
+
+	return s.repo.CreatePriceWatch(ctx, productID, req.Email)
+}
+
+// DeletePriceWatch removes a watch from a product
+func (s *ProductService) DeletePriceWatch(ctx context.Context, productID, watchID uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeletePriceWatch")
+	defer span.End()
+
+	return s.repo.DeletePriceWatch(ctx, productID, watchID)
+}
+
+// notifyPriceDrop publishes EventPriceDropped to the product's watchers when
+// its price went down. The update is already committed, so failures are only logged.
+func (s *ProductService) notifyPriceDrop(ctx context.Context, productID uuid.UUID, oldPrice, newPrice float64) {
+	if s.opts.Publisher == nil {
+		return
+	}
+	if e := s.priceDropEvent(ctx, productID, oldPrice, newPrice); e != nil {
+		s.publishPriceDrops(ctx, []events.Event{*e})
+	}
+}
+
+// notifyPriceDrops publishes EventPriceDropped for every change of a bulk
+// update that lowered a watched price, as one delivery rather than one call
+// per product
+func (s *ProductService) notifyPriceDrops(ctx context.Context, changes []models.PriceChange) {
+	if s.opts.Publisher == nil {
+		return
+	}
+	var drops []events.Event
+	for _, c := range changes {
+		if e := s.priceDropEvent(ctx, c.Product.ID, c.OldPrice, c.Product.Price); e != nil {
+			drops = append(drops, *e)
+		}
+	}
+	if len(drops) > 0 {
+		s.publishPriceDrops(ctx, drops)
+	}
+}
+
+// publishPriceDrops delivers drops, a single one through the batch window and
+// several as one batch, then ends the notified watches if configured
+func (s *ProductService) publishPriceDrops(ctx context.Context, drops []events.Event) {
+	var err error
+	if len(drops) == 1 {
+		err = s.opts.Publisher.Publish(ctx, drops[0])
+	} else {
+		err = s.opts.Publisher.PublishBatch(ctx, drops)
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Publishing price drops failed", err)
+		return
+	}
+	if !s.opts.ClearPriceWatchOnNotify {
+		return
+	}
+	for _, e := range drops {
+		if err := s.repo.ClearPriceWatches(ctx, e.ProductID); err != nil {
+			s.logger.WithContext(ctx).With(zap.String("product_id", e.ProductID.String())).
+				Error("Clearing notified price watches failed", err)
+		}
+	}
+}
+
+// priceDropEvent returns the EventPriceDropped for a price change, or nil
+// when the price did not go down, nobody watches the product or the event
+// cannot be built
+func (s *ProductService) priceDropEvent(ctx context.Context, productID uuid.UUID, oldPrice, newPrice float64) *events.Event {
+	if newPrice >= oldPrice {
+		return nil
+	}
+	log := s.logger.WithContext(ctx).With(zap.String("product_id", productID.String()))
+
+	watches, err := s.repo.PriceWatches(ctx, productID)
+	if err != nil {
+		log.Error("Loading price watches failed", err)
+		return nil
+	}
+	drop := models.NewPriceDropEvent(productID, oldPrice, newPrice, watches)
+	if drop == nil {
+		return nil
+	}
+	payload, err := json.Marshal(drop)
+	if err != nil {
+		log.Error("Encoding price drop failed", err)
+		return nil
+	}
+	return &events.Event{
+		Type:       models.EventPriceDropped,
+		ProductID:  productID,
+		Payload:    payload,
+		OccurredAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/company/go-product-service/internal/analytics"
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/cache"
+	"github.com/company/go-product-service/internal/events"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/repository"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/company/go-product-service/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Options configures the product service
+type Options struct {
+	// Cache, when set, fronts the repository for product lookups, lists
+	// and the writes that invalidate them
+	Cache cache.ProductRepository
+
+	// Validate checks imported rows with the CreateProductRequest rules
+	Validate *validator.Validate
+
+	// Imports insert ImportBatchSize rows per statement and report at most
+	// ImportMaxErrors rejected rows; zero uses the productio defaults
+	ImportBatchSize int
+	ImportMaxErrors int
+
+	// Publisher, when set, notifies price watchers of price drops; with
+	// ClearPriceWatchOnNotify a product's watches end once notified
+	Publisher               *events.Publisher
+	ClearPriceWatchOnNotify bool
+
+	// Mirror, when set, receives a snapshot of every product write for the
+	// analytics database; it never blocks or fails the write
+	Mirror *analytics.Mirror
+
+	// DefaultProductActive is the initial IsActive of created and imported
+	// products; admin callers may set is_active on a create to override it
+	DefaultProductActive bool
+
+	// AutoGenerateSKU assigns a generated SKU to create requests that omit one
+	AutoGenerateSKU bool
+
+	// StatsCacheTTL is how long the dashboard stats are reused; zero uses 30s
+	StatsCacheTTL time.Duration
+
+	// ListCacheTTL is how long identical list requests are answered from
+	// memory; any product write through the service clears the cache, and
+	// zero disables it
+	ListCacheTTL time.Duration
+
+	// PriceGuard is the lowest price any write may produce: creates, updates,
+	// sale prices, imports and bulk changes; the zero guard refuses
+	// non-positive prices
+	PriceGuard models.PriceGuard
+
+	// StockActivation selects whether stock adjustments deactivate products
+	// that reach zero stock and reactivate restocked ones; both off by default
+	StockActivation models.StockActivation
+
+	// SearchSuggestionLimit is how many similar product names a search that
+	// matches nothing suggests; zero keeps such results a plain empty list
+	SearchSuggestionLimit int
+
+	// NormalizeInput trims the text fields of create, update and import
+	// requests before they are validated; TitleCaseNames also title-cases
+	// product names. Both are off by default.
+	NormalizeInput bool
+	TitleCaseNames bool
+
+	// RejectDuplicateBatchIDs fails batch requests that repeat an ID with a
+	// *models.DuplicateIDError; by default repeats are coalesced, reservation
+	// quantities summed
+	RejectDuplicateBatchIDs bool
+
+	// QualityWeights are the points each data gap deducts from the
+	// data-quality score of 100
+	QualityWeights models.QualityWeights
+
+	// TouchParentOnChildChange advances a product's updated_at, and drops
+	// it from the caches, when its images, tiers or tags change
+	TouchParentOnChildChange bool
+
+	// Reorder suggestions size orders from the sales of the last
+	// ReorderVelocityDays to cover ReorderCoverDays; zero uses 30 and 14
+	ReorderVelocityDays int
+	ReorderCoverDays    int
+
+	// CoverageVelocityDays is the sales window behind coverage_days and
+	// max_coverage_days; zero uses 30
+	CoverageVelocityDays int
+
+	// BulkCreateMode is the mode of bulk creates that don't pick one;
+	// empty means all_or_nothing
+	BulkCreateMode string
+
+	// DefaultCurrency is reported for products without a currency; empty
+	// means USD
+	DefaultCurrency string
+
+	// PriceDecimalPlaces bounds the decimal places of incoming prices that
+	// carry no currency; priced currencies use their ISO 4217 minor units.
+	// Zero uses two.
+	PriceDecimalPlaces int
+
+	// UpdateCoalesceWindow, when positive, merges a caller's successive
+	// updates of a product within the window into one audit entry and
+	// event; each update is still persisted as it arrives
+	UpdateCoalesceWindow time.Duration
+
+	// Now is the clock sale windows are judged by; nil uses time.Now
+	Now func() time.Time
+}
+
+// ProductService implements the product use cases shared by the REST and gRPC APIs
+type ProductService struct {
+	repo      *repository.ProductRepository
+	products  cache.ProductRepository
+	logger    *logger.Logger
+	validate  *validator.Validate
+	similar   *cache.TTLCache[similarKey, []models.Product]
+	stats     *cache.TTLCache[struct{}, *models.ProductStats]
+	lists     *cache.ListCache[listPage]
+	coalescer *events.Coalescer
+	opts      Options
+}
+
+// NewProductService creates a service on repo
+func NewProductService(repo *repository.ProductRepository, logger *logger.Logger, opts Options) *ProductService {
+	s := &ProductService{
+		repo:     repo,
+		products: repo,
+		logger:   logger,
+		validate: opts.Validate,
+		similar:  newSimilarCache(),
+		stats:    newStatsCache(opts.StatsCacheTTL),
+		lists:    newListCache(opts.ListCacheTTL),
+		opts:     opts,
+	}
+	if s.validate == nil {
+		s.validate = validator.New()
+	}
+	if opts.Cache != nil {
+		s.products = opts.Cache
+	}
+	if s.opts.Now == nil {
+		s.opts.Now = time.Now
+	}
+	if opts.UpdateCoalesceWindow > 0 {
+		s.coalescer = events.NewCoalescer(opts.UpdateCoalesceWindow, s.recordCoalesced)
+	}
+	return s
+}
+
+// CreateProduct creates a product from a validated request
+func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreateProduct")
+	defer span.End()
+
+	p, err := s.newProduct(ctx, uuid.New(), req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.products.Create(ctx, p, req.OpeningMovement(p.ID)); err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductCreated, p)
+	return p, nil
+}
+
+// newProduct builds the product a validated create request describes under
+// id, checking its prices and metadata
+func (s *ProductService) newProduct(ctx context.Context, id uuid.UUID, req *models.CreateProductRequest) (*models.Product, error) {
+	p := &models.Product{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       req.Price,
+		Cost:        req.Cost,
+		Category:    req.Category,
+		SKU:         req.SKU,
+		Barcode:     req.Barcode,
+		Stock:       req.Stock,
+		IsActive:    req.InitialActive(s.opts.DefaultProductActive, auth.HasScope(ctx, auth.ScopeAdmin)),
+		Status:      models.StatusActive,
+		Metadata:    req.Metadata,
+	}
+	if err := s.checkPrices(p.Price, nil); err != nil {
+		return nil, err
+	}
+	if err := s.checkPrecision(p); err != nil {
+		return nil, err
+	}
+	if err := p.Metadata.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetProduct returns a live product, or an error wrapping sql.ErrNoRows
+func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.GetProduct")
+	defer span.End()
+
+	return s.products.GetByID(ctx, id)
+}
+
+// UpdateProduct applies the fields set in a validated request to the
+// product as its row lock sees it, so fields the request leaves alone, stock
+// above all, keep whatever value was last committed.
+func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req *models.UpdateProductRequest) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpdateProduct")
+	defer span.End()
+
+	if err := req.Metadata.Validate(); err != nil {
+		return nil, err
+	}
+	var oldPrice float64
+	p, err := s.storeUpdate(ctx, id, func(p *models.Product) error {
+		oldPrice = p.Price
+		applyUpdate(p, req)
+		if req.Price != nil || req.SalePrice != nil {
+			if err := s.checkPrices(p.Price, req.SalePrice); err != nil {
+				return err
+			}
+		}
+		if req.Price != nil {
+			return s.checkPrecision(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductUpdated, p)
+	s.notifyPriceDrop(ctx, p.ID, oldPrice, p.Price)
+	return p, nil
+}
+
+// DeleteProduct soft-deletes a product
+func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteProduct")
+	defer span.End()
+
+	s.flushPending(id)
+	if err := s.products.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductDeleted, &models.Product{ID: id})
+	return nil
+}
+
+// mirror queues a snapshot of p for the analytics database, if one is configured
+func (s *ProductService) mirror(event string, p *models.Product) {
+	if s.opts.Mirror != nil {
+		s.opts.Mirror.Record(event, *p)
+	}
+}
+
+// ListProducts returns a page of products, the total matching the filter
+// and the cursor of the following page, empty on the last one
+func (s *ProductService) ListProducts(ctx context.Context, f *models.ProductFilter) ([]models.Product, int64, string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ListProducts")
+	defer span.End()
+
+	f, err := s.resolveSavedQuery(ctx, f)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	// Filtering by margin would reveal costs to callers who may not see them
+	if f.MinMargin != nil || f.MaxMargin != nil {
+		if err := auth.Require(ctx, auth.ScopeFinance); err != nil {
+			return nil, 0, "", err
+		}
+	}
+	if f.PriceVsCategoryAvg != "" {
+		if err := auth.Require(ctx, auth.ScopeRead); err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	f.CoverageWindowDays = s.coverageWindow()
+
+	if s.lists != nil {
+		if cached, ok := s.lists.Get(f); ok {
+			return cached.products, cached.total, cached.next, nil
+		}
+	}
+
+	products, total, err := s.products.List(ctx, f)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	page, next, err := query.Page(f, products)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if s.lists != nil {
+		s.lists.Set(f, listPage{products: page, total: total, next: next})
+	}
+	return page, total, next, nil
+}
+
+// applyUpdate copies the fields set in req onto p
+func applyUpdate(p *models.Product, req *models.UpdateProductRequest) {
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.Price != nil {
+		p.Price = *req.Price
+	}
+	if req.Cost != nil {
+		p.Cost = req.Cost
+	}
+	if req.Category != nil {
+		p.Category = *req.Category
+	}
+	if req.SKU != nil {
+		p.SKU = *req.SKU
+	}
+	if req.Barcode != nil {
+		p.Barcode = req.Barcode
+	}
+	if req.Stock != nil {
+		p.Stock = *req.Stock
+	}
+	if req.IsActive != nil {
+		p.IsActive = *req.IsActive
+	}
+	if req.Metadata != nil {
+		p.Metadata = req.Metadata
+	}
+	if req.ReorderLevel != nil {
+		p.ReorderLevel = req.ReorderLevel
+	}
+	if req.SalePrice != nil {
+		p.SalePrice = req.SalePrice
+	}
+	if req.SaleStartsAt != nil {
+		p.SaleStartsAt = req.SaleStartsAt
+	}
+	if req.SaleEndsAt != nil {
+		p.SaleEndsAt = req.SaleEndsAt
+	}
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// LowQualityProducts returns a page of products whose data-quality score,
+// under Options.QualityWeights, is at most f.MaxScore, worst first
+func (s *ProductService) LowQualityProducts(ctx context.Context, f *models.LowQualityFilter) ([]models.ScoredProduct, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.LowQualityProducts")
+	defer span.End()
+
+	return s.repo.LowQualityProducts(ctx, f, s.opts.QualityWeights)
+}
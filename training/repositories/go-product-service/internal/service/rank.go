@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// ProductRank returns the 1-based position of a product among the products
+// of its scope under the ordering of q
+func (s *ProductService) ProductRank(ctx context.Context, id uuid.UUID, q *models.RankQuery) (*models.ProductRank, error) {
+	return s.repo.ProductRank(ctx, id, q)
+}
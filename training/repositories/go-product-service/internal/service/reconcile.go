@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ReconcileStock sets the stock of every counted SKU to its physical count,
+// or none of them and returns an *inventory.UnknownSKUError naming a SKU
+// that matches no live product
+func (s *ProductService) ReconcileStock(ctx context.Context, req *models.ReconcileStockRequest) (*models.ReconcileResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ReconcileStock")
+	defer span.End()
+
+	s.FlushUpdates()
+	result, err := s.products.ReconcileStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	return result, nil
+}
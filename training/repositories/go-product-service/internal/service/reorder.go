@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// Reorder defaults for zero Options
+const (
+	defaultReorderVelocityDays = 30
+	defaultReorderCoverDays    = 14
+)
+
+// ReorderSuggestions returns a page of products at or below their reorder
+// level with the quantity that covers Options.ReorderCoverDays of the sales
+// seen over Options.ReorderVelocityDays
+func (s *ProductService) ReorderSuggestions(ctx context.Context, f *models.ReorderFilter) ([]models.ReorderSuggestion, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ReorderSuggestions")
+	defer span.End()
+
+	window := s.opts.ReorderVelocityDays
+	if window <= 0 {
+		window = defaultReorderVelocityDays
+	}
+	cover := s.opts.ReorderCoverDays
+	if cover <= 0 {
+		cover = defaultReorderCoverDays
+	}
+	return s.repo.ReorderSuggestions(ctx, f, window, cover)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// ReserveBatch reserves stock for every line item in one transaction, or
+// none of them and returns an *inventory.InsufficientStockError naming the
+// item that fell short. Items repeating a product are reserved once for
+// their combined quantity.
+func (s *ProductService) ReserveBatch(ctx context.Context, req *models.ReserveBatchRequest) ([]models.Reservation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ReserveBatch")
+	defer span.End()
+
+	items, _, duplicates := models.CoalesceLineItems(req.Items)
+	if err := s.checkDuplicates(duplicates); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		s.flushPending(item.ProductID)
+	}
+
+	reservations, err := s.products.ReserveBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	return reservations, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// UpcomingSales returns a page of products whose sale starts within
+// f.Within of now, by Options.Now, soonest first
+func (s *ProductService) UpcomingSales(ctx context.Context, f *models.UpcomingSalesFilter) ([]models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpcomingSales")
+	defer span.End()
+
+	within, err := models.ParseWindow(f.Within)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidFilter, err)
+	}
+	q, args := query.UpcomingSales(f, s.opts.Now(), within)
+	return s.repo.QueryProducts(ctx, q, args...)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// CreateSavedQuery stores a named filter, or fails with models.ErrSavedQueryExists
+func (s *ProductService) CreateSavedQuery(ctx context.Context, req *models.SaveQueryRequest) (*models.SavedQuery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreateSavedQuery")
+	defer span.End()
+
+	q, err := s.savedQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateSavedQuery(ctx, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// UpdateSavedQuery replaces the description and filter of the query named
+// req.Name, or fails with models.ErrSavedQueryNotFound
+func (s *ProductService) UpdateSavedQuery(ctx context.Context, req *models.SaveQueryRequest) (*models.SavedQuery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpdateSavedQuery")
+	defer span.End()
+
+	q, err := s.savedQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSavedQuery(ctx, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// GetSavedQuery returns a saved query, or models.ErrSavedQueryNotFound
+func (s *ProductService) GetSavedQuery(ctx context.Context, name string) (*models.SavedQuery, error) {
+	return s.repo.GetSavedQuery(ctx, name)
+}
+
+// ListSavedQueries returns a page of saved queries by name
+func (s *ProductService) ListSavedQueries(ctx context.Context, page *models.PageFilter) ([]models.SavedQuery, error) {
+	return s.repo.ListSavedQueries(ctx, page)
+}
+
+// DeleteSavedQuery removes a saved query, or fails with models.ErrSavedQueryNotFound
+func (s *ProductService) DeleteSavedQuery(ctx context.Context, name string) error {
+	return s.repo.DeleteSavedQuery(ctx, name)
+}
+
+// savedQuery checks the filter of req the way a list request is checked,
+// so a stored query always runs; its paging is dropped since each caller
+// picks their own page
+func (s *ProductService) savedQuery(req *models.SaveQueryRequest) (*models.SavedQuery, error) {
+	f := models.ProductFilter(req.Filter)
+	f.Limit, f.Offset, f.Cursor, f.SavedQuery = 0, 0, "", ""
+	f.ApplyDefaults()
+	if err := s.validate.Struct(&f); err != nil {
+		return nil, err
+	}
+	if _, _, err := query.ListProducts(&f); err != nil {
+		return nil, err
+	}
+	return &models.SavedQuery{Name: req.Name, Description: req.Description, Filter: models.SavedFilter(f)}, nil
+}
+
+// resolveSavedQuery replaces f by the saved query it names, keeping the
+// paging of f; a filter naming no saved query is returned as is
+func (s *ProductService) resolveSavedQuery(ctx context.Context, f *models.ProductFilter) (*models.ProductFilter, error) {
+	if f.SavedQuery == "" {
+		return f, nil
+	}
+	q, err := s.repo.GetSavedQuery(ctx, f.SavedQuery)
+	if err != nil {
+		return nil, err
+	}
+	return q.Apply(f), nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// SearchSuggestions returns "did you mean" product names for a search that
+// matched nothing. It returns nil when suggestions are disabled, the filter
+// has no search, or the search found products.
+func (s *ProductService) SearchSuggestions(ctx context.Context, f *models.ProductFilter, total int64) ([]string, error) {
+	if s.opts.SearchSuggestionLimit <= 0 || f.Search == "" || total > 0 {
+		return nil, nil
+	}
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SearchSuggestions")
+	defer span.End()
+
+	return s.repo.SuggestNames(ctx, f.Search, s.opts.SearchSuggestionLimit)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/company/go-product-service/internal/cache"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// similarCacheTTL is how long similar products are reused; the lists are
+// read on every product page and tolerate being briefly stale
+const similarCacheTTL = time.Minute
+
+// similarKey identifies a cached similar products list
+type similarKey struct {
+	id     uuid.UUID
+	filter models.SimilarProductsFilter
+}
+
+// newSimilarCache creates the cache of similar products lists
+func newSimilarCache() *cache.TTLCache[similarKey, []models.Product] {
+	return cache.NewTTLCache[similarKey, []models.Product](similarCacheTTL)
+}
+
+// SimilarProducts returns active products in the same category as the given
+// product and close to it in price, or an error wrapping sql.ErrNoRows when
+// the product does not exist
+func (s *ProductService) SimilarProducts(ctx context.Context, id uuid.UUID, f *models.SimilarProductsFilter) ([]models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SimilarProducts")
+	defer span.End()
+
+	key := similarKey{id: id, filter: *f}
+	if products, ok := s.similar.Get(key); ok {
+		return products, nil
+	}
+
+	p, err := s.products.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	q, args := query.SimilarProducts(p, f)
+	products, err := s.repo.QueryProducts(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	s.similar.Set(key, products)
+	return products, nil
+}
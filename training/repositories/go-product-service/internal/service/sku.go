@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/sku"
+)
+
+// AssignSKU gives a create request that omits its SKU a generated, unused
+// one when Options.AutoGenerateSKU is on; otherwise the request is left for
+// validation to reject. Call it before validating the request.
+func (s *ProductService) AssignSKU(ctx context.Context, req *models.CreateProductRequest) error {
+	if !s.opts.AutoGenerateSKU || req.SKU != "" {
+		return nil
+	}
+	generated, err := sku.GenerateUnique(ctx, req.Category, s.skuExists)
+	if err != nil {
+		return err
+	}
+	req.SKU = generated
+	return nil
+}
+
+// skuExists reports whether a live product already uses the SKU, checked on
+// the primary so a SKU taken moments ago is never missed on a lagging replica
+func (s *ProductService) skuExists(ctx context.Context, candidate string) (bool, error) {
+	_, err := s.repo.GetBySKU(dbroute.WithStrongConsistency(ctx), candidate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
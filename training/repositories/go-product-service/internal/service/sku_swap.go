@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// SwapSKUs exchanges the SKUs of the two products in a validated request,
+// returning sku.ErrSwapNotFound if either is not live
+func (s *ProductService) SwapSKUs(ctx context.Context, req *models.SwapSKUsRequest) ([]models.SKUSwapResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SwapSKUs")
+	defer span.End()
+
+	s.flushPending(req.ProductIDs[0], req.ProductIDs[1])
+	results, err := s.products.SwapSKUs(ctx, req.ProductIDs[0], req.ProductIDs[1])
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	return results, nil
+}
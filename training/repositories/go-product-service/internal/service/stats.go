@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/company/go-product-service/internal/cache"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// defaultStatsCacheTTL is used when Options.StatsCacheTTL is not set
+const defaultStatsCacheTTL = 30 * time.Second
+
+// newStatsCache creates the single-entry cache of the dashboard summary
+func newStatsCache(ttl time.Duration) *cache.TTLCache[struct{}, *models.ProductStats] {
+	if ttl <= 0 {
+		ttl = defaultStatsCacheTTL
+	}
+	return cache.NewTTLCache[struct{}, *models.ProductStats](ttl)
+}
+
+// ProductStats returns the dashboard summary of the catalog, reused for the
+// stats cache TTL so a busy dashboard doesn't rescan the table
+func (s *ProductService) ProductStats(ctx context.Context) (*models.ProductStats, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ProductStats")
+	defer span.End()
+
+	if st, ok := s.stats.Get(struct{}{}); ok {
+		return st, nil
+	}
+	st, err := s.repo.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Set(struct{}{}, st)
+	return st, nil
+}
+
+// CreationTimeline returns how many products were created per interval of f;
+// it is not cached, as each dashboard picks its own window
+func (s *ProductService) CreationTimeline(ctx context.Context, f *models.CreationTimelineFilter) ([]models.TimelineBucket, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreationTimeline")
+	defer span.End()
+
+	return s.repo.CreationTimeline(ctx, f)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/dbroute"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// TransitionStatus moves a product to the status of a validated request if
+// the lifecycle allows it, returning a *models.TransitionError otherwise. A
+// draft becomes active only by passing the checks of PublishDraft.
+func (s *ProductService) TransitionStatus(ctx context.Context, id uuid.UUID, req *models.TransitionRequest) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.TransitionStatus")
+	defer span.End()
+
+	// The transition is checked against the primary's current status
+	ctx = dbroute.WithStrongConsistency(ctx)
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := models.CheckTransition(p.Status, req.Status); err != nil {
+		return nil, err
+	}
+	if p.Status == models.StatusDraft && req.Status == models.StatusActive {
+		return s.PublishDraft(ctx, id)
+	}
+
+	s.flushPending(id)
+	moved, err := s.products.TransitionStatus(ctx, id, p.Status, req.Status)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductUpdated, moved)
+	return moved, nil
+}
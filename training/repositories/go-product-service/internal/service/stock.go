@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// AdjustStock changes a product's stock by the request's delta, recording a
+// stock movement. With the StockActivation options the product is also
+// deactivated when its stock reaches zero, or reactivated when restocked from
+// zero, atomically with the stock change.
+func (s *ProductService) AdjustStock(ctx context.Context, id uuid.UUID, req *models.StockAdjustmentRequest) (*models.Product, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.AdjustStock")
+	defer span.End()
+
+	s.flushPending(id)
+	p, err := s.products.AdjustStock(ctx, req.Movement(id), s.opts.StockActivation)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductUpdated, p)
+	return p, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// TagCounts reports how many live products use each tag, most used first
+func (s *ProductService) TagCounts(ctx context.Context, f *models.TagCountFilter) ([]models.TagCount, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.TagCounts")
+	defer span.End()
+
+	return s.repo.TagCounts(ctx, f)
+}
+
+// DeleteTag removes a tag from every product that carries it. With
+// Options.TouchParentOnChildChange those products are touched and drop out
+// of the caches.
+func (s *ProductService) DeleteTag(ctx context.Context, tag string) (*models.DeleteTagResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteTag")
+	defer span.End()
+
+	s.FlushUpdates()
+	result, err := s.products.DeleteTag(ctx, tag, s.opts.TouchParentOnChildChange)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists()
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/company/go-product-service/internal/auth"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/productio"
+	"github.com/company/go-product-service/internal/query"
+	"github.com/company/go-product-service/internal/telemetry"
+)
+
+// Import and export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "ndjson"
+)
+
+// ErrUnsupportedFormat is returned for an export format other than CSV or NDJSON
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// ImportProducts creates the products read from r in batches and reports
+// the rows that were rejected. With upsert, rows for existing SKUs update
+// them and unchanged rows are reported as skipped.
+func (s *ProductService) ImportProducts(ctx context.Context, r productio.RowReader, upsert bool) (*models.ImportReport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ImportProducts")
+	defer span.End()
+
+	// Batches commit as they go, so even a failed import may have written rows
+	defer s.invalidateLists()
+
+	return productio.Import(ctx, s.repo.DB(), s.validate, r, productio.ImportOptions{
+		Upsert:        upsert,
+		BatchSize:     s.opts.ImportBatchSize,
+		MaxErrors:     s.opts.ImportMaxErrors,
+		DefaultActive: s.opts.DefaultProductActive,
+		Privileged:    auth.HasScope(ctx, auth.ScopeAdmin),
+		PricePlaces:   s.pricePlaces(),
+		PriceGuard:    s.opts.PriceGuard,
+		Normalize:     s.opts.NormalizeInput,
+		TitleCase:     s.opts.TitleCaseNames,
+	})
+}
+
+// ExportProducts streams every product matching f to w in the given format,
+// ignoring its paging, and returns the number of products written. CSV
+// columns follow mapping.
+func (s *ProductService) ExportProducts(ctx context.Context, w io.Writer, format string, mapping productio.Mapping, f *models.ProductFilter) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ExportProducts")
+	defer span.End()
+
+	f, err := s.resolveSavedQuery(ctx, f)
+	if err != nil {
+		return 0, err
+	}
+	all := *f
+	all.Limit, all.Offset, all.Cursor = 0, 0, ""
+	q, args, err := query.ListProducts(&all)
+	if err != nil {
+		return 0, err
+	}
+
+	switch format {
+	case FormatCSV:
+		return productio.StreamCSV(ctx, s.repo.DB(), w, mapping, q, args...)
+	case FormatJSONL:
+		return productio.StreamJSONL(ctx, s.repo.DB(), w, q, args...)
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// ExportIncremental streams, as JSONL, every product whose updated_at falls
+// in [f.Since, f.Until), soft-deleted ones included as tombstones carrying
+// deleted_at, and returns the number written. The same window always yields
+// the same rows in the same order.
+func (s *ProductService) ExportIncremental(ctx context.Context, w io.Writer, f *models.IncrementalExportFilter) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.ExportIncremental")
+	defer span.End()
+
+	q, args := query.IncrementalExport(f.Since, f.Until)
+	return productio.StreamJSONL(ctx, s.repo.DB(), w, q, args...)
+}
+
+// SaveColumnMapping stores a named CSV layout, replacing one with the same name
+func (s *ProductService) SaveColumnMapping(ctx context.Context, req *models.CreateColumnMappingRequest) (*models.ColumnMapping, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SaveColumnMapping")
+	defer span.End()
+
+	if err := productio.Mapping(req.Columns).ValidateForExport(); err != nil {
+		return nil, err
+	}
+	m := &models.ColumnMapping{Name: req.Name, Columns: req.Columns}
+	if err := s.repo.SaveColumnMapping(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListColumnMappings returns every stored CSV layout
+func (s *ProductService) ListColumnMappings(ctx context.Context) ([]models.ColumnMapping, error) {
+	return s.repo.ListColumnMappings(ctx)
+}
+
+// GetColumnMapping returns a stored CSV layout, or models.ErrColumnMappingNotFound
+func (s *ProductService) GetColumnMapping(ctx context.Context, name string) (*models.ColumnMapping, error) {
+	return s.repo.GetColumnMapping(ctx, name)
+}
+
+// ImportMapping resolves the named mapping for an import; an empty name
+// selects the default layout
+func (s *ProductService) ImportMapping(ctx context.Context, name string) (productio.Mapping, error) {
+	return s.mapping(ctx, name, productio.DefaultImportMapping())
+}
+
+// ExportMapping resolves the named mapping for an export; an empty name
+// selects the default layout
+func (s *ProductService) ExportMapping(ctx context.Context, name string) (productio.Mapping, error) {
+	return s.mapping(ctx, name, productio.DefaultExportMapping())
+}
+
+// mapping loads the named mapping, or returns def when name is empty
+func (s *ProductService) mapping(ctx context.Context, name string, def productio.Mapping) (productio.Mapping, error) {
+	if name == "" {
+		return def, nil
+	}
+	m, err := s.repo.GetColumnMapping(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return productio.Mapping(m.Columns), nil
+}
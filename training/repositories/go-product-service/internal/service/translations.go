@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// SetTranslation stores a product's name and description for a locale,
+// replacing any existing translation. With
+// Options.TouchParentOnChildChange the product is touched and drops out of
+// the caches.
+func (s *ProductService) SetTranslation(ctx context.Context, productID uuid.UUID, locale string, req *models.SetTranslationRequest) (*models.ProductTranslation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.SetTranslation")
+	defer span.End()
+
+	t := &models.ProductTranslation{ProductID: productID, Locale: locale, Name: req.Name, Description: req.Description}
+	if err := s.validate.Struct(t); err != nil {
+		return nil, err
+	}
+	s.flushPending(productID)
+	if err := s.products.SetTranslation(ctx, t, s.opts.TouchParentOnChildChange); err != nil {
+		return nil, err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return t, nil
+}
+
+// DeleteTranslation removes a product's translation for a locale, touching
+// the product like SetTranslation
+func (s *ProductService) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteTranslation")
+	defer span.End()
+
+	s.flushPending(productID)
+	if err := s.products.DeleteTranslation(ctx, productID, locale, s.opts.TouchParentOnChildChange); err != nil {
+		return err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return nil
+}
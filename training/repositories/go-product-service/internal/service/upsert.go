@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// UpsertProduct updates the product like UpdateProduct, or creates it under
+// id when no product has that ID, reporting whether it was created. A create
+// needs every field a create request requires and passes the same
+// validation, or fails with models.ErrIncompleteUpsert. An ID taken by a
+// deleted product, or by a concurrent create, is updated instead, so it
+// still fails with sql.ErrNoRows when the product is not live.
+func (s *ProductService) UpsertProduct(ctx context.Context, id uuid.UUID, req *models.UpdateProductRequest) (*models.Product, bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.UpsertProduct")
+	defer span.End()
+
+	p, err := s.UpdateProduct(ctx, id, req)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return p, false, err
+	}
+	create, err := req.CreateRequest()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.validate.Struct(create); err != nil {
+		return nil, false, err
+	}
+	if p, err = s.newProduct(ctx, id, create); err != nil {
+		return nil, false, err
+	}
+	created, err := s.products.CreateIfMissing(ctx, p, create.OpeningMovement(id))
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		p, err := s.UpdateProduct(ctx, id, req)
+		return p, false, err
+	}
+	s.invalidateLists()
+	s.mirror(models.EventProductCreated, p)
+	return p, true, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/company/go-product-service/internal/models"
+	"github.com/company/go-product-service/internal/telemetry"
+	"github.com/google/uuid"
+)
+
+// CreateVariant adds a variant to a product from a validated request. With
+// Options.TouchParentOnChildChange the product is touched and drops out of
+// the caches.
+func (s *ProductService) CreateVariant(ctx context.Context, productID uuid.UUID, req *models.CreateVariantRequest) (*models.ProductVariant, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.CreateVariant")
+	defer span.End()
+
+	if err := req.Attributes.Validate(); err != nil {
+		return nil, err
+	}
+	v := &models.ProductVariant{
+		ProductID:  productID,
+		SKU:        req.SKU,
+		Name:       req.Name,
+		Price:      req.Price,
+		Stock:      req.Stock,
+		Attributes: req.Attributes,
+	}
+	s.flushPending(productID)
+	if err := s.products.CreateVariant(ctx, v, s.opts.TouchParentOnChildChange); err != nil {
+		return nil, err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return v, nil
+}
+
+// DeleteVariant removes one of a product's variants, touching the product
+// like CreateVariant
+func (s *ProductService) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	ctx, span := telemetry.StartSpan(ctx, "ProductService.DeleteVariant")
+	defer span.End()
+
+	s.flushPending(productID)
+	if err := s.products.DeleteVariant(ctx, productID, variantID, s.opts.TouchParentOnChildChange); err != nil {
+		return err
+	}
+	if s.opts.TouchParentOnChildChange {
+		s.invalidateLists()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sku
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	// defaultPrefix is used when the category has no usable letters
+	defaultPrefix = "PRD"
+	prefixLength  = 3
+	suffixBytes   = 4
+	// maxAttempts bounds the retries on SKU collisions
+	maxAttempts = 5
+)
+
+// ErrNoUniqueSKU is returned when every generated candidate collided
+var ErrNoUniqueSKU = errors.New("could not generate a unique SKU")
+
+// ExistsFunc reports whether a SKU is already taken
+type ExistsFunc func(ctx context.Context, sku string) (bool, error)
+
+// Generate returns a SKU made of a category prefix and a random suffix, e.g. ELE-3F9A0C1B
+func Generate(category string) (string, error) {
+	suffix := make([]byte, suffixBytes)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return prefix(category) + "-" + strings.ToUpper(hex.EncodeToString(suffix)), nil
+}
+
+// GenerateUnique generates SKUs until exists reports one as free
+func GenerateUnique(ctx context.Context, category string, exists ExistsFunc) (string, error) {
+	for i := 0; i < maxAttempts; i++ {
+		candidate, err := Generate(category)
+		if err != nil {
+			return "", err
+		}
+
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrNoUniqueSKU
+}
+
+// prefix takes the first letters or digits of the category, upper-cased
+func prefix(category string) string {
+	var sb strings.Builder
+	for _, r := range category {
+		if sb.Len() == prefixLength {
+			break
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	if sb.Len() == 0 {
+		return defaultPrefix
+	}
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package sku
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateUsesCategoryPrefix(t *testing.T) {
+	for category, want := range map[string]string{
+		"electronics": "^ELE-[0-9A-F]{8}$",
+		"3d printers": "^3DP-[0-9A-F]{8}$",
+		"é":           "^PRD-[0-9A-F]{8}$",
+	} {
+		got, err := Generate(category)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !regexp.MustCompile(want).MatchString(got) {
+			t.Errorf("%q: expected %s, got %s", category, want, got)
+		}
+	}
+}
+
+func TestGenerateUniqueRetriesCollisions(t *testing.T) {
+	var tried []string
+	exists := func(_ context.Context, sku string) (bool, error) {
+		tried = append(tried, sku)
+		return len(tried) < 3, nil
+	}
+	got, err := GenerateUnique(context.Background(), "furniture", exists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tried) != 3 || got != tried[2] {
+		t.Fatalf("expected the third candidate after two collisions, got %s from %v", got, tried)
+	}
+}
+
+func TestGenerateUniqueGivesUp(t *testing.T) {
+	attempts := 0
+	exists := func(context.Context, string) (bool, error) {
+		attempts++
+		return true, nil
+	}
+	if _, err := GenerateUnique(context.Background(), "furniture", exists); !errors.Is(err, ErrNoUniqueSKU) {
+		t.Fatalf("expected ErrNoUniqueSKU, got %v", err)
+	}
+	if attempts != maxAttempts {
+		t.Fatalf("expected %d attempts, got %d", maxAttempts, attempts)
+	}
+}
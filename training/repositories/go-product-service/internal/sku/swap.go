@@ -0,0 +1,106 @@
+package sku
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/company/go-product-service/internal/audit"
+	"github.com/company/go-product-service/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrSwapNotFound is returned when either product of a swap does not exist
+	ErrSwapNotFound = errors.New("product not found")
+	// ErrSwapSameProduct is returned when both sides of a swap are the same product
+	ErrSwapSameProduct = errors.New("cannot swap a product's SKU with itself")
+)
+
+// lockForSwap locks both products in id order, so concurrent swaps over the
+// same pair cannot deadlock
+const lockForSwap = `SELECT id, sku FROM products
+WHERE id = ANY($1) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE`
+
+const setSKU = `UPDATE products SET sku = $2, updated_at = NOW() WHERE id = $1`
+
+// Swap exchanges the SKUs of products a and b in one transaction. The unique
+// constraint on sku is checked per row, so a is first parked on a temporary
+// SKU derived from its ID to free its value for b. Both changes are written
+// to the audit log within the same transaction.
+func Swap(ctx context.Context, db *sql.DB, actor string, a, b uuid.UUID) ([]models.SKUSwapResult, error) {
+	if a == b {
+		return nil, ErrSwapSameProduct
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, lockForSwap, pq.Array([]uuid.UUID{a, b}))
+	if err != nil {
+		return nil, err
+	}
+	skus := make(map[uuid.UUID]string, 2)
+	for rows.Next() {
+		var id uuid.UUID
+		var s string
+		if err := rows.Scan(&id, &s); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		skus[id] = s
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(skus) != 2 {
+		return nil, ErrSwapNotFound
+	}
+
+	steps := []struct {
+		id  uuid.UUID
+		sku string
+	}{
+		{a, "swap:" + a.String()},
+		{b, skus[a]},
+		{a, skus[b]},
+	}
+	for _, step := range steps {
+		if _, err := tx.ExecContext(ctx, setSKU, step.id, step.sku); err != nil {
+			return nil, err
+		}
+	}
+
+	results := []models.SKUSwapResult{
+		{ProductID: a, OldSKU: skus[a], NewSKU: skus[b]},
+		{ProductID: b, OldSKU: skus[b], NewSKU: skus[a]},
+	}
+	for _, r := range results {
+		before, _ := json.Marshal(map[string]string{"sku": r.OldSKU})
+		after, _ := json.Marshal(map[string]string{"sku": r.NewSKU})
+		entry := &models.AuditEntry{
+			Actor:      actor,
+			Action:     models.AuditActionSwapSKU,
+			EntityType: models.AuditEntityProduct,
+			EntityID:   r.ProductID.String(),
+			Before:     before,
+			After:      after,
+		}
+		if err := audit.Record(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
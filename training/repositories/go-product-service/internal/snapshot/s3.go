@@ -0,0 +1,260 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3PartSize is the size of each uploaded part, the smallest S3 accepts for
+// every part but the last; it bounds the memory a snapshot upload holds
+const S3PartSize = 5 << 20
+
+// S3Config locates a bucket on S3 or an S3-compatible service
+type S3Config struct {
+	// Endpoint is the service URL; objects are addressed path-style as
+	// Endpoint/Bucket/key, which every S3-compatible service accepts
+	Endpoint string
+	Region   string
+	Bucket   string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3Store writes objects to an S3 bucket with SigV4-signed requests.
+// Objects larger than one part are sent as a multipart upload, so a stream
+// of unknown length is uploaded without being buffered whole.
+type S3Store struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Store creates a store for the bucket in cfg
+func NewS3Store(cfg S3Config) *S3Store {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &S3Store{cfg: cfg, client: http.DefaultClient, now: time.Now}
+}
+
+// Put uploads r under key
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader) error {
+	first, err := readPart(r)
+	if err != nil {
+		return err
+	}
+	if len(first) < S3PartSize {
+		_, err := s.do(ctx, http.MethodPut, key, nil, first)
+		return err
+	}
+	return s.putMultipart(ctx, key, first, r)
+}
+
+// putMultipart uploads first and the rest of r as numbered parts, aborting
+// the upload if any part fails so no orphaned parts are billed
+func (s *S3Store) putMultipart(ctx context.Context, key string, first []byte, r io.Reader) error {
+	resp, err := s.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return err
+	}
+	var initiated struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(resp.body, &initiated); err != nil {
+		return fmt.Errorf("s3 initiate upload: %w", err)
+	}
+	upload := url.Values{"uploadId": {initiated.UploadID}}
+
+	complete, err := s.uploadParts(ctx, key, initiated.UploadID, first, r)
+	if err != nil {
+		_, _ = s.do(context.WithoutCancel(ctx), http.MethodDelete, key, upload, nil)
+		return err
+	}
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, http.MethodPost, key, upload, body)
+	return err
+}
+
+// completedPart names one uploaded part in the completion request
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeUpload is the body of CompleteMultipartUpload
+type completeUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// uploadParts sends part after part until r is exhausted
+func (s *S3Store) uploadParts(ctx context.Context, key, uploadID string, part []byte, r io.Reader) (*completeUpload, error) {
+	complete := &completeUpload{}
+	for n := 1; len(part) > 0; n++ {
+		query := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}
+		resp, err := s.do(ctx, http.MethodPut, key, query, part)
+		if err != nil {
+			return nil, err
+		}
+		complete.Parts = append(complete.Parts, completedPart{PartNumber: n, ETag: resp.etag})
+		if len(part) < S3PartSize {
+			break
+		}
+		if part, err = readPart(r); err != nil {
+			return nil, err
+		}
+	}
+	return complete, nil
+}
+
+// readPart reads up to S3PartSize bytes, fewer only at the end of r
+func readPart(r io.Reader) ([]byte, error) {
+	buf := make([]byte, S3PartSize)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return buf[:n], err
+}
+
+// s3Response is the part of a successful response the store needs
+type s3Response struct {
+	body []byte
+	etag string
+}
+
+// do sends a signed request for key and fails on any non-2xx status
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body []byte) (*s3Response, error) {
+	path := "/" + s.cfg.Bucket + "/" + key
+	target := s.cfg.Endpoint + encodePath(path)
+	if len(query) > 0 {
+		target += "?" + canonicalQuery(query)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, path, query, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", method, key, resp.Status, bytes.TrimSpace(data))
+	}
+	return &s3Response{body: data, etag: resp.Header.Get("ETag")}, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req
+func (s *S3Store) sign(req *http.Request, path string, query url.Values, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, encodePath(path), canonicalQuery(query), canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	for _, part := range []string{s.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery encodes query sorted by key, as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, encodeComponent(k)+"="+encodeComponent(query.Get(k)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// encodePath escapes every path segment, keeping the slashes between them
+func encodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		segments[i] = encodeComponent(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// encodeComponent percent-encodes every byte but the SigV4 unreserved set
+func encodeComponent(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
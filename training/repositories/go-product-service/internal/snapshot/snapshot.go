@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/company/go-product-service/internal/jobs"
+	"github.com/company/go-product-service/internal/productio"
+	"github.com/company/go-product-service/internal/query"
+)
+
+// ObjectStore writes objects to a bucket; implementations must consume r
+// as a stream rather than assuming its length up front
+type ObjectStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+}
+
+// Result describes a completed catalog snapshot; it is also written next
+// to the snapshot as a JSON manifest
+type Result struct {
+	Key     string    `json:"key"`
+	TakenAt time.Time `json:"taken_at"`
+	Rows    int64     `json:"rows"`
+}
+
+// Snapshotter dumps the full catalog as gzip-compressed JSONL
+type Snapshotter struct {
+	db     *sql.DB
+	store  ObjectStore
+	prefix string
+	now    func() time.Time
+}
+
+// NewSnapshotter creates a snapshotter writing under prefix in store
+func NewSnapshotter(db *sql.DB, store ObjectStore, prefix string) *Snapshotter {
+	return &Snapshotter{db: db, store: store, prefix: prefix, now: time.Now}
+}
+
+// Run streams every live product to the store; rows are compressed and
+// uploaded as they are read, so the catalog is never held in memory
+func (s *Snapshotter) Run(ctx context.Context) (*Result, error) {
+	takenAt := s.now().UTC()
+	key := s.prefix + takenAt.Format("20060102T150405Z") + ".jsonl.gz"
+
+	pr, pw := io.Pipe()
+	type streamed struct {
+		rows int64
+		err  error
+	}
+	done := make(chan streamed, 1)
+	go func() {
+		gz := gzip.NewWriter(pw)
+		rows, err := productio.StreamJSONL(ctx, s.db, gz, query.CatalogSnapshot)
+		if closeErr := gz.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+		done <- streamed{rows: rows, err: err}
+	}()
+
+	putErr := s.store.Put(ctx, key, pr)
+	// Unblock the producer if the store stopped reading early
+	pr.CloseWithError(putErr)
+	out := <-done
+	if out.err != nil {
+		return nil, out.err
+	}
+	if putErr != nil {
+		return nil, putErr
+	}
+
+	result := &Result{Key: key, TakenAt: takenAt, Rows: out.rows}
+	manifest, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, key+".manifest.json", bytes.NewReader(manifest)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Job schedules the snapshot on a jobs.Runner
+func (s *Snapshotter) Job(interval time.Duration) jobs.Job {
+	return jobs.Job{
+		Name:     "catalog-snapshot",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := s.Run(ctx)
+			return err
+		},
+	}
+}
+
+// MemoryStore keeps objects in memory; it is intended for tests and local runs
+type MemoryStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory object store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string][]byte)}
+}
+
+// Put reads r to the end and stores it under key
+func (s *MemoryStore) Put(_ context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	return nil
+}
+
+// Get returns the object stored under key
+func (s *MemoryStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	return data, ok
+}
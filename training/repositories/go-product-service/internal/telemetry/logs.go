@@ -0,0 +1,27 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// SetupLogExport creates a logger provider exporting log records over
+// OTLP/HTTP in batches; like SetupTracing, the exporter reads the
+// OTEL_EXPORTER_OTLP_* variables itself. Shut the provider down to flush
+// pending records.
+func SetupLogExport(ctx context.Context, serviceName string) (*sdklog.LoggerProvider, error) {
+	exporter, err := otlploghttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := newResource(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	), nil
+}
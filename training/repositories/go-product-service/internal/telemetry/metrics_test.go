@@ -0,0 +1,20 @@
+package telemetry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounterFuncIsServed(t *testing.T) {
+	m := NewMetrics()
+	var compacted int64 = 42
+	m.CounterFunc("audit_entries_compacted_total", "Audit log entries removed by retention compaction.", func() int64 { return compacted })
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(w.Body.String(), "audit_entries_compacted_total 42") {
+		t.Fatalf("expected the compaction counter, got:\n%s", w.Body)
+	}
+}
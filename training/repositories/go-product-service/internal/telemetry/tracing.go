@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/XSAM/otelsql"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer used for service and repository spans
+const instrumentationName = "github.com/company/go-product-service"
+
+// SetupTracing installs a global tracer provider exporting over OTLP/HTTP,
+// plus the W3C trace context and baggage propagators. The exporter reads
+// OTEL_EXPORTER_OTLP_ENDPOINT and the other OTEL_EXPORTER_OTLP_* variables
+// itself; an empty endpoint keeps tracing disabled and the returned shutdown
+// is a no-op.
+func SetupTracing(ctx context.Context, endpoint, serviceName string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := newResource(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// newResource describes this service to the collector
+func newResource(serviceName string) (*resource.Resource, error) {
+	return resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+}
+
+// TracingMiddleware starts a server span per request, continuing any trace
+// propagated by the caller; handlers pass c.Request.Context() down so service
+// and repository spans nest under it
+func TracingMiddleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName)
+}
+
+// StartSpan starts a child span of the span in ctx, e.g.
+// ctx, span := telemetry.StartSpan(ctx, "ProductService.Create")
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// OpenDB wraps connector so every query, exec and transaction records a SQL
+// span under the caller's span
+func OpenDB(connector driver.Connector) *sql.DB {
+	return otelsql.OpenDB(connector, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
+}
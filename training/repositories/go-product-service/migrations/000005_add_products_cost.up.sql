@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost NUMERIC(10, 2);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_content_hash;
+
+ALTER TABLE products DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS barcode VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_products_barcode ON products (barcode);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_metadata;
+
+ALTER TABLE products DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_translations;
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS product_variants;
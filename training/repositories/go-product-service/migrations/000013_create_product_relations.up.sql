@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS product_variants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    price NUMERIC(10, 2),
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    attributes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants (product_id);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (product_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_tags_tag ON product_tags (tag);
+
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    PRIMARY KEY (product_id, locale)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+
+ALTER TABLE products DROP COLUMN IF EXISTS status;
@@ -0,0 +1,5 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+
+UPDATE products SET status = 'active' WHERE status IN ('archived', 'discontinued');
+
+ALTER TABLE products ADD CONSTRAINT products_status_check CHECK (status IN ('draft', 'active'));
@@ -0,0 +1,6 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+
+UPDATE products SET status = 'archived' WHERE status = 'active' AND NOT is_active;
+
+ALTER TABLE products ADD CONSTRAINT products_status_check
+    CHECK (status IN ('draft', 'active', 'archived', 'discontinued'));
@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_level INTEGER CHECK (reorder_level >= 0);
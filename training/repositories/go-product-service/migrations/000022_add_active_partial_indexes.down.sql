@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_active_created_at;
+DROP INDEX IF EXISTS idx_products_active_price;
+DROP INDEX IF EXISTS idx_products_active_category;
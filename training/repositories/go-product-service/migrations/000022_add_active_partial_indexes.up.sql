@@ -0,0 +1,11 @@
+-- Storefront queries only touch live, active products; each index below is
+-- matched by queries that filter with the literal predicate built by
+-- query.ActivePredicate together with deleted_at IS NULL
+CREATE INDEX IF NOT EXISTS idx_products_active_category ON products (category, created_at DESC, id DESC)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_active_price ON products (price, id)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_active_created_at ON products (created_at DESC, id DESC)
+    WHERE is_active AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS sale_price NUMERIC(10, 2) CHECK (sale_price > 0),
+    ADD COLUMN IF NOT EXISTS sale_starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sale_ends_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT products_sale_window_check CHECK (sale_ends_at IS NULL OR sale_ends_at > sale_starts_at);
+
+CREATE INDEX IF NOT EXISTS idx_products_sale_starts_at ON products (sale_starts_at)
+    WHERE sale_starts_at IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    min_qty INTEGER NOT NULL CHECK (min_qty > 1),
+    price NUMERIC(10, 2) NOT NULL CHECK (price > 0),
+    PRIMARY KEY (product_id, min_qty)
+);
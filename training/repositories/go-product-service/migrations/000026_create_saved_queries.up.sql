@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS saved_queries (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS audit_daily_summaries (
+    day DATE NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    entries BIGINT NOT NULL,
+    PRIMARY KEY (day, entity_type, action)
+);
@@ -0,0 +1 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS created_by VARCHAR(255);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_products_barcode_normalized ON products (ltrim(barcode, '0'));
@@ -0,0 +1,79 @@
+// Package logger provides the service's structured logger
+package logger
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger wraps a zap logger whose level can be changed after construction
+type Logger struct {
+	zap   *zap.Logger
+	level zap.AtomicLevel
+}
+
+// NewLogger creates a JSON logger writing to stdout at info level; call
+// SetLevel once the configuration is loaded
+func NewLogger() *Logger {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = "time"
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(cfg), zapcore.Lock(os.Stdout), level)
+	return &Logger{zap: zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)), level: level}
+}
+
+// New wraps an existing zap logger, for tests and custom outputs
+func New(z *zap.Logger, level zap.AtomicLevel) *Logger {
+	return &Logger{zap: z.WithOptions(zap.AddCallerSkip(1)), level: level}
+}
+
+// SetLevel changes the minimum level logged: debug, info, warn or error
+func (l *Logger) SetLevel(level string) error {
+	return l.level.UnmarshalText([]byte(level))
+}
+
+// With returns a logger that adds fields to every entry
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{zap: l.zap.With(fields...), level: l.level}
+}
+
+// Debug logs a message at debug level
+func (l *Logger) Debug(msg string, fields ...zap.Field) {
+	l.zap.Debug(msg, fields...)
+}
+
+// Info logs a message at info level
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.zap.Info(msg, fields...)
+}
+
+// Warn logs a message at warn level; err may be nil
+func (l *Logger) Warn(msg string, err error, fields ...zap.Field) {
+	l.zap.Warn(msg, withError(err, fields)...)
+}
+
+// Error logs a message at error level
+func (l *Logger) Error(msg string, err error, fields ...zap.Field) {
+	l.zap.Error(msg, withError(err, fields)...)
+}
+
+// Fatal logs a message at fatal level and exits
+func (l *Logger) Fatal(msg string, err error, fields ...zap.Field) {
+	l.zap.Fatal(msg, withError(err, fields)...)
+}
+
+// Sync flushes buffered entries
+func (l *Logger) Sync() error {
+	return l.zap.Sync()
+}
+
+// withError prepends err to fields unless it is nil
+func withError(err error, fields []zap.Field) []zap.Field {
+	if err == nil {
+		return fields
+	}
+	return append([]zap.Field{zap.Error(err)}, fields...)
+}
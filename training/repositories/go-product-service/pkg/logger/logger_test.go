@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelsAndErrors(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, logs := observer.New(level)
+	l := New(zap.New(core), level)
+
+	l.Debug("hidden")
+	l.Warn("index check failed", errors.New("missing idx"))
+	l.Error("delivery failed", nil, zap.String("sink", "kafka"))
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries above info, got %d", len(entries))
+	}
+	if entries[0].Level != zapcore.WarnLevel || entries[0].ContextMap()["error"] != "missing idx" {
+		t.Fatalf("unexpected warn entry: %+v", entries[0])
+	}
+	if entries[1].Level != zapcore.ErrorLevel || entries[1].ContextMap()["sink"] != "kafka" {
+		t.Fatalf("unexpected error entry: %+v", entries[1])
+	}
+	if _, ok := entries[1].ContextMap()["error"]; ok {
+		t.Fatal("a nil error must not add an error field")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, logs := observer.New(level)
+	l := New(zap.New(core), level)
+
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("shown")
+	if logs.Len() != 1 {
+		t.Fatal("debug entry should be logged after SetLevel(debug)")
+	}
+	if err := l.SetLevel("loud"); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// contextKey names the field that carries a context to the OpenTelemetry core
+const contextKey = "context"
+
+// ExportTo also sends every entry at or above the current level to provider,
+// in addition to stdout. Loggers derived with With afterwards share the export.
+func (l *Logger) ExportTo(provider log.LoggerProvider, name string) {
+	otelLogger := provider.Logger(name)
+	l.zap = l.zap.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &otelCore{LevelEnabler: l.level, logger: otelLogger})
+	}))
+}
+
+// WithContext returns a logger whose entries carry the trace and span IDs of
+// the span active in ctx, as trace_id and span_id on stdout and as the trace
+// context of exported records; without an active span it returns l
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.With(
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+		zap.Field{Key: contextKey, Type: zapcore.SkipType, Interface: ctx},
+	)
+}
+
+// otelCore is a zapcore.Core emitting entries as OpenTelemetry log records
+type otelCore struct {
+	zapcore.LevelEnabler
+	logger log.Logger
+	fields []zapcore.Field
+}
+
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append([]zapcore.Field(nil), c.fields...), fields...)
+	return &clone
+}
+
+func (c *otelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write emits ent; the context carried by WithContext, if any, supplies the
+// record's trace context and every other field becomes an attribute
+func (c *otelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ctx := context.Background()
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range append(append([]zapcore.Field(nil), c.fields...), fields...) {
+		if f.Type == zapcore.SkipType {
+			if fc, ok := f.Interface.(context.Context); ok && f.Key == contextKey {
+				ctx = fc
+			}
+			continue
+		}
+		f.AddTo(enc)
+	}
+
+	var rec log.Record
+	rec.SetTimestamp(ent.Time)
+	rec.SetObservedTimestamp(time.Now())
+	rec.SetSeverity(severity(ent.Level))
+	rec.SetSeverityText(ent.Level.String())
+	rec.SetBody(log.StringValue(ent.Message))
+	for k, v := range enc.Fields {
+		rec.AddAttributes(log.KeyValue{Key: k, Value: value(v)})
+	}
+	c.logger.Emit(ctx, rec)
+	return nil
+}
+
+func (c *otelCore) Sync() error {
+	return nil
+}
+
+// severity maps a zap level to its OpenTelemetry severity
+func severity(level zapcore.Level) log.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return log.SeverityDebug
+	case zapcore.InfoLevel:
+		return log.SeverityInfo
+	case zapcore.WarnLevel:
+		return log.SeverityWarn
+	case zapcore.ErrorLevel:
+		return log.SeverityError
+	default:
+		return log.SeverityFatal
+	}
+}
+
+// value converts a field value as zapcore.MapObjectEncoder stores it
+func value(v interface{}) log.Value {
+	switch val := v.(type) {
+	case string:
+		return log.StringValue(val)
+	case bool:
+		return log.BoolValue(val)
+	case int64:
+		return log.Int64Value(val)
+	case int:
+		return log.IntValue(val)
+	case float64:
+		return log.Float64Value(val)
+	case time.Duration:
+		return log.Int64Value(int64(val))
+	case time.Time:
+		return log.StringValue(val.Format(time.RFC3339Nano))
+	default:
+		return log.StringValue(fmt.Sprint(val))
+	}
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// memoryExporter keeps exported log records
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func TestExportedRecordsCarryTheActiveTrace(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	core, stdout := observer.New(level)
+	l := New(zap.New(core), level)
+	exporter := &memoryExporter{}
+	l.ExportTo(sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter))), "test")
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	sc := span.SpanContext()
+
+	l.WithContext(ctx).Warn("Publishing price drop failed", nil, zap.String("product_id", "p-1"))
+	l.Debug("hidden")
+	l.Info("no span")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("expected 2 exported records at info and above, got %d", len(exporter.records))
+	}
+	traced := exporter.records[0]
+	if traced.TraceID() != sc.TraceID() || traced.SpanID() != sc.SpanID() {
+		t.Fatalf("expected trace %s span %s, got %s %s", sc.TraceID(), sc.SpanID(), traced.TraceID(), traced.SpanID())
+	}
+	if traced.Severity() != log.SeverityWarn || traced.Body().AsString() != "Publishing price drop failed" {
+		t.Fatalf("unexpected record: %v %v", traced.Severity(), traced.Body())
+	}
+	attrs := map[string]string{}
+	traced.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	if attrs["product_id"] != "p-1" || attrs["trace_id"] != sc.TraceID().String() {
+		t.Fatalf("unexpected attributes: %v", attrs)
+	}
+	if _, ok := attrs[contextKey]; ok {
+		t.Fatal("the context must not be exported as an attribute")
+	}
+	if exporter.records[1].TraceID().IsValid() {
+		t.Fatal("a record logged without a span must have no trace ID")
+	}
+
+	// stdout keeps every entry and shows the same trace ID
+	if stdout.Len() != 2 || stdout.All()[0].ContextMap()["trace_id"] != sc.TraceID().String() {
+		t.Fatalf("unexpected stdout entries: %+v", stdout.All())
+	}
+}